@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// bulkResult is the outcome of a bulk operation on one container
+type bulkResult struct {
+	Name string
+	Err  error
+}
+
+// runBulk applies op to every name using at most parallel concurrent calls.
+// Once ctx is cancelled, names that have not been started are reported with
+// the context error. Results keep the order of names.
+func runBulk(ctx context.Context, names []string, parallel int, op func(ctx context.Context, name string) error, progress func(done, total int)) []bulkResult {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	results := make([]bulkResult, len(names))
+	jobs := make(chan int)
+	var mutex sync.Mutex
+	done := 0
+
+	var wg sync.WaitGroup
+	for w := 0; w < parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				err := ctx.Err()
+				if err == nil {
+					err = op(ctx, names[i])
+				}
+				results[i] = bulkResult{Name: names[i], Err: err}
+
+				mutex.Lock()
+				done++
+				if progress != nil {
+					progress(done, len(names))
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	for i := range names {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// bulkContainerAction runs a container action such as start or stop on every
+// container matching labels whose running state equals running, showing a
+// progress bar and a summary. Ctrl-C cancels the outstanding operations.
+func bulkContainerAction(action, labels string, running bool, parallel int) {
+	containers, err := listContainers(labels, false)
+	if err != nil {
+		fmt.Printf("❌ Konteynerler listelenemedi: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	var names []string
+	for _, c := range containers {
+		if isRunningStatus(c.Status) == running {
+			names = append(names, c.Name)
+		}
+	}
+	if len(names) == 0 {
+		fmt.Printf("📭 İşlem yapılacak konteyner yok\n")
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("⚙️  %d konteyner için %s işlemi başlatılıyor (paralel: %d)\n", len(names), action, parallel)
+	results := runBulk(ctx, names, parallel, func(ctx context.Context, name string) error {
+		return containerAction(ctx, name, action)
+	}, printProgressBar)
+	fmt.Println()
+
+	var failed []bulkResult
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+
+	if ctx.Err() != nil {
+		fmt.Printf("🛑 İşlem iptal edildi\n")
+	}
+	fmt.Printf("\n📊 Özet: %d başarılı, %d başarısız\n", len(results)-len(failed), len(failed))
+	for _, result := range failed {
+		fmt.Printf("❌ %s: %v\n", result.Name, result.Err)
+	}
+
+	if len(failed) > 0 {
+		os.Exit(exitError)
+	}
+}
+
+// isRunningStatus reports whether a container list status such as "Up 5
+// minutes" describes a running container
+func isRunningStatus(status string) bool {
+	return status == "running" || strings.HasPrefix(status, "Up")
+}
+
+// printProgressBar redraws a progress bar for done out of total items
+func printProgressBar(done, total int) {
+	const width = 30
+	filled := done * width / total
+	fmt.Printf("\r   [%s%s] %d/%d", strings.Repeat("█", filled), strings.Repeat("░", width-filled), done, total)
+}
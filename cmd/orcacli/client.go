@@ -1,27 +1,1204 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
+	"orca/pkg/audit"
+	"orca/pkg/build"
 	"orca/pkg/container"
 	"orca/pkg/scheduler"
 )
 
+// httpClient is shared by all requests to the ORCA server
+var httpClient = &apiClient{Client: &http.Client{}}
+
+// serverAddress is the server as given by --server, kept for messages since
+// serverURL is rewritten for Unix sockets
+var serverAddress string
+
+// apiClient is an HTTP client that scopes requests to the selected namespace
+// and reports connection failures as a serverUnreachableError
+type apiClient struct {
+	*http.Client
+	// namespace is added to every request that does not name one
+	namespace string
+}
+
+// Do sends a request
+func (c *apiClient) Do(req *http.Request) (*http.Response, error) {
+	if c.namespace != "" {
+		query := req.URL.Query()
+		if query.Get("namespace") == "" {
+			query.Set("namespace", c.namespace)
+			req.URL.RawQuery = query.Encode()
+		}
+	}
+
+	resp, err := c.Client.Do(req)
+	return resp, checkReachable(err)
+}
+
+// Get sends a GET request
+func (c *apiClient) Get(target string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", target, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.Do(req)
+}
+
+// Post sends a POST request
+func (c *apiClient) Post(target, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest("POST", target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return c.Do(req)
+}
+
+// serverUnreachableError means no ORCA server is listening at the configured
+// address, which usually means the orchestrator is not running
+type serverUnreachableError struct {
+	address string
+	err     error
+}
+
+func (e *serverUnreachableError) Error() string {
+	return fmt.Sprintf("ORCA sunucusuna ulaşılamıyor: %s. Orchestrator çalışıyor mu?", e.address)
+}
+
+func (e *serverUnreachableError) Unwrap() error {
+	return e.err
+}
+
+// checkReachable wraps errors caused by a refused connection, an unknown host
+// or a missing socket in a serverUnreachableError
+func checkReachable(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var dnsErr *net.DNSError
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ENOENT) || errors.As(err, &dnsErr) {
+		return &serverUnreachableError{address: serverAddress, err: err}
+	}
+	return err
+}
+
+// configureClient points the HTTP client at the server given by --server.
+// A unix:///path/to/orca.sock URL dials the Unix socket instead of TCP.
+func configureClient() {
+	serverAddress = serverURL
+	httpClient.namespace = namespace
+	if !strings.HasPrefix(serverURL, "unix://") {
+		return
+	}
+
+	socketPath := strings.TrimPrefix(serverURL, "unix://")
+	httpClient.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", socketPath)
+		},
+	}
+	serverURL = "http://unix"
+}
+
+// retries is the number of extra attempts made for idempotent requests when
+// the server cannot be reached
+var retries = 3
+
+// retryBaseDelay is the delay before the first retry; it doubles per attempt
+const retryBaseDelay = 500 * time.Millisecond
+
+// getWithRetry performs an idempotent GET, retrying with exponential backoff
+// on connection errors. HTTP error statuses are returned to the caller as is.
+func getWithRetry(target string) (*http.Response, error) {
+	delay := retryBaseDelay
+	for attempt := 0; ; attempt++ {
+		resp, err := httpClient.Get(target)
+		if err == nil || attempt >= retries || !isConnectionError(err) {
+			return resp, err
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// isConnectionError reports whether err means the server could not be reached
+// or dropped the connection, as happens while it restarts
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// serverInfo is the response of the server /info endpoint
+type serverInfo struct {
+	Build  build.Info `json:"build"`
+	Uptime string     `json:"uptime"`
+}
+
+// serverVersion is the response of the server /version endpoint
+type serverVersion struct {
+	build.Info
+	DockerVersion    string `json:"docker_version"`
+	DockerAPIVersion string `json:"docker_api_version"`
+}
+
+// statusError is an error response of the ORCA server
+type statusError struct {
+	status  int
+	message string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.status, e.message)
+}
+
+// httpError returns the error for a response with an unexpected status
+func httpError(status int, message string) error {
+	return &statusError{status: status, message: message}
+}
+
 // HTTP client functions
 
-func createContainer(spec container.ContainerSpec) (*container.Container, error) {
+// specError builds the error for a rejected create request, listing each
+// field problem on its own line when the server reports them
+func specError(status int, body []byte) error {
+	var validation struct {
+		Error  string                     `json:"error"`
+		Errors container.ValidationErrors `json:"errors"`
+	}
+	if json.Unmarshal(body, &validation) != nil || len(validation.Errors) == 0 {
+		return httpError(status, string(body))
+	}
+
+	lines := make([]string, 0, len(validation.Errors))
+	for _, fe := range validation.Errors {
+		lines = append(lines, fmt.Sprintf("  - %s: %s", fe.Field, fe.Message))
+	}
+	return httpError(status, validation.Error+"\n"+strings.Join(lines, "\n"))
+}
+
+// createContainer creates a container. With start set the server also starts
+// it and removes it again if the start fails.
+func createContainer(spec container.ContainerSpec, start bool) (*container.Container, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	createURL := serverURL + "/containers"
+	if start {
+		createURL += "?start=true"
+	}
+
+	resp, err := httpClient.Post(createURL, "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, specError(resp.StatusCode, body)
+	}
+
+	var c container.Container
+	if err := json.NewDecoder(resp.Body).Decode(&c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// listContainers lists containers, optionally filtered by a label selector
+// of the form "key=value,key2=value2"
+func listContainers(labels string, size bool) ([]*container.Container, error) {
+	query := url.Values{}
+	if labels != "" {
+		query.Set("label", labels)
+	}
+	if size {
+		query.Set("size", "true")
+	}
+	if allNamespaces {
+		query.Set("all_namespaces", "true")
+	}
+
+	listURL := serverURL + "/containers"
+	if len(query) > 0 {
+		listURL += "?" + query.Encode()
+	}
+
+	resp, err := getWithRetry(listURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var containers []*container.Container
+	if err := json.NewDecoder(resp.Body).Decode(&containers); err != nil {
+		return nil, err
+	}
+
+	return containers, nil
+}
+
+func startContainer(containerID string) error {
+	return containerAction(context.Background(), containerID, "start")
+}
+
+func stopContainer(containerID string) error {
+	return containerAction(context.Background(), containerID, "stop")
+}
+
+// containerAction posts a container action such as start or stop; the
+// request is aborted when ctx is cancelled
+func containerAction(ctx context.Context, containerID, action string) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", serverURL+"/containers/"+containerID+"/"+action, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return httpError(resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// recreateContainer replaces a container with a fresh one built from its
+// current configuration, pulling the image first if pull is set
+func recreateContainer(containerID string, pull bool) (*container.Container, error) {
+	recreateURL := serverURL + "/containers/" + containerID + "/recreate"
+	if pull {
+		recreateURL += "?pull=true"
+	}
+
+	resp, err := httpClient.Post(recreateURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var c container.Container
+	if err := json.NewDecoder(resp.Body).Decode(&c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+func adoptContainer(containerID, deployment string) (*scheduler.Deployment, error) {
+	adoptURL := serverURL + "/containers/" + containerID + "/adopt"
+	if deployment != "" {
+		adoptURL += "?deployment=" + url.QueryEscape(deployment)
+	}
+
+	resp, err := httpClient.Post(adoptURL, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, specError(resp.StatusCode, body)
+	}
+
+	var d scheduler.Deployment
+	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+func commitContainer(containerID, ref, message, author string) (string, error) {
+	query := url.Values{"ref": {ref}}
+	if message != "" {
+		query.Set("message", message)
+	}
+	if author != "" {
+		query.Set("author", author)
+	}
+
+	resp, err := httpClient.Post(serverURL+"/containers/"+containerID+"/commit?"+query.Encode(), "application/json", nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return "", httpError(resp.StatusCode, string(body))
+	}
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	return result.ID, nil
+}
+
+func removeContainer(containerID string) error {
+	req, err := http.NewRequest("DELETE", serverURL+"/containers/"+containerID+"/remove", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return httpError(resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+func inspectContainer(containerID string) (*container.Container, error) {
+	resp, err := getWithRetry(serverURL + "/containers/" + containerID)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var c container.Container
+	if err := json.NewDecoder(resp.Body).Decode(&c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// updateContainer changes the resource limits of a running container
+func updateContainer(containerID string, resources container.Resources) (*container.Container, error) {
+	data, err := json.Marshal(resources)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", serverURL+"/containers/"+containerID, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var c container.Container
+	if err := json.NewDecoder(resp.Body).Decode(&c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// setRestartPolicy changes the restart policy of a container
+func setRestartPolicy(containerID string, policy string) (*container.Container, error) {
+	data, err := json.Marshal(map[string]string{"restart_policy": policy})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", serverURL+"/containers/"+containerID+"/restart-policy", bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var c container.Container
+	if err := json.NewDecoder(resp.Body).Decode(&c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// followContainerLogs streams container logs to out until the container stops
+func followContainerLogs(containerID string, req logsRequest, out io.Writer) error {
+	query := req.query()
+	query.Set("follow", "true")
+
+	resp, err := httpClient.Get(logsURL(containerID, query))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return httpError(resp.StatusCode, string(body))
+	}
+
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
+
+// attachContainer streams the output of the main process of a running
+// container to out until the process exits. If stdin is not nil it is sent
+// to the process.
+func attachContainer(containerID string, stdin io.Reader, out io.Writer) error {
+	attachURL := serverURL + "/containers/" + containerID + "/attach"
+	if stdin != nil {
+		attachURL += "?stdin=true"
+	}
+
+	req, err := http.NewRequest("POST", attachURL, stdin)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return httpError(resp.StatusCode, string(body))
+	}
+
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
+
+func getContainerChanges(containerID string) ([]container.FilesystemChange, error) {
+	resp, err := getWithRetry(serverURL + "/containers/" + containerID + "/changes")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var changes []container.FilesystemChange
+	if err := json.NewDecoder(resp.Body).Decode(&changes); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// getContainerStats fetches a resource usage sample of a container
+func getContainerStats(containerID string) (*container.ContainerStats, error) {
+	resp, err := getWithRetry(serverURL + "/containers/" + containerID + "/stats")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var stats container.ContainerStats
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// getContainerTop fetches the process table of a container
+func getContainerTop(containerID string) (*container.ContainerProcesses, error) {
+	resp, err := getWithRetry(serverURL + "/containers/" + containerID + "/top")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var processes container.ContainerProcesses
+	if err := json.NewDecoder(resp.Body).Decode(&processes); err != nil {
+		return nil, err
+	}
+
+	return &processes, nil
+}
+
+// logsRequest selects the logs returned by the logs endpoints
+type logsRequest struct {
+	Tail       string
+	Grep       string
+	Since      string
+	Timestamps bool
+	MaxBytes   int64
+	// Previous reads the last exited container of a replica
+	Previous bool
+}
+
+// query encodes the request as logs endpoint query parameters
+func (r logsRequest) query() url.Values {
+	query := url.Values{}
+	query.Set("tail", r.Tail)
+	if r.Grep != "" {
+		query.Set("grep", r.Grep)
+	}
+	if r.Since != "" {
+		query.Set("since", r.Since)
+	}
+	if r.Timestamps {
+		query.Set("timestamps", "true")
+	}
+	if r.MaxBytes > 0 {
+		query.Set("max_bytes", strconv.FormatInt(r.MaxBytes, 10))
+	}
+	if r.Previous {
+		query.Set("previous", "true")
+	}
+	return query
+}
+
+// logsURL returns the logs URL of a container, or of a whole deployment for
+// targets of the form deployment/<name>
+func logsURL(target string, query url.Values) string {
+	if name := strings.TrimPrefix(target, "deployment/"); name != target {
+		return fmt.Sprintf("%s/deployments/%s/logs?%s", serverURL, name, query.Encode())
+	}
+	return fmt.Sprintf("%s/containers/%s/logs?%s", serverURL, target, query.Encode())
+}
+
+// getContainerLogs fetches the logs of a container or deployment target. The
+// returned flag reports whether the server truncated the logs.
+func getContainerLogs(target string, req logsRequest) (string, bool, error) {
+	resp, err := getWithRetry(logsURL(target, req.query()))
+	if err != nil {
+		return "", false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return "", false, httpError(resp.StatusCode, string(body))
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", false, err
+	}
+
+	truncated := resp.Header.Get("X-Orca-Logs-Truncated") == "true"
+	return string(body), truncated, nil
+}
+
+// downloadContainerLogs writes container logs to out as a file download and
+// reports the number of bytes written so far to progress
+func downloadContainerLogs(target string, req logsRequest, out io.Writer, progress func(written, total int64)) (int64, bool, error) {
+	query := req.query()
+	query.Set("download", "true")
+
+	resp, err := getWithRetry(logsURL(target, query))
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return 0, false, httpError(resp.StatusCode, string(body))
+	}
+
+	pw := &progressWriter{w: out, total: resp.ContentLength, report: progress}
+	written, err := io.Copy(pw, resp.Body)
+	if err != nil {
+		return written, false, err
+	}
+
+	truncated := resp.Header.Get("X-Orca-Logs-Truncated") == "true"
+	return written, truncated, nil
+}
+
+// exportContainer writes the filesystem of a container to out as a tar
+// archive and reports the number of bytes written so far to progress
+func exportContainer(containerID string, out io.Writer, progress func(written, total int64)) (int64, error) {
+	resp, err := getWithRetry(serverURL + "/containers/" + containerID + "/export")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return 0, httpError(resp.StatusCode, string(body))
+	}
+
+	pw := &progressWriter{w: out, total: resp.ContentLength, report: progress}
+	return io.Copy(pw, resp.Body)
+}
+
+// catFile writes the contents of a single file of a container to out
+func catFile(containerID, filePath string, out io.Writer) error {
+	resp, err := getWithRetry(serverURL + "/containers/" + containerID + "/file?path=" + url.QueryEscape(filePath))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return httpError(resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
+
+// progressWriter reports the running byte count after every write
+type progressWriter struct {
+	w       io.Writer
+	written int64
+	total   int64
+	report  func(written, total int64)
+}
+
+// Write writes p and reports progress
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	if p.report != nil {
+		p.report(p.written, p.total)
+	}
+	return n, err
+}
+
+// createDeploymentWithProgress creates a deployment, passing the progress
+// the server streams as server-sent events to handle. With partial the
+// replicas created before one fails are kept.
+func createDeploymentWithProgress(spec container.DeploymentSpec, partial bool, handle func(scheduler.Progress)) (*scheduler.Deployment, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("progress", "true")
+	if partial {
+		query.Set("partial", "true")
+	}
+	resp, err := httpClient.Post(serverURL+"/deployments?"+query.Encode(), "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, specError(resp.StatusCode, body)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	event := ""
+	for scanner.Scan() {
+		line := scanner.Text()
+		if name, ok := strings.CutPrefix(line, "event: "); ok {
+			event = name
+			continue
+		}
+		payload, ok := strings.CutPrefix(line, "data: ")
+		if !ok {
+			continue
+		}
+
+		switch event {
+		case "progress":
+			var progress scheduler.Progress
+			if err := json.Unmarshal([]byte(payload), &progress); err != nil {
+				return nil, err
+			}
+			handle(progress)
+		case "error":
+			var failure struct {
+				Status int    `json:"status"`
+				Error  string `json:"error"`
+			}
+			if err := json.Unmarshal([]byte(payload), &failure); err != nil {
+				return nil, err
+			}
+			return nil, httpError(failure.Status, failure.Error)
+		case "deployment":
+			var deployment scheduler.Deployment
+			if err := json.Unmarshal([]byte(payload), &deployment); err != nil {
+				return nil, err
+			}
+			return &deployment, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("sunucu deployment sonucunu göndermeden bağlantıyı kapattı")
+}
+
+// deploymentExists reports whether a deployment exists in namespace, or in
+// the selected namespace if namespace is empty
+func deploymentExists(namespace, name string) (bool, error) {
+	getURL := serverURL + "/deployments/" + name
+	if namespace != "" {
+		getURL += "?namespace=" + url.QueryEscape(namespace)
+	}
+
+	resp, err := getWithRetry(getURL)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		body, _ := ioutil.ReadAll(resp.Body)
+		return false, httpError(resp.StatusCode, string(body))
+	}
+}
+
+// getDeployment fetches a single deployment with its replicas
+func getDeployment(name string) (*scheduler.Deployment, error) {
+	resp, err := getWithRetry(serverURL + "/deployments/" + name)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var d scheduler.Deployment
+	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// getDeploymentStatus fetches the live replica summary of a deployment
+func getDeploymentStatus(name string) (*scheduler.DeploymentStatus, error) {
+	resp, err := getWithRetry(serverURL + "/deployments/" + name + "/status")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var status scheduler.DeploymentStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// deploymentPageSize is the page size used when listing all deployments
+const deploymentPageSize = 100
+
+// listDeploymentsPage fetches one page of deployments, optionally filtered by
+// selector, and returns it together with the total number of matches
+func listDeploymentsPage(selector string, limit, offset int) ([]*scheduler.Deployment, int, error) {
+	query := url.Values{}
+	if selector != "" {
+		query.Set("selector", selector)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		query.Set("offset", strconv.Itoa(offset))
+	}
+	if allNamespaces {
+		query.Set("all_namespaces", "true")
+	}
+
+	resp, err := getWithRetry(serverURL + "/deployments?" + query.Encode())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, 0, httpError(resp.StatusCode, string(body))
+	}
+
+	var deployments []*scheduler.Deployment
+	if err := json.NewDecoder(resp.Body).Decode(&deployments); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := strconv.Atoi(resp.Header.Get("X-Total-Count"))
+	if err != nil {
+		total = offset + len(deployments)
+	}
+
+	return deployments, total, nil
+}
+
+// listDeployments fetches every deployment matching selector, page by page
+func listDeployments(selector string) ([]*scheduler.Deployment, error) {
+	var deployments []*scheduler.Deployment
+	for {
+		page, total, err := listDeploymentsPage(selector, deploymentPageSize, len(deployments))
+		if err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, page...)
+		if len(page) == 0 || len(deployments) >= total {
+			return deployments, nil
+		}
+	}
+}
+
+// deleteDeployment deletes a deployment, also removing its shared volume
+// when removeVolume is set
+func deleteDeployment(name string, removeVolume bool) error {
+	deleteURL := serverURL + "/deployments/" + name
+	if removeVolume {
+		deleteURL += "?remove_volume=true"
+	}
+
+	req, err := http.NewRequest("DELETE", deleteURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return httpError(resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+func getScale(name string) (*scheduler.ScaleStatus, error) {
+	resp, err := httpClient.Get(serverURL + "/deployments/" + name + "/scale")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var status scheduler.ScaleStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+func scaleDeployment(name string, replicas int) (*scheduler.ScaleStatus, error) {
+	data, err := json.Marshal(map[string]int{"replicas": replicas})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", serverURL+"/deployments/"+name+"/scale", bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var status scheduler.ScaleStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+func restartDeployment(name string) (*scheduler.Deployment, error) {
+	resp, err := httpClient.Post(serverURL+"/deployments/"+name+"/restart", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var deployment scheduler.Deployment
+	if err := json.NewDecoder(resp.Body).Decode(&deployment); err != nil {
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
+// updateDeployment rolls out a new spec to a deployment. canary is empty,
+// a replica count or a percentage such as "25%".
+func updateDeployment(spec container.DeploymentSpec, canary string, autoPromote time.Duration, forceRecreate bool) (*scheduler.Deployment, error) {
+	resp, err := putDeploymentUpdate(spec, canary, autoPromote, forceRecreate, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, specError(resp.StatusCode, body)
+	}
+
+	var deployment scheduler.Deployment
+	if err := json.NewDecoder(resp.Body).Decode(&deployment); err != nil {
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
+func planUpdateDeployment(spec container.DeploymentSpec, canary string, autoPromote time.Duration, forceRecreate bool) (*scheduler.Plan, error) {
+	resp, err := putDeploymentUpdate(spec, canary, autoPromote, forceRecreate, true)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return decodePlan(resp)
+}
+
+// putDeploymentUpdate sends a spec update of a deployment, or with dryRun
+// asks for its plan
+func putDeploymentUpdate(spec container.DeploymentSpec, canary string, autoPromote time.Duration, forceRecreate, dryRun bool) (*http.Response, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	if canary != "" {
+		query.Set("canary", canary)
+	}
+	if autoPromote > 0 {
+		query.Set("auto_promote", autoPromote.String())
+	}
+	if forceRecreate {
+		query.Set("force_recreate", "true")
+	}
+	if dryRun {
+		query.Set("dry_run", "true")
+	}
+	updateURL := serverURL + "/deployments/" + spec.Name
+	if len(query) > 0 {
+		updateURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest("PUT", updateURL, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return httpClient.Do(req)
+}
+
+// promoteDeployment rolls a paused canary out to every replica
+func promoteDeployment(name string) (*scheduler.Deployment, error) {
+	return postRollout(name, "promote")
+}
+
+// abortRollout reverts a paused canary to the previous spec
+func abortRollout(name string) (*scheduler.Deployment, error) {
+	return postRollout(name, "abort")
+}
+
+// postRollout sends a promote or abort request for the rollout of a deployment
+func postRollout(name, action string) (*scheduler.Deployment, error) {
+	resp, err := httpClient.Post(serverURL+"/deployments/"+name+"/"+action, "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var deployment scheduler.Deployment
+	if err := json.NewDecoder(resp.Body).Decode(&deployment); err != nil {
+		return nil, err
+	}
+
+	return &deployment, nil
+}
+
+func planScaleDeployment(name string, replicas int) (*scheduler.Plan, error) {
+	data, err := json.Marshal(map[string]int{"replicas": replicas})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", serverURL+"/deployments/"+name+"/scale?dry_run=true", bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return decodePlan(resp)
+}
+
+func planRestartDeployment(name string) (*scheduler.Plan, error) {
+	resp, err := httpClient.Post(serverURL+"/deployments/"+name+"/restart?dry_run=true", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return decodePlan(resp)
+}
+
+func planAbortRollout(name string) (*scheduler.Plan, error) {
+	resp, err := httpClient.Post(serverURL+"/deployments/"+name+"/abort?dry_run=true", "application/json", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return decodePlan(resp)
+}
+
+// decodePlan reads the plan of a dry run from a response
+func decodePlan(resp *http.Response) (*scheduler.Plan, error) {
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var plan scheduler.Plan
+	if err := json.NewDecoder(resp.Body).Decode(&plan); err != nil {
+		return nil, err
+	}
+
+	return &plan, nil
+}
+
+func batchDeleteDeployments(selector map[string]string) ([]scheduler.BatchDeleteResult, error) {
+	return batchDelete(serverURL+"/deployments/batch-delete", selector)
+}
+
+func createService(spec container.ServiceSpec) (*scheduler.Service, error) {
 	data, err := json.Marshal(spec)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.Post(serverURL+"/containers", "application/json", bytes.NewBuffer(data))
+	resp, err := httpClient.Post(serverURL+"/services", "application/json", bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
@@ -29,19 +1206,24 @@ func createContainer(spec container.ContainerSpec) (*container.Container, error)
 
 	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, specError(resp.StatusCode, body)
 	}
 
-	var c container.Container
-	if err := json.NewDecoder(resp.Body).Decode(&c); err != nil {
+	var service scheduler.Service
+	if err := json.NewDecoder(resp.Body).Decode(&service); err != nil {
 		return nil, err
 	}
 
-	return &c, nil
+	return &service, nil
 }
 
-func listContainers() ([]*container.Container, error) {
-	resp, err := http.Get(serverURL + "/containers")
+func listServices() ([]*scheduler.Service, error) {
+	listURL := serverURL + "/services"
+	if allNamespaces {
+		listURL += "?all_namespaces=true"
+	}
+
+	resp, err := getWithRetry(listURL)
 	if err != nil {
 		return nil, err
 	}
@@ -49,34 +1231,44 @@ func listContainers() ([]*container.Container, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, httpError(resp.StatusCode, string(body))
 	}
 
-	var containers []*container.Container
-	if err := json.NewDecoder(resp.Body).Decode(&containers); err != nil {
+	var services []*scheduler.Service
+	if err := json.NewDecoder(resp.Body).Decode(&services); err != nil {
 		return nil, err
 	}
 
-	return containers, nil
+	return services, nil
 }
 
-func startContainer(containerID string) error {
-	resp, err := http.Post(serverURL+"/containers/"+containerID+"/start", "application/json", nil)
+func getService(name string) (*scheduler.Service, error) {
+	resp, err := getWithRetry(serverURL + "/services/" + name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, httpError(resp.StatusCode, string(body))
 	}
 
-	return nil
+	var service scheduler.Service
+	if err := json.NewDecoder(resp.Body).Decode(&service); err != nil {
+		return nil, err
+	}
+
+	return &service, nil
 }
 
-func stopContainer(containerID string) error {
-	resp, err := http.Post(serverURL+"/containers/"+containerID+"/stop", "application/json", nil)
+func deleteService(name string) error {
+	req, err := http.NewRequest("DELETE", serverURL+"/services/"+name, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return err
 	}
@@ -84,20 +1276,73 @@ func stopContainer(containerID string) error {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return httpError(resp.StatusCode, string(body))
 	}
 
 	return nil
 }
 
-func removeContainer(containerID string) error {
-	req, err := http.NewRequest("DELETE", serverURL+"/containers/"+containerID+"/remove", nil)
+func batchDeleteServices(selector map[string]string) ([]scheduler.BatchDeleteResult, error) {
+	return batchDelete(serverURL+"/services/batch-delete", selector)
+}
+
+func createSecret(secret container.Secret) (*container.Secret, error) {
+	data, err := json.Marshal(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Post(serverURL+"/secrets", "application/json", bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, specError(resp.StatusCode, body)
+	}
+
+	var created container.Secret
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func listSecrets() ([]*container.Secret, error) {
+	listURL := serverURL + "/secrets"
+	if allNamespaces {
+		listURL += "?all_namespaces=true"
+	}
+
+	resp, err := getWithRetry(listURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var secrets []*container.Secret
+	if err := json.NewDecoder(resp.Body).Decode(&secrets); err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+func deleteSecret(name string) error {
+	req, err := http.NewRequest("DELETE", serverURL+"/secrets/"+name, nil)
 	if err != nil {
 		return err
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		return err
 	}
@@ -105,14 +1350,19 @@ func removeContainer(containerID string) error {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return httpError(resp.StatusCode, string(body))
 	}
 
 	return nil
 }
 
-func inspectContainer(containerID string) (*container.Container, error) {
-	resp, err := http.Get(serverURL + "/containers/" + containerID)
+func batchDelete(url string, selector map[string]string) ([]scheduler.BatchDeleteResult, error) {
+	data, err := json.Marshal(map[string]interface{}{"selector": selector})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Post(url, "application/json", bytes.NewBuffer(data))
 	if err != nil {
 		return nil, err
 	}
@@ -120,66 +1370,111 @@ func inspectContainer(containerID string) (*container.Container, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, httpError(resp.StatusCode, string(body))
 	}
 
-	var c container.Container
-	if err := json.NewDecoder(resp.Body).Decode(&c); err != nil {
+	var results []scheduler.BatchDeleteResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
 		return nil, err
 	}
 
-	return &c, nil
+	return results, nil
 }
 
-func getContainerLogs(containerID string, tail int) (string, error) {
-	url := fmt.Sprintf("%s/containers/%s/logs?tail=%d", serverURL, containerID, tail)
-	
-	resp, err := http.Get(url)
+// listOrphans lists replica containers whose deployment no longer exists
+// getEvents fetches the recent events of a deployment or service, only
+// those after since if it is set
+func getEvents(kind, name, since string) ([]scheduler.Event, error) {
+	query := url.Values{"kind": {kind}, "name": {name}}
+	if since != "" {
+		query.Set("since", since)
+	}
+	resp, err := getWithRetry(serverURL + "/events?" + query.Encode())
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return "", fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, httpError(resp.StatusCode, string(body))
 	}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
+	var events []scheduler.Event
+	if err := json.NewDecoder(resp.Body).Decode(&events); err != nil {
+		return nil, err
 	}
 
-	return string(body), nil
+	return events, nil
 }
 
-func createDeployment(spec container.DeploymentSpec) (*scheduler.Deployment, error) {
-	data, err := json.Marshal(spec)
+// watchEvents streams container events to handle until the server closes the
+// stream
+func watchEvents(actions []string, name, since string, handle func(container.ContainerEvent)) error {
+	query := url.Values{}
+	if len(actions) > 0 {
+		query.Set("type", strings.Join(actions, ","))
+	}
+	if name != "" {
+		query.Set("name", name)
+	}
+	if since != "" {
+		query.Set("since", since)
+	}
+	if allNamespaces {
+		query.Set("all_namespaces", "true")
+	}
+
+	resp, err := httpClient.Get(serverURL + "/events?" + query.Encode())
 	if err != nil {
-		return nil, err
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return httpError(resp.StatusCode, string(body))
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var event container.ContainerEvent
+		if err := decoder.Decode(&event); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		handle(event)
 	}
+}
 
-	resp, err := http.Post(serverURL+"/deployments", "application/json", bytes.NewBuffer(data))
+func getAudit(tail int, since time.Time) ([]audit.Entry, error) {
+	query := url.Values{"tail": {strconv.Itoa(tail)}}
+	if !since.IsZero() {
+		query.Set("since", since.Format(time.RFC3339Nano))
+	}
+	resp, err := getWithRetry(serverURL + "/audit?" + query.Encode())
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, httpError(resp.StatusCode, string(body))
 	}
 
-	var deployment scheduler.Deployment
-	if err := json.NewDecoder(resp.Body).Decode(&deployment); err != nil {
+	var entries []audit.Entry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
 		return nil, err
 	}
 
-	return &deployment, nil
+	return entries, nil
 }
 
-func listDeployments() ([]*scheduler.Deployment, error) {
-	resp, err := http.Get(serverURL + "/deployments")
+func listOrphans() ([]*container.Container, error) {
+	resp, err := getWithRetry(serverURL + "/orphans")
 	if err != nil {
 		return nil, err
 	}
@@ -187,65 +1482,133 @@ func listDeployments() ([]*scheduler.Deployment, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, httpError(resp.StatusCode, string(body))
 	}
 
-	var deployments []*scheduler.Deployment
-	if err := json.NewDecoder(resp.Body).Decode(&deployments); err != nil {
+	var orphans []*container.Container
+	if err := json.NewDecoder(resp.Body).Decode(&orphans); err != nil {
 		return nil, err
 	}
 
-	return deployments, nil
+	return orphans, nil
 }
 
-func deleteDeployment(name string) error {
-	req, err := http.NewRequest("DELETE", serverURL+"/deployments/"+name, nil)
+// listNetworks lists the Docker networks on the server host
+func listNetworks() ([]*container.Network, error) {
+	resp, err := getWithRetry(serverURL + "/networks")
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var networks []*container.Network
+	if err := json.NewDecoder(resp.Body).Decode(&networks); err != nil {
+		return nil, err
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	return networks, nil
+}
+
+// inspectNetwork returns a Docker network by name or ID
+func inspectNetwork(name string) (*container.Network, error) {
+	resp, err := getWithRetry(serverURL + "/networks/" + name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, httpError(resp.StatusCode, string(body))
 	}
 
-	return nil
+	var network container.Network
+	if err := json.NewDecoder(resp.Body).Decode(&network); err != nil {
+		return nil, err
+	}
+
+	return &network, nil
 }
 
-func createService(spec container.ServiceSpec) (*scheduler.Service, error) {
-	data, err := json.Marshal(spec)
+// formatSubnets formats the subnets of a network with their gateways
+func formatSubnets(subnets []container.NetworkSubnet) string {
+	if len(subnets) == 0 {
+		return "-"
+	}
+
+	parts := make([]string, 0, len(subnets))
+	for _, subnet := range subnets {
+		if subnet.Gateway != "" {
+			parts = append(parts, fmt.Sprintf("%s (gw %s)", subnet.Subnet, subnet.Gateway))
+		} else {
+			parts = append(parts, subnet.Subnet)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// systemPrune removes unused Docker resources on the server host
+func systemPrune(volumes bool, until string) (*container.PruneReport, error) {
+	query := url.Values{}
+	if volumes {
+		query.Set("volumes", "true")
+	}
+	if until != "" {
+		query.Set("until", until)
+	}
+	pruneURL := serverURL + "/system/prune"
+	if len(query) > 0 {
+		pruneURL += "?" + query.Encode()
+	}
+
+	resp, err := httpClient.Post(pruneURL, "application/json", nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var report container.PruneReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
 
-	resp, err := http.Post(serverURL+"/services", "application/json", bytes.NewBuffer(data))
+// pruneOrphans removes replica containers whose deployment no longer exists
+func pruneOrphans() ([]scheduler.BatchDeleteResult, error) {
+	resp, err := httpClient.Post(serverURL+"/orphans/prune", "application/json", nil)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, httpError(resp.StatusCode, string(body))
 	}
 
-	var service scheduler.Service
-	if err := json.NewDecoder(resp.Body).Decode(&service); err != nil {
+	var results []scheduler.BatchDeleteResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
 		return nil, err
 	}
 
-	return &service, nil
+	return results, nil
 }
 
-func listServices() ([]*scheduler.Service, error) {
-	resp, err := http.Get(serverURL + "/services")
+// getReconcileStatus fetches the state of the reconcile loop
+func getReconcileStatus() (*scheduler.ReconcileStatus, error) {
+	resp, err := getWithRetry(serverURL + "/reconcile/status")
 	if err != nil {
 		return nil, err
 	}
@@ -253,40 +1616,45 @@ func listServices() ([]*scheduler.Service, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, httpError(resp.StatusCode, string(body))
 	}
 
-	var services []*scheduler.Service
-	if err := json.NewDecoder(resp.Body).Decode(&services); err != nil {
+	var status scheduler.ReconcileStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
 		return nil, err
 	}
 
-	return services, nil
+	return &status, nil
 }
 
-func deleteService(name string) error {
-	req, err := http.NewRequest("DELETE", serverURL+"/services/"+name, nil)
-	if err != nil {
-		return err
+// setReconcilePaused pauses or resumes the reconcile loop
+func setReconcilePaused(paused bool) (*scheduler.ReconcileStatus, error) {
+	action := "resume"
+	if paused {
+		action = "pause"
 	}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := httpClient.Post(serverURL+"/reconcile/"+action, "application/json", nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, httpError(resp.StatusCode, string(body))
 	}
 
-	return nil
+	var status scheduler.ReconcileStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
 }
 
 func getStats() (map[string]interface{}, error) {
-	resp, err := http.Get(serverURL + "/stats")
+	resp, err := getWithRetry(serverURL + "/stats")
 	if err != nil {
 		return nil, err
 	}
@@ -294,7 +1662,7 @@ func getStats() (map[string]interface{}, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, httpError(resp.StatusCode, string(body))
 	}
 
 	var stats map[string]interface{}
@@ -305,6 +1673,57 @@ func getStats() (map[string]interface{}, error) {
 	return stats, nil
 }
 
+// getServerVersion fetches the build and Docker versions of the server
+func getServerVersion() (*serverVersion, error) {
+	resp, err := getWithRetry(serverURL + "/version")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var version serverVersion
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// majorVersion returns the major component of a semantic version such as
+// v1.2.3, or "" when version is not of that form (e.g. dev builds)
+func majorVersion(version string) string {
+	major, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), ".")
+	if _, err := strconv.Atoi(major); err != nil {
+		return ""
+	}
+	return major
+}
+
+func getServerInfo() (*serverInfo, error) {
+	resp, err := getWithRetry(serverURL + "/info")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, httpError(resp.StatusCode, string(body))
+	}
+
+	var info serverInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
 // Utility functions for formatting output
 
 func truncateString(s string, length int) string {
@@ -314,6 +1733,24 @@ func truncateString(s string, length int) string {
 	return s[:length]
 }
 
+// formatHealthCheck summarizes a container health check on one line
+func formatHealthCheck(check *container.HealthCheck) string {
+	parts := []string{strings.Join(check.Test, " ")}
+	if check.Interval != "" {
+		parts = append(parts, "aralık "+check.Interval)
+	}
+	if check.Timeout != "" {
+		parts = append(parts, "zaman aşımı "+check.Timeout)
+	}
+	if check.StartPeriod != "" {
+		parts = append(parts, "başlangıç "+check.StartPeriod)
+	}
+	if check.Retries > 0 {
+		parts = append(parts, fmt.Sprintf("%d deneme", check.Retries))
+	}
+	return strings.Join(parts, ", ")
+}
+
 func formatPorts(ports map[string]string) string {
 	if len(ports) == 0 {
 		return "-"
@@ -327,6 +1764,151 @@ func formatPorts(ports map[string]string) string {
 	return strings.Join(portStrings, ", ")
 }
 
+// parseKeyValues parses repeated KEY=VALUE flag values into a map
+func parseKeyValues(values []string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("geçersiz KEY=VALUE değeri: %s", value)
+		}
+		result[parts[0]] = parts[1]
+	}
+	return result, nil
+}
+
+// parsePortFlags parses repeated hostPort:containerPort[/protocol] flag values
+// into a ContainerSpec port map
+// readSpecFile reads a spec file, or standard input when path is "-" so
+// generated specs can be piped in
+func readSpecFile(path string) ([]byte, error) {
+	if path == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(path)
+}
+
+// parseVolumeFlags parses volume flags of the form source:destination[:ro]
+func parseVolumeFlags(values []string) ([]container.VolumeMount, error) {
+	var result []container.VolumeMount
+	for _, value := range values {
+		parts := strings.Split(value, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("geçersiz volume değeri: %s (kaynak:hedef[:ro] olmalı)", value)
+		}
+		volume := container.VolumeMount{Source: parts[0], Destination: parts[1]}
+		if len(parts) == 3 {
+			switch parts[2] {
+			case "ro":
+				volume.ReadOnly = true
+			case "rw":
+			default:
+				return nil, fmt.Errorf("geçersiz volume modu: %s (ro veya rw olmalı)", parts[2])
+			}
+		}
+		result = append(result, volume)
+	}
+	return result, nil
+}
+
+// mergeValues merges overrides over base, or replaces base with overrides
+// when replace is set. Base is returned unchanged when there are no overrides.
+func mergeValues(base, overrides map[string]string, replace bool) map[string]string {
+	if len(overrides) == 0 {
+		return base
+	}
+	result := make(map[string]string, len(base)+len(overrides))
+	if !replace {
+		for key, value := range base {
+			result[key] = value
+		}
+	}
+	for key, value := range overrides {
+		result[key] = value
+	}
+	return result
+}
+
+func parsePortFlags(values []string) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, value := range values {
+		parts := strings.SplitN(value, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("geçersiz port değeri: %s (hostPort:containerPort olmalı)", value)
+		}
+		result[parts[1]] = parts[0]
+	}
+	return result, nil
+}
+
+// parseUlimitFlags parses --ulimit values of the form name=soft[:hard]
+func parseUlimitFlags(values []string) ([]container.Ulimit, error) {
+	ulimits := make([]container.Ulimit, 0, len(values))
+	for _, value := range values {
+		ulimit, err := container.ParseUlimit(value)
+		if err != nil {
+			return nil, err
+		}
+		ulimits = append(ulimits, ulimit)
+	}
+	return ulimits, nil
+}
+
+// mergeUlimits returns base with the ulimits in overrides added, replacing
+// those of the same name
+func mergeUlimits(base, overrides []container.Ulimit) []container.Ulimit {
+	result := make([]container.Ulimit, 0, len(base)+len(overrides))
+	for _, ulimit := range base {
+		overridden := false
+		for _, override := range overrides {
+			if override.Name == ulimit.Name {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			result = append(result, ulimit)
+		}
+	}
+	return append(result, overrides...)
+}
+
+// containerNameFromImage derives a unique container name from an image reference
+func containerNameFromImage(image string) string {
+	name := image
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.IndexAny(name, ":@"); i >= 0 {
+		name = name[:i]
+	}
+	return fmt.Sprintf("%s-%d", name, time.Now().Unix())
+}
+
+func printBatchDeleteResults(kind string, results []scheduler.BatchDeleteResult) {
+	if len(results) == 0 {
+		fmt.Printf("Selector ile eşleşen %s bulunamadı.\n", kind)
+		return
+	}
+
+	for _, result := range results {
+		if result.Deleted {
+			fmt.Printf("%s silindi: %s\n", kind, result.Name)
+		} else {
+			fmt.Printf("%s silinemedi: %s (%s)\n", kind, result.Name, result.Error)
+		}
+	}
+}
+
+// formatServiceStatus shows the status of a service with the number of
+// endpoints that passed its last health check
+func formatServiceStatus(svc *scheduler.Service) string {
+	if svc.Health == nil {
+		return svc.Status
+	}
+	return fmt.Sprintf("%s (%d/%d)", svc.Status, svc.Health.Healthy, svc.Health.Total)
+}
+
 func formatServicePorts(ports []container.ServicePort) string {
 	if len(ports) == 0 {
 		return "-"
@@ -334,11 +1916,17 @@ func formatServicePorts(ports []container.ServicePort) string {
 
 	var portStrings []string
 	for _, port := range ports {
+		portString := strconv.Itoa(port.Port)
 		if port.TargetPort != 0 {
-			portStrings = append(portStrings, fmt.Sprintf("%d:%d", port.Port, port.TargetPort))
-		} else {
-			portStrings = append(portStrings, strconv.Itoa(port.Port))
+			portString = fmt.Sprintf("%d:%d", port.Port, port.TargetPort)
+		}
+		if port.NodePort != 0 {
+			portString = fmt.Sprintf("%d:%d→%d", port.NodePort, port.Port, port.TargetPort)
+		}
+		if container.ServicePortProtocol(port) != "tcp" {
+			portString += "/" + container.ServicePortProtocol(port)
 		}
+		portStrings = append(portStrings, portString)
 	}
 
 	return strings.Join(portStrings, ", ")
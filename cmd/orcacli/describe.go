@@ -0,0 +1,336 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"orca/pkg/container"
+	"orca/pkg/scheduler"
+
+	"github.com/docker/go-units"
+)
+
+// describeDeployment prints a deployment with the live state of its
+// replicas, the services in front of it and its recent events, optionally
+// only those since a time filter
+func describeDeployment(out io.Writer, name, eventsSince string) error {
+	deployment, err := getDeployment(name)
+	if err != nil {
+		return err
+	}
+	spec := deployment.Spec
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Ad:\t%s\n", deployment.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", container.NormalizeNamespace(spec.Namespace))
+	fmt.Fprintf(w, "Oluşturulma:\t%s (%s önce)\n", deployment.Created.Format("2006-01-02 15:04:05"), since(deployment.Created))
+	fmt.Fprintf(w, "Durum:\t%s\n", deployment.Status)
+	fmt.Fprintf(w, "Image:\t%s\n", spec.Container.Image)
+	if deployment.ImageDigest != "" {
+		fmt.Fprintf(w, "Sabit digest:\t%s\n", deployment.ImageDigest)
+	}
+	fmt.Fprintf(w, "Strateji:\t%s\n", valueOr(spec.Strategy, container.StrategyRollingUpdate))
+	if len(spec.DependsOn) > 0 {
+		fmt.Fprintf(w, "Bağımlılıklar:\t%s\n", strings.Join(spec.DependsOn, ", "))
+	}
+	if spec.MinReadySeconds > 0 {
+		fmt.Fprintf(w, "Min. hazır süre:\t%ds\n", spec.MinReadySeconds)
+	}
+	if spec.PublishMode != "" {
+		fmt.Fprintf(w, "Yayın modu:\t%s\n", spec.PublishMode)
+	}
+	fmt.Fprintf(w, "Etiketler:\t%s\n", formatLabels(spec.Container.Labels))
+	fmt.Fprintf(w, "Portlar:\t%s\n", formatPorts(spec.Container.Ports))
+	if spec.Container.Resources != nil {
+		fmt.Fprintf(w, "Kaynaklar:\t%s\n", formatResources(spec.Container.Resources))
+	}
+
+	if status, err := getDeploymentStatus(name); err == nil {
+		summary := fmt.Sprintf("%d istenen, %d hazır, %d kullanılabilir, %d kullanılamaz", status.Desired, status.Ready, status.Available, status.Unavailable)
+		if status.Drifted > 0 {
+			summary += fmt.Sprintf(", %d farklı image", status.Drifted)
+		}
+		fmt.Fprintf(w, "Replica durumu:\t%s\n", summary)
+		if status.Rollout != nil {
+			fmt.Fprintf(w, "Rollout:\t%s\n", formatRollout(status.Rollout))
+		}
+		if len(status.WaitingFor) > 0 {
+			fmt.Fprintf(w, "Bekleniyor:\t%s\n", strings.Join(status.WaitingFor, ", "))
+		}
+		if len(status.MissingReplicas) > 0 {
+			fmt.Fprintf(w, "Eksik replica'lar:\t%s\n", formatIndices(status.MissingReplicas))
+		}
+	} else {
+		fmt.Fprintf(w, "Replica durumu:\talınamadı: %v\n", err)
+	}
+	w.Flush()
+
+	fmt.Fprintf(out, "\nReplica'lar:\n")
+	if len(deployment.Replicas) == 0 {
+		fmt.Fprintf(out, "  <yok>\n")
+	} else {
+		w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "  NAME\tSTATUS\tRESTARTS\tAGE\tPORTS")
+		for _, replica := range deployment.Replicas {
+			c, err := inspectContainer(replica.ID)
+			if err != nil {
+				fmt.Fprintf(w, "  %s\tbilinmiyor\t-\t-\t%v\n", replica.Name, err)
+				continue
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%d\t%s\t%s\n", c.Name, c.Status, c.RestartCount, containerAge(c), formatPorts(c.Ports))
+		}
+		w.Flush()
+	}
+
+	fmt.Fprintf(out, "\nService'ler:\n")
+	if services, err := listServices(); err != nil {
+		fmt.Fprintf(out, "  alınamadı: %v\n", err)
+	} else {
+		found := false
+		for _, svc := range services {
+			if scheduler.ServiceTargets(svc.Spec, deployment) {
+				fmt.Fprintf(out, "  %s (%s) %s, %d endpoint\n", svc.Name, svc.Spec.Type, formatServicePorts(svc.Spec.Ports), len(svc.Endpoints))
+				found = true
+			}
+		}
+		if !found {
+			fmt.Fprintf(out, "  <yok>\n")
+		}
+	}
+
+	printEvents(out, "deployment", name, eventsSince)
+	return nil
+}
+
+// describeService prints a service with its endpoints, the deployments it
+// targets and its recent events, optionally only those since a time filter
+func describeService(out io.Writer, name, eventsSince string) error {
+	svc, err := getService(name)
+	if err != nil {
+		return err
+	}
+	spec := svc.Spec
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Ad:\t%s\n", svc.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", container.NormalizeNamespace(spec.Namespace))
+	fmt.Fprintf(w, "Oluşturulma:\t%s (%s önce)\n", svc.Created.Format("2006-01-02 15:04:05"), since(svc.Created))
+	fmt.Fprintf(w, "Durum:\t%s\n", formatServiceStatus(svc))
+	if svc.Health != nil {
+		fmt.Fprintf(w, "Son sağlık kontrolü:\t%s (%s önce)\n", svc.Health.Checked.Format("2006-01-02 15:04:05"), since(svc.Health.Checked))
+	}
+	fmt.Fprintf(w, "Tip:\t%s\n", spec.Type)
+	fmt.Fprintf(w, "Portlar:\t%s\n", formatServicePorts(spec.Ports))
+	if spec.DeploymentRef != "" {
+		fmt.Fprintf(w, "Deployment:\t%s\n", spec.DeploymentRef)
+	} else {
+		fmt.Fprintf(w, "Selector:\t%s\n", formatLabels(spec.Selector))
+	}
+	w.Flush()
+
+	fmt.Fprintf(out, "\nEndpoint'ler:\n")
+	if len(svc.Endpoints) == 0 {
+		fmt.Fprintf(out, "  <yok>\n")
+	}
+	unhealthy := make(map[string]bool)
+	if svc.Health != nil {
+		for _, endpoint := range svc.Health.Unhealthy {
+			unhealthy[endpoint] = true
+		}
+	}
+	for _, endpoint := range svc.Endpoints {
+		if unhealthy[endpoint] {
+			fmt.Fprintf(out, "  %s (yanıt vermiyor)\n", endpoint)
+			continue
+		}
+		fmt.Fprintf(out, "  %s\n", endpoint)
+	}
+
+	fmt.Fprintf(out, "\nDeployment'lar:\n")
+	if deployments, err := listDeployments(""); err != nil {
+		fmt.Fprintf(out, "  alınamadı: %v\n", err)
+	} else {
+		found := false
+		for _, d := range deployments {
+			if scheduler.ServiceTargets(spec, d) {
+				fmt.Fprintf(out, "  %s (%d/%d replica, %s)\n", d.Name, len(d.Replicas), d.Spec.Replicas, d.Status)
+				found = true
+			}
+		}
+		if !found {
+			fmt.Fprintf(out, "  <yok>\n")
+		}
+	}
+
+	printEvents(out, "service", name, eventsSince)
+	return nil
+}
+
+// describeContainer prints a container with its runtime state and the
+// deployment it belongs to
+func describeContainer(out io.Writer, name string) error {
+	c, err := inspectContainer(name)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Ad:\t%s\n", c.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", container.NormalizeNamespace(c.Namespace))
+	fmt.Fprintf(w, "ID:\t%s\n", truncateString(c.ID, 12))
+	fmt.Fprintf(w, "Image:\t%s\n", c.Image)
+	fmt.Fprintf(w, "Durum:\t%s\n", c.Status)
+	if c.Health != "" {
+		fmt.Fprintf(w, "Sağlık:\t%s\n", c.Health)
+	}
+	if c.HealthCheck != nil {
+		fmt.Fprintf(w, "Health check:\t%s\n", formatHealthCheck(c.HealthCheck))
+	}
+	fmt.Fprintf(w, "Yeniden başlatma:\t%d\n", c.RestartCount)
+	fmt.Fprintf(w, "Oluşturulma:\t%s (%s önce)\n", c.Created.Format("2006-01-02 15:04:05"), since(c.Created))
+	if c.Started != nil && !c.Started.IsZero() {
+		fmt.Fprintf(w, "Başlatılma:\t%s (%s önce)\n", c.Started.Format("2006-01-02 15:04:05"), since(*c.Started))
+	}
+	if deployment := c.Labels[container.DeploymentLabel]; deployment != "" {
+		fmt.Fprintf(w, "Deployment:\t%s\n", deployment)
+	}
+	fmt.Fprintf(w, "Restart policy:\t%s\n", valueOr(c.RestartPolicy, "no"))
+	fmt.Fprintf(w, "Stop sinyali:\t%s\n", valueOr(c.StopSignal, "SIGTERM"))
+	fmt.Fprintf(w, "Portlar:\t%s\n", formatPorts(c.Ports))
+	if len(c.Ulimits) > 0 {
+		ulimits := make([]string, 0, len(c.Ulimits))
+		for _, ulimit := range c.Ulimits {
+			ulimits = append(ulimits, ulimit.String())
+		}
+		fmt.Fprintf(w, "Ulimit'ler:\t%s\n", strings.Join(ulimits, ", "))
+	}
+	if c.Resources != nil {
+		fmt.Fprintf(w, "Kaynaklar:\t%s\n", formatResources(c.Resources))
+	}
+	if c.NetworkMode != "" {
+		fmt.Fprintf(w, "Network:\t%s\n", c.NetworkMode)
+	}
+	fmt.Fprintf(w, "Etiketler:\t%s\n", formatLabels(c.Labels))
+	w.Flush()
+
+	if len(c.Secrets) > 0 {
+		fmt.Fprintf(out, "\nSecret'lar:\n")
+		for _, mount := range c.Secrets {
+			fmt.Fprintf(out, "  %s -> %s\n", mount.Secret, mount.Target)
+		}
+	}
+	return nil
+}
+
+// printEvents prints the recent events of a deployment or service
+func printEvents(out io.Writer, kind, name, eventsSince string) {
+	fmt.Fprintf(out, "\nOlaylar:\n")
+	events, err := getEvents(kind, name, eventsSince)
+	if err != nil {
+		fmt.Fprintf(out, "  alınamadı: %v\n", err)
+		return
+	}
+	if len(events) == 0 {
+		fmt.Fprintf(out, "  <yok>\n")
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  AGE\tTYPE\tDETAIL")
+	for _, event := range events {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", since(event.Timestamp), event.Type, eventDetail(event))
+	}
+	w.Flush()
+}
+
+// eventDetail describes the replica a replica event refers to
+func eventDetail(event scheduler.Event) string {
+	object, ok := event.Object.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	index, ok := object["index"].(float64)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("replica %d", int(index))
+}
+
+// containerAge returns how long a container has been running, or since when
+// it exists if it never started
+func containerAge(c *container.Container) string {
+	if c.Started != nil && !c.Started.IsZero() {
+		return since(*c.Started)
+	}
+	return since(c.Created)
+}
+
+// since formats the time elapsed since t for humans
+func since(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return units.HumanDuration(time.Since(t))
+}
+
+// formatLabels formats labels as sorted key=value pairs
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "<yok>"
+	}
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// formatResources formats the memory and CPU limits of a container
+func formatResources(resources *container.Resources) string {
+	var parts []string
+	if resources.Memory != "" {
+		parts = append(parts, "bellek "+resources.Memory)
+	}
+	if resources.CPUs > 0 {
+		parts = append(parts, fmt.Sprintf("cpu %g", resources.CPUs))
+	}
+	if len(parts) == 0 {
+		return "<sınırsız>"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// valueOr returns value, or fallback if it is empty
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// runDescribe describes the resource of the given kind and exits on error
+func runDescribe(kind, name, eventsSince string) {
+	var err error
+	switch kind {
+	case "deployment", "deploy":
+		err = describeDeployment(os.Stdout, name, eventsSince)
+	case "service", "svc":
+		err = describeService(os.Stdout, name, eventsSince)
+	case "container":
+		err = describeContainer(os.Stdout, name)
+	default:
+		fmt.Printf("Geçersiz kaynak türü: %s (deployment, service veya container olmalı)\n", kind)
+		os.Exit(exitInvalid)
+	}
+
+	if err != nil {
+		fmt.Printf("%s bilgileri alınamadı: %v\n", kind, err)
+		os.Exit(exitCode(err))
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dumpFile is a single file of a deployment dump, relative to the dump root
+type dumpFile struct {
+	path string
+	data []byte
+}
+
+// dumpCollector gathers dump files from concurrent requests
+type dumpCollector struct {
+	mutex  sync.Mutex
+	files  []dumpFile
+	errors []string
+}
+
+// add stores a file of the dump
+func (c *dumpCollector) add(path string, data []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.files = append(c.files, dumpFile{path: path, data: data})
+}
+
+// addJSON stores v as an indented JSON file
+func (c *dumpCollector) addJSON(path string, v interface{}) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		c.fail(path, err)
+		return
+	}
+	c.add(path, append(data, '\n'))
+}
+
+// fail records that a file of the dump could not be collected
+func (c *dumpCollector) fail(path string, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.errors = append(c.errors, fmt.Sprintf("%s: %v", path, err))
+}
+
+// collectDump fetches the deployment and the inspect output, recent logs and
+// stats of every replica. All per-replica requests run concurrently; failed
+// ones are listed in errors.txt instead of aborting the dump.
+func collectDump(name string, tail string) ([]dumpFile, []string, error) {
+	deployment, err := getDeployment(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c := &dumpCollector{}
+	c.addJSON("deployment.json", deployment)
+
+	var wg sync.WaitGroup
+	for _, replica := range deployment.Replicas {
+		id := replica.ID
+		dir := replica.Name
+		if dir == "" {
+			dir = id
+		}
+
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			path := dir + "/inspect.json"
+			inspected, err := inspectContainer(id)
+			if err != nil {
+				c.fail(path, err)
+				return
+			}
+			c.addJSON(path, inspected)
+		}()
+		go func() {
+			defer wg.Done()
+			path := dir + "/logs.txt"
+			logs, _, err := getContainerLogs(id, logsRequest{Tail: tail, Timestamps: true})
+			if err != nil {
+				c.fail(path, err)
+				return
+			}
+			c.add(path, []byte(logs))
+		}()
+		go func() {
+			defer wg.Done()
+			path := dir + "/stats.json"
+			stats, err := getContainerStats(id)
+			if err != nil {
+				c.fail(path, err)
+				return
+			}
+			c.addJSON(path, stats)
+		}()
+	}
+	wg.Wait()
+
+	sort.Strings(c.errors)
+	if len(c.errors) > 0 {
+		c.add("errors.txt", []byte(strings.Join(c.errors, "\n")+"\n"))
+	}
+
+	sort.Slice(c.files, func(i, j int) bool {
+		return c.files[i].path < c.files[j].path
+	})
+	return c.files, c.errors, nil
+}
+
+// writeDumpDir writes the dump files below dir
+func writeDumpDir(dir string, files []dumpFile) error {
+	for _, f := range files {
+		path := filepath.Join(dir, filepath.FromSlash(f.path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, f.data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeDumpTar writes the dump files into a gzipped tarball below root
+func writeDumpTar(path, root string, files []dumpFile) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+
+	for _, f := range files {
+		header := &tar.Header{
+			Name:    root + "/" + f.path,
+			Mode:    0644,
+			Size:    int64(len(f.data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return out.Close()
+}
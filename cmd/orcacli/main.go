@@ -1,21 +1,33 @@
 package main
 
 import (
-	"encoding/json"
+	"bytes"
+	"encoding/base64"
+	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"net"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"orca/pkg/audit"
+	"orca/pkg/build"
 	"orca/pkg/container"
+	"orca/pkg/scheduler"
+	"orca/pkg/timeutil"
 
+	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
 )
 
 const (
 	defaultServerURL = "http://localhost:8080"
-	orcaBanner = `
+	orcaLogo = `
  ██████╗ ██████╗  ██████╗ █████╗ 
 ██╔═══██╗██╔══██╗██╔════╝██╔══██╗
 ██║   ██║██████╔╝██║     ███████║
@@ -23,13 +35,18 @@ const (
 ╚██████╔╝██║  ██║╚██████╗██║  ██║
  ╚═════╝ ╚═╝  ╚═╝ ╚═════╝╚═╝  ╚═╝
                                   
-Container Orchestrator CLI v1.0.0
 `
 )
 
+var orcaBanner = orcaLogo + "Container Orchestrator CLI v" + build.Version + "\n"
+
 var (
 	serverURL string
-	rootCmd   = &cobra.Command{
+	// namespace scopes every request; allNamespaces makes list commands
+	// show the resources of all namespaces
+	namespace     string
+	allNamespaces bool
+	rootCmd       = &cobra.Command{
 		Use:   "orca",
 		Short: "🐋 ORCA Container Orchestrator CLI",
 		Long: orcaBanner + `
@@ -53,7 +70,10 @@ Daha fazla bilgi için: orca [komut] --help`,
 )
 
 func init() {
-	rootCmd.PersistentFlags().StringVar(&serverURL, "server", defaultServerURL, "ORCA sunucu URL'si")
+	rootCmd.PersistentFlags().StringVar(&serverURL, "server", defaultServerURL, "ORCA sunucu URL'si (http://host:port veya unix:///path/orca.sock)")
+	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", os.Getenv("ORCA_NAMESPACE"), "Namespace of the resources (default: $ORCA_NAMESPACE or default)")
+	rootCmd.PersistentFlags().IntVar(&retries, "retries", retries, "Sunucuya ulaşılamadığında okuma istekleri için tekrar deneme sayısı")
+	cobra.OnInitialize(configureClient)
 
 	// Container commands
 	rootCmd.AddCommand(createContainerCmd)
@@ -63,26 +83,93 @@ func init() {
 	rootCmd.AddCommand(removeContainerCmd)
 	rootCmd.AddCommand(inspectContainerCmd)
 	rootCmd.AddCommand(logsContainerCmd)
+	rootCmd.AddCommand(attachContainerCmd)
+	rootCmd.AddCommand(updateContainerCmd)
+	rootCmd.AddCommand(setRestartPolicyCmd)
+	rootCmd.AddCommand(diffContainerCmd)
+	rootCmd.AddCommand(topContainerCmd)
+	rootCmd.AddCommand(exportContainerCmd)
+	rootCmd.AddCommand(catFileCmd)
+	rootCmd.AddCommand(commitContainerCmd)
+	rootCmd.AddCommand(describeCmd)
+	rootCmd.AddCommand(auditCmd)
+	rootCmd.AddCommand(eventsCmd)
+	rootCmd.AddCommand(runContainerCmd)
+	rootCmd.AddCommand(recreateContainerCmd)
+	rootCmd.AddCommand(adoptContainerCmd)
+
+	// Network commands
+	rootCmd.AddCommand(listNetworksCmd)
+	rootCmd.AddCommand(inspectNetworkCmd)
 
 	// Deployment commands
 	rootCmd.AddCommand(deployCmd)
 	rootCmd.AddCommand(listDeploymentsCmd)
 	rootCmd.AddCommand(deleteDeploymentCmd)
+	rootCmd.AddCommand(scaleDeploymentCmd)
+	rootCmd.AddCommand(dumpDeploymentCmd)
+	rootCmd.AddCommand(orphansCmd)
+	rootCmd.AddCommand(rolloutCmd)
+	rolloutCmd.AddCommand(rolloutRestartCmd)
+	rolloutCmd.AddCommand(rolloutUpdateCmd)
+	rolloutCmd.AddCommand(rolloutPromoteCmd)
+	rolloutCmd.AddCommand(rolloutAbortCmd)
+	rolloutCmd.AddCommand(rolloutStatusCmd)
 
 	// Service commands
 	rootCmd.AddCommand(createServiceCmd)
 	rootCmd.AddCommand(listServicesCmd)
 	rootCmd.AddCommand(deleteServiceCmd)
 
+	// Secret commands
+	rootCmd.AddCommand(secretCmd)
+	secretCmd.AddCommand(secretCreateCmd)
+	secretCmd.AddCommand(secretListCmd)
+	secretCmd.AddCommand(secretDeleteCmd)
+
 	// Utility commands
 	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(reconcileCmd)
+	reconcileCmd.AddCommand(reconcileStatusCmd)
+	reconcileCmd.AddCommand(reconcilePauseCmd)
+	reconcileCmd.AddCommand(reconcileResumeCmd)
+	rootCmd.AddCommand(systemCmd)
+	systemCmd.AddCommand(systemPruneCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
+// Exit codes of the CLI, so scripts can tell failures apart
+const (
+	exitError       = 1 // any other failure
+	exitNotFound    = 2 // the resource does not exist (HTTP 404)
+	exitInvalid     = 3 // invalid arguments or a rejected request (HTTP 400)
+	exitUnreachable = 4 // the server could not be reached
+)
+
+// exitCode returns the exit code for an error returned by a client call
+func exitCode(err error) int {
+	var unreachable *serverUnreachableError
+	var netErr net.Error
+	if errors.As(err, &unreachable) || errors.As(err, &netErr) {
+		return exitUnreachable
+	}
+
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.status {
+		case http.StatusNotFound:
+			return exitNotFound
+		case http.StatusBadRequest:
+			return exitInvalid
+		}
+	}
+	return exitError
+}
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Printf("❌ Hata: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitInvalid)
 	}
 }
 
@@ -90,33 +177,54 @@ func main() {
 var createContainerCmd = &cobra.Command{
 	Use:   "create [spec-file]",
 	Short: "📦 Yeni bir konteyner oluştur",
-	Long: `Belirtilen JSON spec dosyasından yeni bir konteyner oluşturur.
+	Long: `Belirtilen JSON spec dosyasından (- verilirse standart girdiden) yeni bir
+konteyner oluşturur.
+--env, --label, --port ve --volume flag'leri spec dosyasındaki değerlerin
+üzerine yazılır (--replace ile tamamen değiştirir); spec dosyası verilmezse
+konteyner yalnızca flag'lerden oluşturulur.
 
 Örnek kullanım:
   orca create examples/test-container.json
-  orca create my-app-spec.json`,
-	Args:  cobra.ExactArgs(1),
+  orca create my-app-spec.json
+  orca create my-app-spec.json --start
+  envsubst < app.tmpl.json | orca create -
+  orca create my-app-spec.json -e LOG_LEVEL=debug --label team=core
+  orca create --image nginx:alpine --name web -p 8080:80 -v /data:/usr/share/nginx/html:ro`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		specFile := args[0]
-		
-		fmt.Printf("📄 Spec dosyası okunuyor: %s\n", specFile)
-		data, err := ioutil.ReadFile(specFile)
-		if err != nil {
-			fmt.Printf("❌ Spec dosyası okunamadı: %v\n", err)
-			os.Exit(1)
+		var spec container.ContainerSpec
+		if len(args) == 1 {
+			specFile := args[0]
+
+			if specFile == "-" {
+				fmt.Printf("📄 Spec standart girdiden okunuyor\n")
+			} else {
+				fmt.Printf("📄 Spec dosyası okunuyor: %s\n", specFile)
+			}
+			data, err := readSpecFile(specFile)
+			if err != nil {
+				fmt.Printf("❌ Spec dosyası okunamadı: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+
+			if err := container.DecodeSpec(bytes.NewReader(data), &spec); err != nil {
+				fmt.Printf("❌ Spec dosyası parse edilemedi: %v\n", err)
+				os.Exit(exitInvalid)
+			}
 		}
 
-		var spec container.ContainerSpec
-		if err := json.Unmarshal(data, &spec); err != nil {
-			fmt.Printf("❌ Spec dosyası parse edilemedi: %v\n", err)
-			os.Exit(1)
+		if err := applyCreateFlags(cmd, &spec); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(exitInvalid)
 		}
 
+		start, _ := cmd.Flags().GetBool("start")
+
 		fmt.Printf("🚀 Konteyner oluşturuluyor: %s\n", spec.Name)
-		c, err := createContainer(spec)
+		c, err := createContainer(spec, start)
 		if err != nil {
 			fmt.Printf("❌ Konteyner oluşturulamadı: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitCode(err))
 		}
 
 		fmt.Printf("✅ Konteyner başarıyla oluşturuldu!\n")
@@ -124,9 +232,110 @@ var createContainerCmd = &cobra.Command{
 		fmt.Printf("   🏷️  İsim: %s\n", c.Name)
 		fmt.Printf("   🖼️  Image: %s\n", c.Image)
 		fmt.Printf("   📊 Durum: %s\n", c.Status)
+		printWarnings(c.Warnings)
 	},
 }
 
+// applyCreateFlags merges the create command flags over spec. With --replace
+// a given flag replaces the spec value of its field instead of merging.
+func applyCreateFlags(cmd *cobra.Command, spec *container.ContainerSpec) error {
+	flags := cmd.Flags()
+	replace, _ := flags.GetBool("replace")
+
+	if image, _ := flags.GetString("image"); image != "" {
+		spec.Image = image
+	}
+	if name, _ := flags.GetString("name"); name != "" {
+		spec.Name = name
+	}
+	if platform, _ := flags.GetString("platform"); platform != "" {
+		spec.Platform = platform
+	}
+	if gpus, _ := flags.GetString("gpus"); gpus != "" {
+		spec.GPUs = gpus
+	}
+	if stopSignal, _ := flags.GetString("stop-signal"); stopSignal != "" {
+		spec.StopSignal = stopSignal
+	}
+	if devices, _ := flags.GetStringArray("device"); len(devices) > 0 {
+		spec.Devices = append(spec.Devices, devices...)
+	}
+	if ulimitFlags, _ := flags.GetStringArray("ulimit"); len(ulimitFlags) > 0 {
+		ulimits, err := parseUlimitFlags(ulimitFlags)
+		if err != nil {
+			return err
+		}
+		spec.Ulimits = mergeUlimits(spec.Ulimits, ulimits)
+	}
+	if spec.Image == "" {
+		return fmt.Errorf("spec dosyası veya --image belirtilmelidir")
+	}
+	if spec.Name == "" {
+		spec.Name = containerNameFromImage(spec.Image)
+	}
+
+	envFlags, _ := flags.GetStringArray("env")
+	env, err := parseKeyValues(envFlags)
+	if err != nil {
+		return err
+	}
+	spec.Environment = mergeValues(spec.Environment, env, replace)
+
+	labelFlags, _ := flags.GetStringArray("label")
+	labels, err := parseKeyValues(labelFlags)
+	if err != nil {
+		return err
+	}
+	spec.Labels = mergeValues(spec.Labels, labels, replace)
+
+	portFlags, _ := flags.GetStringArray("port")
+	ports, err := parsePortFlags(portFlags)
+	if err != nil {
+		return err
+	}
+	if len(ports) > 0 {
+		// Normalize both sides so "80" and "80/tcp" refer to the same port
+		if ports, err = container.NormalizePorts(ports); err != nil {
+			return err
+		}
+		base, err := container.NormalizePorts(spec.Ports)
+		if err != nil {
+			return err
+		}
+		spec.Ports = mergeValues(base, ports, replace)
+	}
+
+	volumeFlags, _ := flags.GetStringArray("volume")
+	volumes, err := parseVolumeFlags(volumeFlags)
+	if err != nil {
+		return err
+	}
+	if len(volumes) > 0 {
+		if replace {
+			spec.Volumes = nil
+		}
+		// A volume flag replaces a spec volume with the same destination
+		for _, volume := range volumes {
+			kept := spec.Volumes[:0]
+			for _, existing := range spec.Volumes {
+				if existing.Destination != volume.Destination {
+					kept = append(kept, existing)
+				}
+			}
+			spec.Volumes = append(kept, volume)
+		}
+	}
+
+	return nil
+}
+
+// printWarnings prints the warnings Docker returned for a container
+func printWarnings(warnings []string) {
+	for _, warning := range warnings {
+		fmt.Printf("⚠️  Docker uyarısı: %s\n", warning)
+	}
+}
+
 var listContainersCmd = &cobra.Command{
 	Use:     "containers",
 	Aliases: []string{"ps", "list"},
@@ -136,13 +345,27 @@ var listContainersCmd = &cobra.Command{
 Örnek kullanım:
   orca containers
   orca ps
-  orca list`,
+  orca list
+  orca containers -l app=web
+  orca containers --show-label orca.deployment
+  orca containers --size`,
 	Run: func(cmd *cobra.Command, args []string) {
+		labels, _ := cmd.Flags().GetString("label")
+		showLabels, _ := cmd.Flags().GetStringSlice("show-label")
+		size, _ := cmd.Flags().GetBool("size")
+
+		if labels != "" {
+			if _, err := scheduler.ParseSelector(labels); err != nil {
+				fmt.Printf("❌ %v\n", err)
+				os.Exit(exitInvalid)
+			}
+		}
+
 		fmt.Println("🔍 Konteynerler getiriliyor...")
-		containers, err := listContainers()
+		containers, err := listContainers(labels, size)
 		if err != nil {
 			fmt.Printf("❌ Konteyner listesi alınamadı: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitCode(err))
 		}
 
 		if len(containers) == 0 {
@@ -153,7 +376,17 @@ var listContainersCmd = &cobra.Command{
 		fmt.Printf("\n📦 Toplam %d konteyner bulundu:\n\n", len(containers))
 		
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-		fmt.Fprintln(w, "ID\tİSİM\tIMAGE\tDURUM\tPORTLAR")
+		header := "ID\tİSİM\tIMAGE\tDURUM\tPORTLAR"
+		if allNamespaces {
+			header = "NAMESPACE\t" + header
+		}
+		if size {
+			header += "\tBOYUT"
+		}
+		for _, label := range showLabels {
+			header += "\t" + strings.ToUpper(label)
+		}
+		fmt.Fprintln(w, header)
 		fmt.Fprintln(w, strings.Repeat("─", 80))
 		
 		for _, c := range containers {
@@ -178,10 +411,30 @@ var listContainersCmd = &cobra.Command{
 				status = "⚪ " + status
 			}
 			
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", 
-				c.ID[:12], c.Name, c.Image, status, ports)
+			if allNamespaces {
+				fmt.Fprintf(w, "%s\t", c.Namespace)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s",
+				truncateString(c.ID, 12), c.Name, c.Image, status, ports)
+			if size {
+				fmt.Fprintf(w, "\t%s (sanal %s)", units.HumanSize(float64(c.SizeRw)), units.HumanSize(float64(c.SizeRootFs)))
+			}
+			for _, label := range showLabels {
+				value := c.Labels[label]
+				if value == "" {
+					value = "-"
+				}
+				fmt.Fprintf(w, "\t%s", value)
+			}
+			fmt.Fprintln(w)
 		}
 		w.Flush()
+
+		for _, c := range containers {
+			if c.Error != "" {
+				fmt.Printf("⚠️  %s: %s\n", c.Name, c.Error)
+			}
+		}
 	},
 }
 
@@ -192,15 +445,26 @@ var startContainerCmd = &cobra.Command{
 
 Örnek kullanım:
   orca start my-container
-  orca start test-integration`,
-	Args:  cobra.ExactArgs(1),
+  orca start test-integration
+  orca start --all -l env=staging`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			labels, _ := cmd.Flags().GetString("label")
+			parallel, _ := cmd.Flags().GetInt("parallel")
+			bulkContainerAction("start", labels, false, parallel)
+			return
+		}
+		if len(args) != 1 {
+			fmt.Printf("❌ Konteyner adı veya --all belirtilmelidir\n")
+			os.Exit(exitInvalid)
+		}
 		containerID := args[0]
 		
 		fmt.Printf("🚀 Konteyner başlatılıyor: %s\n", containerID)
 		if err := startContainer(containerID); err != nil {
 			fmt.Printf("❌ Konteyner başlatılamadı: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitCode(err))
 		}
 
 		fmt.Printf("✅ Konteyner başarıyla başlatıldı: %s\n", containerID)
@@ -214,15 +478,26 @@ var stopContainerCmd = &cobra.Command{
 
 Örnek kullanım:
   orca stop my-container
-  orca stop test-integration`,
-	Args:  cobra.ExactArgs(1),
+  orca stop test-integration
+  orca stop --all -l env=staging --parallel 10`,
+	Args:  cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		if all, _ := cmd.Flags().GetBool("all"); all {
+			labels, _ := cmd.Flags().GetString("label")
+			parallel, _ := cmd.Flags().GetInt("parallel")
+			bulkContainerAction("stop", labels, true, parallel)
+			return
+		}
+		if len(args) != 1 {
+			fmt.Printf("❌ Konteyner adı veya --all belirtilmelidir\n")
+			os.Exit(exitInvalid)
+		}
 		containerID := args[0]
 		
 		fmt.Printf("⏹️  Konteyner durduruluyor: %s\n", containerID)
 		if err := stopContainer(containerID); err != nil {
 			fmt.Printf("❌ Konteyner durdurulamadı: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitCode(err))
 		}
 
 		fmt.Printf("✅ Konteyner başarıyla durduruldu: %s\n", containerID)
@@ -246,7 +521,7 @@ var removeContainerCmd = &cobra.Command{
 		fmt.Printf("🗑️  Konteyner siliniyor: %s\n", containerID)
 		if err := removeContainer(containerID); err != nil {
 			fmt.Printf("❌ Konteyner silinemedi: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitCode(err))
 		}
 
 		fmt.Printf("✅ Konteyner başarıyla silindi: %s\n", containerID)
@@ -269,20 +544,57 @@ var inspectContainerCmd = &cobra.Command{
 		c, err := inspectContainer(containerID)
 		if err != nil {
 			fmt.Printf("❌ Konteyner bilgileri alınamadı: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitCode(err))
 		}
 
 		fmt.Printf("\n📋 Konteyner Detayları:\n")
 		fmt.Printf("═══════════════════════════════════════\n")
 		fmt.Printf("🏷️  İsim: %s\n", c.Name)
+		fmt.Printf("🗂️  Namespace: %s\n", c.Namespace)
 		fmt.Printf("📋 ID: %s\n", c.ID)
 		fmt.Printf("🖼️  Image: %s\n", c.Image)
 		fmt.Printf("📊 Durum: %s\n", c.Status)
+		if c.Health != "" {
+			fmt.Printf("🩺 Sağlık: %s\n", c.Health)
+		}
+		if c.HealthCheck != nil {
+			fmt.Printf("🩺 Health Check: %s\n", formatHealthCheck(c.HealthCheck))
+		}
+		if c.WorkingDir != "" {
+			fmt.Printf("📁 Çalışma Dizini: %s\n", c.WorkingDir)
+		}
+		if c.RestartPolicy != "" {
+			fmt.Printf("🔁 Restart Policy: %s\n", c.RestartPolicy)
+		}
+		if c.NetworkMode != "" {
+			fmt.Printf("🌐 Network Mode: %s\n", c.NetworkMode)
+		}
+		if c.LogDriver != "" {
+			fmt.Printf("📜 Log Driver: %s\n", c.LogDriver)
+		}
+		if c.GPUs != "" {
+			fmt.Printf("🎮 GPU: %s\n", c.GPUs)
+		}
+		if c.StopSignal != "" {
+			fmt.Printf("🛑 Stop Sinyali: %s\n", c.StopSignal)
+		}
+		if len(c.Devices) > 0 {
+			fmt.Printf("🔌 Cihazlar:\n")
+			for _, device := range c.Devices {
+				fmt.Printf("   %s\n", device)
+			}
+		}
+		if len(c.Ulimits) > 0 {
+			fmt.Printf("📏 Ulimit'ler:\n")
+			for _, ulimit := range c.Ulimits {
+				fmt.Printf("   %s (soft %d, hard %d)\n", ulimit.Name, ulimit.Soft, ulimit.Hard)
+			}
+		}
 		
 		if len(c.Ports) > 0 {
 			fmt.Printf("🌐 Portlar:\n")
 			for containerPort, hostPort := range c.Ports {
-				fmt.Printf("   %s:%s (tcp)\n", hostPort, containerPort)
+				fmt.Printf("   %s:%s\n", hostPort, containerPort)
 			}
 		}
 		
@@ -293,6 +605,16 @@ var inspectContainerCmd = &cobra.Command{
 			}
 		}
 		
+		if c.Resources != nil {
+			fmt.Printf("⚙️  Kaynaklar:\n")
+			if c.Resources.Memory != "" {
+				fmt.Printf("   Bellek: %s\n", c.Resources.Memory)
+			}
+			if c.Resources.CPUs > 0 {
+				fmt.Printf("   CPU: %g\n", c.Resources.CPUs)
+			}
+		}
+		
 		fmt.Printf("📅 Oluşturulma: %s\n", c.Created.Format("2006-01-02 15:04:05"))
 		if !c.Started.IsZero() {
 			fmt.Printf("🚀 Başlatılma: %s\n", c.Started.Format("2006-01-02 15:04:05"))
@@ -300,206 +622,1476 @@ var inspectContainerCmd = &cobra.Command{
 	},
 }
 
-var logsContainerCmd = &cobra.Command{
-	Use:   "logs [container-name]",
-	Short: "📜 Konteyner loglarını görüntüle",
-	Long: `Belirtilen konteyner adı veya ID'si ile konteyner loglarını görüntüler.
+var describeCmd = &cobra.Command{
+	Use:   "describe [deployment|service|container] [name]",
+	Short: "🔎 Kaynağın özetini ve son olaylarını göster",
+	Long: `Bir deployment, service veya konteyner hakkında birden fazla endpoint'ten
+toplanan okunabilir bir özet gösterir. Deployment'lar için spec, replica'ların
+durumu, yaşı ve yeniden başlatma sayısı, önündeki service'ler ve son olaylar;
+service'ler için endpoint'ler, hedeflenen deployment'lar ve son olaylar listelenir.
 
 Örnek kullanım:
-  orca logs my-container
-  orca logs test-integration --tail 50`,
-	Args:  cobra.ExactArgs(1),
+  orca describe deployment web-app
+  orca describe svc web-service
+  orca describe container web-app-0
+  orca describe deployment web-app --since 30m`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		since, _ := cmd.Flags().GetString("since")
+		runDescribe(args[0], args[1], since)
+	},
+}
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "📋 Audit log'daki son işlemleri göster",
+	Long: `Sunucunun audit log'undaki değişiklik yapan işlemleri (kim, ne, hangi
+kaynak, ne zaman ve sonucu) eskiden yeniye listeler.
+
+Örnek kullanım:
+  orca audit
+  orca audit --tail 20
+  orca audit --since 1d
+  orca audit -f`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		containerID := args[0]
 		tail, _ := cmd.Flags().GetInt("tail")
-		
-		fmt.Printf("📜 Konteyner logları getiriliyor: %s (son %d satır)\n", containerID, tail)
-		logs, err := getContainerLogs(containerID, tail)
+		follow, _ := cmd.Flags().GetBool("follow")
+
+		var since time.Time
+		if value, _ := cmd.Flags().GetString("since"); value != "" {
+			parsed, err := timeutil.ParseSince(value, time.Now())
+			if err != nil {
+				fmt.Printf("❌ %v\n", err)
+				os.Exit(exitInvalid)
+			}
+			since = parsed
+		}
+
+		entries, err := getAudit(tail, since)
 		if err != nil {
-			fmt.Printf("❌ Konteyner logları alınamadı: %v\n", err)
-			os.Exit(1)
+			fmt.Printf("❌ Audit log alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
 		}
 
-		fmt.Printf("\n📋 Konteyner Logları:\n")
-		fmt.Printf("═══════════════════════════════════════\n")
-		fmt.Print(logs)
+		fmt.Printf("%-23s  %-15s  %-10s  %-12s  %-12s  %-24s  %-6s  %s\n", "TIME", "ACTOR", "KIND", "ACTION", "NAMESPACE", "NAME", "STATUS", "OUTCOME")
+		var last time.Time
+		for {
+			for _, entry := range entries {
+				printAuditEntry(entry)
+				last = entry.Time
+			}
+			if !follow {
+				return
+			}
+
+			time.Sleep(2 * time.Second)
+			if last.IsZero() {
+				last = time.Now()
+			}
+			entries, err = getAudit(0, last)
+			if err != nil {
+				fmt.Printf("❌ Audit log alınamadı: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+		}
 	},
 }
 
-// Deployment commands
-var deployCmd = &cobra.Command{
-	Use:   "deploy [spec-file]",
-	Short: "Create a deployment",
-	Args:  cobra.ExactArgs(1),
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "📡 Konteyner olaylarını canlı izle",
+	Long: `Docker konteyner olaylarını (start, die, oom, restart vb.) geldikçe
+gösterir. Olay tipi ve konteyner adıyla filtrelenebilir.
+
+Örnek kullanım:
+  orca events
+  orca events --type die --name myapp-0
+  orca events --type die,start --since 1h
+  orca events -A`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		specFile := args[0]
-		
-		data, err := ioutil.ReadFile(specFile)
-		if err != nil {
-			fmt.Printf("Spec dosyası okunamadı: %v\n", err)
-			os.Exit(1)
-		}
+		actions, _ := cmd.Flags().GetStringSlice("type")
+		name, _ := cmd.Flags().GetString("name")
+		since, _ := cmd.Flags().GetString("since")
 
-		var spec container.DeploymentSpec
-		if err := json.Unmarshal(data, &spec); err != nil {
-			fmt.Printf("Spec dosyası parse edilemedi: %v\n", err)
-			os.Exit(1)
+		if since != "" {
+			if _, err := timeutil.ParseSince(since, time.Now()); err != nil {
+				fmt.Printf("❌ %v\n", err)
+				os.Exit(exitInvalid)
+			}
 		}
 
-		deployment, err := createDeployment(spec)
+		fmt.Printf("%-23s  %-14s  %-12s  %-24s  %-12s  %s\n", "TIME", "ACTION", "NAMESPACE", "NAME", "CONTAINER ID", "DETAILS")
+		err := watchEvents(actions, name, since, func(event container.ContainerEvent) {
+			details := event.Image
+			if event.ExitCode != "" {
+				details = fmt.Sprintf("exit code %s", event.ExitCode)
+			}
+			fmt.Printf("%-23s  %-14s  %-12s  %-24s  %-12s  %s\n",
+				event.Time.Local().Format("2006-01-02 15:04:05.000"), event.Action, event.Namespace,
+				valueOr(event.Name, "-"), truncateString(event.ContainerID, 12), details)
+		})
 		if err != nil {
-			fmt.Printf("Deployment oluşturulamadı: %v\n", err)
-			os.Exit(1)
+			fmt.Printf("❌ Olay akışı alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
 		}
-
-		fmt.Printf("Deployment oluşturuldu: %s (%d replicas)\n", deployment.Name, len(deployment.Replicas))
 	},
 }
 
-var listDeploymentsCmd = &cobra.Command{
-	Use:     "deployments",
-	Aliases: []string{"deploy"},
-	Short:   "List deployments",
+// Network commands
+var listNetworksCmd = &cobra.Command{
+	Use:   "networks",
+	Short: "🌐 Ağları ve bağlı konteynerleri listele",
+	Long: `Sunucudaki Docker ağlarını subnet, gateway ve bağlı konteynerleriyle
+birlikte listeler. Ağlar tüm namespace'ler tarafından paylaşılır.
+
+Örnek kullanım:
+  orca networks`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		deployments, err := listDeployments()
+		fmt.Println("🔍 Ağlar getiriliyor...")
+		networks, err := listNetworks()
 		if err != nil {
-			fmt.Printf("Deployment listesi alınamadı: %v\n", err)
-			os.Exit(1)
+			fmt.Printf("❌ Ağ listesi alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
 		}
 
-		if len(deployments) == 0 {
-			fmt.Println("Hiç deployment bulunamadı.")
+		if len(networks) == 0 {
+			fmt.Println("📭 Hiç ağ bulunamadı.")
 			return
 		}
 
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "NAME\tREPLICAS\tSTATUS\tCREATED")
-		
-		for _, d := range deployments {
-			created := d.Created.Format("2006-01-02 15:04:05")
-			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", 
-				d.Name, len(d.Replicas), d.Status, created)
+		fmt.Printf("\n🌐 Toplam %d ağ bulundu:\n\n", len(networks))
+		for _, network := range networks {
+			managed := ""
+			if network.Managed {
+				managed = ", ORCA"
+			}
+			fmt.Printf("%s (%s, %s%s) %s\n", network.Name, network.Driver, network.Scope, managed, formatSubnets(network.Subnets))
+			if len(network.Containers) == 0 {
+				fmt.Printf("   bağlı konteyner yok\n")
+			}
+			for _, c := range network.Containers {
+				fmt.Printf("   %-32s %-12s %s\n", c.Name, truncateString(c.ID, 12), valueOr(c.IPv4Address, "-"))
+			}
 		}
-		
-		w.Flush()
 	},
 }
 
-var deleteDeploymentCmd = &cobra.Command{
-	Use:   "delete-deployment [name]",
-	Short: "Delete a deployment",
-	Args:  cobra.ExactArgs(1),
+var inspectNetworkCmd = &cobra.Command{
+	Use:   "inspect-network [network-name]",
+	Short: "🔍 Ağ detaylarını görüntüle",
+	Long: `Belirtilen ağ adı veya ID'si ile ağın sürücüsünü, subnet ve gateway'lerini
+ve bağlı konteynerleri görüntüler.
+
+Örnek kullanım:
+  orca inspect-network orca-network
+  orca inspect-network bridge`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		name := args[0]
-		
-		if err := deleteDeployment(name); err != nil {
-			fmt.Printf("Deployment silinemedi: %v\n", err)
-			os.Exit(1)
-		}
-
-		fmt.Printf("Deployment silindi: %s\n", name)
-	},
-}
 
-// Service commands
-var createServiceCmd = &cobra.Command{
-	Use:   "create-service [spec-file]",
-	Short: "Create a service",
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		specFile := args[0]
-		
-		data, err := ioutil.ReadFile(specFile)
+		fmt.Printf("🔍 Ağ bilgileri getiriliyor: %s\n", name)
+		network, err := inspectNetwork(name)
 		if err != nil {
-			fmt.Printf("Spec dosyası okunamadı: %v\n", err)
-			os.Exit(1)
+			fmt.Printf("❌ Ağ bilgileri alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
 		}
 
-		var spec container.ServiceSpec
-		if err := json.Unmarshal(data, &spec); err != nil {
-			fmt.Printf("Spec dosyası parse edilemedi: %v\n", err)
-			os.Exit(1)
+		fmt.Printf("\n📋 Ağ Detayları:\n")
+		fmt.Printf("═══════════════════════════════════════\n")
+		fmt.Printf("🏷️  İsim: %s\n", network.Name)
+		fmt.Printf("📋 ID: %s\n", network.ID)
+		fmt.Printf("🔧 Sürücü: %s\n", network.Driver)
+		fmt.Printf("📍 Kapsam: %s\n", network.Scope)
+		if network.Internal {
+			fmt.Printf("🔒 Dahili: evet\n")
 		}
-
-		service, err := createService(spec)
-		if err != nil {
-			fmt.Printf("Service oluşturulamadı: %v\n", err)
-			os.Exit(1)
+		if network.Managed {
+			fmt.Printf("🐋 ORCA tarafından yönetiliyor\n")
 		}
-
-		fmt.Printf("Service oluşturuldu: %s\n", service.Name)
-	},
-}
-
-var listServicesCmd = &cobra.Command{
-	Use:     "services",
-	Aliases: []string{"svc"},
-	Short:   "List services",
-	Run: func(cmd *cobra.Command, args []string) {
-		services, err := listServices()
-		if err != nil {
-			fmt.Printf("Service listesi alınamadı: %v\n", err)
-			os.Exit(1)
+		fmt.Printf("📅 Oluşturulma: %s\n", network.Created.Local().Format("2006-01-02 15:04:05"))
+		if len(network.Subnets) > 0 {
+			fmt.Printf("🌐 Subnet'ler:\n")
+			for _, subnet := range network.Subnets {
+				fmt.Printf("   %s (gateway: %s)\n", subnet.Subnet, valueOr(subnet.Gateway, "-"))
+			}
 		}
 
-		if len(services) == 0 {
-			fmt.Println("Hiç service bulunamadı.")
+		if len(network.Containers) == 0 {
+			fmt.Printf("📦 Bağlı konteyner yok\n")
 			return
 		}
-
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "NAME\tTYPE\tPORTS\tSTATUS\tCREATED")
-		
-		for _, s := range services {
-			ports := formatServicePorts(s.Spec.Ports)
-			created := s.Created.Format("2006-01-02 15:04:05")
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", 
-				s.Name, s.Spec.Type, ports, s.Status, created)
+		fmt.Printf("📦 Bağlı Konteynerler (%d):\n", len(network.Containers))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "   İSİM\tID\tIPv4\tIPv6\tMAC")
+		for _, c := range network.Containers {
+			fmt.Fprintf(w, "   %s\t%s\t%s\t%s\t%s\n", c.Name, truncateString(c.ID, 12),
+				valueOr(c.IPv4Address, "-"), valueOr(c.IPv6Address, "-"), valueOr(c.MacAddress, "-"))
 		}
-		
 		w.Flush()
 	},
 }
 
-var deleteServiceCmd = &cobra.Command{
-	Use:   "delete-service [name]",
-	Short: "Delete a service",
-	Args:  cobra.ExactArgs(1),
+// printAuditEntry prints an audit entry as a row of the audit table
+func printAuditEntry(entry audit.Entry) {
+	fmt.Printf("%-23s  %-15s  %-10s  %-12s  %-12s  %-24s  %-6d  %s\n",
+		entry.Time.Local().Format("2006-01-02 15:04:05.000"), entry.Actor, entry.Kind, entry.Action,
+		valueOr(entry.Namespace, "-"), valueOr(entry.Name, "-"), entry.Status, entry.Outcome)
+}
+
+var attachContainerCmd = &cobra.Command{
+	Use:   "attach [container-name]",
+	Short: "🔗 Çalışan konteynerin çıktısına bağlan",
+	Long: `Çalışan bir konteynerin ana işleminin stdout ve stderr akışlarına bağlanır
+ve çıktıyı canlı gösterir. logs -f'den farklı olarak log sürücüsünden okumaz,
+doğrudan işlemin akışlarını izler. --stdin ile standart girdi de işleme
+gönderilir; bunun için konteynerin stdin'i açık olmalıdır (örn. docker run -i).
+Ctrl+C konteyneri durdurmadan bağlantıyı kapatır.
+
+Örnek kullanım:
+  orca attach my-container
+  echo "status" | orca attach my-container --stdin`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		name := args[0]
-		
-		if err := deleteService(name); err != nil {
-			fmt.Printf("Service silinemedi: %v\n", err)
-			os.Exit(1)
+		containerID := args[0]
+		withStdin, _ := cmd.Flags().GetBool("stdin")
+
+		var stdin io.Reader
+		if withStdin {
+			stdin = os.Stdin
 		}
 
-		fmt.Printf("Service silindi: %s\n", name)
+		fmt.Printf("🔗 Konteynere bağlanılıyor: %s (çıkmak için Ctrl+C)\n", containerID)
+		if err := attachContainer(containerID, stdin, os.Stdout); err != nil {
+			fmt.Printf("❌ Konteynere bağlanılamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		fmt.Printf("🔌 Konteyner işlemi sonlandı: %s\n", containerID)
 	},
 }
 
-// Utility commands
-var statsCmd = &cobra.Command{
-	Use:   "stats",
-	Short: "📊 Sistem istatistiklerini göster",
-	Long: `ORCA sisteminin genel durumu ve istatistiklerini görüntüler.
+var logsContainerCmd = &cobra.Command{
+	Use:   "logs [container-name|deployment/name]",
+	Short: "📜 Konteyner loglarını görüntüle",
+	Long: `Belirtilen konteyner adı veya ID'si ile konteyner loglarını görüntüler.
 
 Örnek kullanım:
-  orca stats`,
+  orca logs my-container
+  orca logs test-integration --tail 50
+  orca logs test-integration --tail all
+  orca logs test-integration --grep "ERROR|WARN"
+  orca logs test-integration -f
+  orca logs test-integration --output logs.txt
+  orca logs deployment/web --since 10m --timestamps
+  orca logs web-0 --previous`,
+	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("📊 Sistem istatistikleri getiriliyor...")
-		stats, err := getStats()
-		if err != nil {
-			fmt.Printf("❌ İstatistikler alınamadı: %v\n", err)
-			os.Exit(1)
+		containerID := args[0]
+		tail, _ := cmd.Flags().GetString("tail")
+		grep, _ := cmd.Flags().GetString("grep")
+		since, _ := cmd.Flags().GetString("since")
+		timestamps, _ := cmd.Flags().GetBool("timestamps")
+		if tail == "-1" {
+			tail = "all"
+		}
+		if tail != "all" {
+			if n, err := strconv.Atoi(tail); err != nil || n <= 0 {
+				fmt.Printf("❌ Geçersiz --tail değeri: %s (pozitif sayı veya 'all' olmalı)\n", tail)
+				os.Exit(exitInvalid)
+			}
 		}
-
-		fmt.Printf("\n🐋 ORCA Sistem İstatistikleri:\n")
-		fmt.Printf("═══════════════════════════════════════\n")
 		
-		// Containers bilgisini güvenli şekilde al
-		if containers, ok := stats["containers"].(map[string]interface{}); ok {
-			total := int(containers["total"].(float64))
-			running := int(containers["running"].(float64))
+		previous, _ := cmd.Flags().GetBool("previous")
+		if previous && strings.HasPrefix(containerID, "deployment/") {
+			fmt.Printf("❌ --previous yalnızca tek bir replica için kullanılabilir (örn. web-0)\n")
+			os.Exit(exitInvalid)
+		}
+
+		req := logsRequest{Tail: tail, Grep: grep, Since: since, Timestamps: timestamps, Previous: previous}
+		if maxBytes, _ := cmd.Flags().GetString("max-bytes"); maxBytes != "" {
+			n, err := units.RAMInBytes(maxBytes)
+			if err != nil || n < 1 {
+				fmt.Printf("❌ Geçersiz --max-bytes değeri: %s (örn. 512k, 1MB)\n", maxBytes)
+				os.Exit(exitInvalid)
+			}
+			req.MaxBytes = n
+		}
+
+		if follow, _ := cmd.Flags().GetBool("follow"); follow {
+			if strings.HasPrefix(containerID, "deployment/") {
+				fmt.Printf("❌ --follow deployment logları için desteklenmiyor\n")
+				os.Exit(exitInvalid)
+			}
+			if err := followContainerLogs(containerID, req, os.Stdout); err != nil {
+				fmt.Printf("❌ Konteyner logları alınamadı: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+			return
+		}
+
+		if output, _ := cmd.Flags().GetString("output"); output != "" {
+			// A file download defaults to the whole log
+			if !cmd.Flags().Changed("tail") {
+				req.Tail = "all"
+			}
+			downloadLogs(containerID, req, output)
+			return
+		}
+		
+		if tail == "all" {
+			fmt.Printf("📜 Konteyner logları getiriliyor: %s (tüm loglar)\n", containerID)
+		} else {
+			fmt.Printf("📜 Konteyner logları getiriliyor: %s (son %s satır)\n", containerID, tail)
+		}
+		logs, truncated, err := getContainerLogs(containerID, req)
+		if err != nil {
+			fmt.Printf("❌ Konteyner logları alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("\n📋 Konteyner Logları:\n")
+		fmt.Printf("═══════════════════════════════════════\n")
+		fmt.Print(logs)
+
+		if truncated {
+			fmt.Printf("\n⚠️  Loglar sunucu tarafındaki boyut sınırında kesildi\n")
+		}
+	},
+}
+
+// downloadLogs writes container logs to a file while showing download progress
+func downloadLogs(containerID string, req logsRequest, output string) {
+	file, err := os.Create(output)
+	if err != nil {
+		fmt.Printf("❌ Dosya oluşturulamadı: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+	defer file.Close()
+
+	fmt.Printf("📥 Konteyner logları indiriliyor: %s → %s\n", containerID, output)
+	written, truncated, err := downloadContainerLogs(containerID, req, file, func(written, total int64) {
+		if total > 0 {
+			fmt.Printf("\r   %s / %s (%%%d)", units.HumanSize(float64(written)), units.HumanSize(float64(total)), written*100/total)
+		} else {
+			fmt.Printf("\r   %s", units.HumanSize(float64(written)))
+		}
+	})
+	fmt.Println()
+	if err != nil {
+		file.Close()
+		os.Remove(output)
+		fmt.Printf("❌ Konteyner logları alınamadı: %v\n", err)
+		os.Exit(exitCode(err))
+	}
+
+	fmt.Printf("✅ Loglar kaydedildi: %s (%s)\n", output, units.HumanSize(float64(written)))
+	if truncated {
+		fmt.Printf("⚠️  Loglar sunucu tarafındaki boyut sınırında kesildi\n")
+	}
+}
+
+var updateContainerCmd = &cobra.Command{
+	Use:   "update [container-name]",
+	Short: "⚙️  Konteyner kaynak sınırlarını güncelle",
+	Long: `Çalışan bir konteynerin bellek ve CPU sınırlarını yeniden başlatmadan günceller.
+
+Örnek kullanım:
+  orca update my-container --memory 1GB --cpus 1.5
+  orca update my-container --cpus 0.5`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		containerID := args[0]
+		memory, _ := cmd.Flags().GetString("memory")
+		cpus, _ := cmd.Flags().GetFloat64("cpus")
+
+		resources := container.Resources{Memory: memory, CPUs: cpus}
+		if resources.Memory == "" && resources.CPUs == 0 {
+			fmt.Println("❌ En az bir kaynak sınırı belirtilmelidir (--memory, --cpus)")
+			os.Exit(exitInvalid)
+		}
+		if err := container.ValidateResources(resources); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(exitInvalid)
+		}
+
+		fmt.Printf("⚙️  Konteyner kaynakları güncelleniyor: %s\n", containerID)
+		c, err := updateContainer(containerID, resources)
+		if err != nil {
+			fmt.Printf("❌ Konteyner güncellenemedi: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("✅ Konteyner kaynakları güncellendi: %s\n", c.Name)
+		if c.Resources != nil {
+			if c.Resources.Memory != "" {
+				fmt.Printf("   🧠 Bellek: %s\n", c.Resources.Memory)
+			}
+			if c.Resources.CPUs > 0 {
+				fmt.Printf("   🖥️  CPU: %g\n", c.Resources.CPUs)
+			}
+		}
+	},
+}
+
+var setRestartPolicyCmd = &cobra.Command{
+	Use:   "set-restart-policy [container-name] [policy]",
+	Short: "🔁 Konteyner restart policy'sini değiştir",
+	Long: `Bir konteynerin restart policy'sini yeniden oluşturmadan değiştirir.
+Geçerli değerler: no, always, unless-stopped, on-failure[:N]
+
+Örnek kullanım:
+  orca set-restart-policy my-container always
+  orca set-restart-policy my-container on-failure:5`,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		containerID := args[0]
+		policy := args[1]
+
+		if _, err := container.ParseRestartPolicy(policy); err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(exitInvalid)
+		}
+
+		fmt.Printf("🔁 Restart policy güncelleniyor: %s\n", containerID)
+		c, err := setRestartPolicy(containerID, policy)
+		if err != nil {
+			fmt.Printf("❌ Restart policy güncellenemedi: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("✅ Restart policy güncellendi: %s → %s\n", c.Name, c.RestartPolicy)
+	},
+}
+
+var diffContainerCmd = &cobra.Command{
+	Use:   "diff [container-name]",
+	Short: "🧾 Konteyner dosya sistemi değişikliklerini göster",
+	Long: `Konteynerin kök dosya sisteminde image'a göre yapılan değişiklikleri listeler.
+A: eklendi, C: değişti, D: silindi
+
+Örnek kullanım:
+  orca diff my-container`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		containerID := args[0]
+
+		changes, err := getContainerChanges(containerID)
+		if err != nil {
+			fmt.Printf("❌ Konteyner değişiklikleri alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		if len(changes) == 0 {
+			fmt.Println("📭 Dosya sisteminde değişiklik yok.")
+			return
+		}
+
+		for _, change := range changes {
+			fmt.Printf("%s %s\n", change.Kind, change.Path)
+		}
+	},
+}
+
+var exportContainerCmd = &cobra.Command{
+	Use:   "export-container [container-name] [output.tar]",
+	Short: "📦 Konteyner dosya sistemini tar arşivi olarak kaydet",
+	Long: `Konteynerin dosya sistemini olduğu gibi bir tar arşivine kaydeder; arşiv başka
+bir host'a taşınabilir (docker import) veya çevrimdışı incelenebilir. Volume'lar
+arşive dahil edilmez.
+
+Örnek kullanım:
+  orca export-container my-container my-container.tar`,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		containerID := args[0]
+		output := args[1]
+
+		file, err := os.Create(output)
+		if err != nil {
+			fmt.Printf("❌ Dosya oluşturulamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		defer file.Close()
+
+		fmt.Printf("📦 Konteyner dışa aktarılıyor: %s → %s\n", containerID, output)
+		written, err := exportContainer(containerID, file, func(written, total int64) {
+			fmt.Printf("\r   %s", units.HumanSize(float64(written)))
+		})
+		fmt.Println()
+		if err != nil {
+			file.Close()
+			os.Remove(output)
+			fmt.Printf("❌ Konteyner dışa aktarılamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("✅ Konteyner dışa aktarıldı: %s (%s)\n", output, units.HumanSize(float64(written)))
+	},
+}
+
+var catFileCmd = &cobra.Command{
+	Use:   "cat [container-name]:[path]",
+	Short: "📄 Konteyner içindeki tek bir dosyayı yazdır",
+	Long: `Konteynerdeki tek bir dosyanın içeriğini arşiv indirmeden standart çıktıya yazar.
+Yol mutlak olmalıdır; sembolik bağlantılar hedeflerine çözülür.
+
+Örnek kullanım:
+  orca cat my-container:/etc/app/config.yaml`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		containerID, filePath, ok := strings.Cut(args[0], ":")
+		if !ok || containerID == "" || filePath == "" {
+			fmt.Fprintf(os.Stderr, "❌ Geçersiz argüman: %s (<konteyner>:<yol> biçiminde olmalı)\n", args[0])
+			os.Exit(exitInvalid)
+		}
+
+		// Errors go to stderr so they never mix with the file contents
+		if err := catFile(containerID, filePath, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Dosya okunamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+	},
+}
+
+var topContainerCmd = &cobra.Command{
+	Use:   "top-procs [container-name]",
+	Short: "🔬 Konteyner içinde çalışan işlemleri göster",
+	Long: `Konteynere exec ile girmeden içinde çalışan işlemleri ps çıktısı olarak listeler.
+
+Örnek kullanım:
+  orca top-procs my-container`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		containerID := args[0]
+
+		processes, err := getContainerTop(containerID)
+		if err != nil {
+			fmt.Printf("❌ Konteyner işlemleri alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		if len(processes.Processes) == 0 {
+			fmt.Println("📭 Konteynerde çalışan işlem yok.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, strings.Join(processes.Titles, "\t"))
+		for _, process := range processes.Processes {
+			fmt.Fprintln(w, strings.Join(process, "\t"))
+		}
+		w.Flush()
+	},
+}
+
+var runContainerCmd = &cobra.Command{
+	Use:   "run [image]",
+	Short: "▶️  Image'dan hızlıca konteyner oluştur ve başlat",
+	Long: `Spec dosyası gerektirmeden flag'lerden bir konteyner oluşturur ve başlatır.
+-d verilmezse konteyner logları takip edilir.
+
+Örnek kullanım:
+  orca run nginx:alpine -p 8080:80
+  orca run redis:7 --name cache -e MAXMEMORY=256mb -d
+  orca run busybox:latest --rm`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		image := args[0]
+		name, _ := cmd.Flags().GetString("name")
+		portFlags, _ := cmd.Flags().GetStringArray("port")
+		envFlags, _ := cmd.Flags().GetStringArray("env")
+		detach, _ := cmd.Flags().GetBool("detach")
+		autoRemove, _ := cmd.Flags().GetBool("rm")
+		platform, _ := cmd.Flags().GetString("platform")
+		gpus, _ := cmd.Flags().GetString("gpus")
+		devices, _ := cmd.Flags().GetStringArray("device")
+		stopSignal, _ := cmd.Flags().GetString("stop-signal")
+		ulimitFlags, _ := cmd.Flags().GetStringArray("ulimit")
+
+		ports, err := parsePortFlags(portFlags)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(exitInvalid)
+		}
+
+		ulimits, err := parseUlimitFlags(ulimitFlags)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(exitInvalid)
+		}
+
+		env, err := parseKeyValues(envFlags)
+		if err != nil {
+			fmt.Printf("❌ %v\n", err)
+			os.Exit(exitInvalid)
+		}
+
+		if name == "" {
+			name = containerNameFromImage(image)
+		}
+
+		spec := container.ContainerSpec{
+			Name:        name,
+			Image:       image,
+			Ports:       ports,
+			Environment: env,
+			AutoRemove:  autoRemove,
+			Platform:    platform,
+			GPUs:        gpus,
+			Devices:     devices,
+			StopSignal:  stopSignal,
+			Ulimits:     ulimits,
+		}
+
+		fmt.Printf("🚀 Konteyner oluşturuluyor: %s (%s)\n", spec.Name, spec.Image)
+		c, err := createContainer(spec, true)
+		if err != nil {
+			fmt.Printf("❌ Konteyner çalıştırılamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("✅ Konteyner çalışıyor: %s (%s)\n", c.Name, truncateString(c.ID, 12))
+		printWarnings(c.Warnings)
+		if detach {
+			return
+		}
+
+		fmt.Printf("📜 Loglar takip ediliyor (çıkmak için Ctrl+C)\n")
+		if err := followContainerLogs(c.Name, logsRequest{Tail: "all"}, os.Stdout); err != nil {
+			fmt.Printf("❌ Konteyner logları alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+	},
+}
+
+var recreateContainerCmd = &cobra.Command{
+	Use:   "recreate [container-name]",
+	Short: "♻️  Konteyneri aynı yapılandırmayla yeniden oluştur",
+	Long: `Konteynerin mevcut yapılandırmasından (image, ortam değişkenleri, portlar,
+volume'lar, label'lar) bir spec çıkarır, eski konteyneri kaldırır ve aynı spec ile
+yeni bir konteyner oluşturup başlatır. Volume'lar korunur. Yeni konteyner
+başlatılamazsa eski konteyner geri yüklenir.
+
+Örnek kullanım:
+  orca recreate my-container
+  orca recreate web --pull`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		containerID := args[0]
+		pull, _ := cmd.Flags().GetBool("pull")
+
+		if pull {
+			fmt.Printf("📥 Image çekiliyor ve konteyner yeniden oluşturuluyor: %s\n", containerID)
+		} else {
+			fmt.Printf("♻️  Konteyner yeniden oluşturuluyor: %s\n", containerID)
+		}
+
+		c, err := recreateContainer(containerID, pull)
+		if err != nil {
+			fmt.Printf("❌ Konteyner yeniden oluşturulamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("✅ Konteyner yeniden oluşturuldu: %s (%s)\n", c.Name, truncateString(c.ID, 12))
+		printWarnings(c.Warnings)
+	},
+}
+
+var adoptContainerCmd = &cobra.Command{
+	Use:   "adopt [container-name]",
+	Short: "🤝 docker run ile oluşturulmuş konteyneri ORCA yönetimine al",
+	Long: `ORCA dışında (örneğin docker run ile) oluşturulmuş bir konteyneri tek replica'lı
+bir deployment olarak yönetime alır. Docker label'ları yerinde değiştirilemediğinden
+konteyner aynı image, ayarlar, volume'lar ve host portlarıyla deployment'ın 0.
+replica'sı olarak yeniden oluşturulur; bu sırada konteyner kısa bir süre durur.
+Yeni konteyner başlatılamazsa eski konteyner geri yüklenir. Bundan sonra reconcile
+döngüsü konteyneri çalışır tutar ve deployment ölçeklendirilebilir.
+
+Örnek kullanım:
+  orca adopt legacy-web
+  orca adopt legacy-web --deployment web`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		containerID := args[0]
+		name, _ := cmd.Flags().GetString("deployment")
+
+		fmt.Printf("🤝 Konteyner yönetime alınıyor: %s\n", containerID)
+		deployment, err := adoptContainer(containerID, name)
+		if err != nil {
+			fmt.Printf("❌ Konteyner yönetime alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("✅ Konteyner yönetime alındı: %s deployment'ı", deployment.Name)
+		if len(deployment.Replicas) > 0 {
+			fmt.Printf(", replica %s (%s)", deployment.Replicas[0].Name, truncateString(deployment.Replicas[0].ID, 12))
+		}
+		fmt.Println()
+	},
+}
+
+var commitContainerCmd = &cobra.Command{
+	Use:   "commit [container-name] [image:tag]",
+	Short: "📸 Konteynerin mevcut halini yeni bir image olarak kaydet",
+	Long: `Konteynerin dosya sistemini ve ayarlarını yeni bir image olarak kaydeder;
+etkileşimli olarak hata ayıklanıp düzenlenmiş bir konteynerin anlık görüntüsünü
+almak için kullanılır. Konteyner kayıt sırasında kısa süre duraklatılır. Volume'lar
+image'a dahil edilmez.
+
+Örnek kullanım:
+  orca commit my-container my-app:debug
+  orca commit my-container my-app:fixed -m "config düzeltildi" --author "Ayşe <ayse@example.com>"`,
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		containerID := args[0]
+		ref := args[1]
+		message, _ := cmd.Flags().GetString("message")
+		author, _ := cmd.Flags().GetString("author")
+
+		fmt.Printf("📸 Konteyner image olarak kaydediliyor: %s → %s\n", containerID, ref)
+		imageID, err := commitContainer(containerID, ref, message, author)
+		if err != nil {
+			fmt.Printf("❌ Konteyner image olarak kaydedilemedi: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("✅ Image oluşturuldu: %s (%s)\n", ref, imageID)
+	},
+}
+
+// Deployment commands
+var deployCmd = &cobra.Command{
+	Use:   "deploy [spec-file]",
+	Short: "Create a deployment from a spec file, or from stdin with -",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		specFile := args[0]
+		wait, _ := cmd.Flags().GetBool("wait")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		forceRecreate, _ := cmd.Flags().GetBool("force-recreate")
+		partial, _ := cmd.Flags().GetBool("partial")
+		
+		data, err := readSpecFile(specFile)
+		if err != nil {
+			fmt.Printf("Spec dosyası okunamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		var spec container.DeploymentSpec
+		if err := container.DecodeSpec(bytes.NewReader(data), &spec); err != nil {
+			fmt.Printf("Spec dosyası parse edilemedi: %v\n", err)
+			os.Exit(exitInvalid)
+		}
+
+		exists := false
+		if forceRecreate {
+			if exists, err = deploymentExists(spec.Namespace, spec.Name); err != nil {
+				fmt.Printf("Deployment bilgisi alınamadı: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+		}
+
+		var deployment *scheduler.Deployment
+		if exists {
+			// Replace every replica even if the spec is unchanged
+			fmt.Printf("Replica'lar yeniden oluşturuluyor: %s\n", spec.Name)
+			deployment, err = updateDeployment(spec, "", 0, true)
+			if err != nil {
+				fmt.Printf("Deployment yeniden oluşturulamadı: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+			fmt.Printf("Deployment yeniden oluşturuldu: %s (%d replicas)\n", deployment.Name, len(deployment.Replicas))
+		} else {
+			fmt.Printf("Deployment oluşturuluyor: %s\n", spec.Name)
+			deployment, err = createDeploymentWithProgress(spec, partial, printProgress)
+			if err != nil {
+				fmt.Printf("Deployment oluşturulamadı: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+			fmt.Printf("Deployment oluşturuldu: %s (%d replicas)\n", deployment.Name, len(deployment.Replicas))
+		}
+		if len(deployment.WaitingFor) > 0 {
+			fmt.Printf("Bağımlılıklar bekleniyor: %s\n", strings.Join(deployment.WaitingFor, ", "))
+		}
+		if len(deployment.MissingReplicas) > 0 {
+			fmt.Printf("Deployment eksik (%s): %d/%d replica oluşturuldu, eksik replica'lar: %s (reconcile döngüsü yeniden deneyecek)\n",
+				deployment.Status, len(deployment.Replicas), deployment.Spec.Replicas, formatIndices(deployment.MissingReplicas))
+		}
+		if !wait {
+			return
+		}
+
+		if err := waitDeploymentReady(deployment.Name, timeout); err != nil {
+			fmt.Printf("Deployment hazır olmadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		fmt.Printf("Deployment hazır: %s\n", deployment.Name)
+	},
+}
+
+// formatIndices formats replica indices as a comma separated list
+func formatIndices(indices []int) string {
+	parts := make([]string, len(indices))
+	for i, index := range indices {
+		parts[i] = strconv.Itoa(index)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printProgress prints a step reported by the server while a deployment is
+// created
+func printProgress(progress scheduler.Progress) {
+	fmt.Printf("  %s  %s\n", progress.Time.Local().Format("15:04:05"), progress.Message)
+}
+
+// waitDeploymentReady polls the status of a deployment until all desired
+// replicas are ready, printing progress whenever it changes
+func waitDeploymentReady(name string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	lastReady := -1
+
+	for {
+		status, err := getDeploymentStatus(name)
+		if err != nil {
+			return err
+		}
+		if status.Ready != lastReady {
+			fmt.Printf("Hazır replica'lar: %d/%d\n", status.Ready, status.Desired)
+			lastReady = status.Ready
+		}
+		if status.Ready >= status.Desired {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s içinde yalnızca %d/%d replica hazır", timeout, status.Ready, status.Desired)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+var listDeploymentsCmd = &cobra.Command{
+	Use:     "deployments",
+	Aliases: []string{"deploy"},
+	Short:   "List deployments",
+	Run: func(cmd *cobra.Command, args []string) {
+		selector, _ := cmd.Flags().GetString("selector")
+		limit, _ := cmd.Flags().GetInt("limit")
+		offset, _ := cmd.Flags().GetInt("offset")
+
+		if selector != "" {
+			if _, err := scheduler.ParseSelector(selector); err != nil {
+				fmt.Printf("❌ %v\n", err)
+				os.Exit(exitInvalid)
+			}
+		}
+
+		var deployments []*scheduler.Deployment
+		var total int
+		var err error
+		if limit > 0 {
+			deployments, total, err = listDeploymentsPage(selector, limit, offset)
+		} else {
+			deployments, err = listDeployments(selector)
+			total = len(deployments)
+		}
+		if err != nil {
+			fmt.Printf("Deployment listesi alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		if len(deployments) == 0 {
+			fmt.Println("Hiç deployment bulunamadı.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		header := "NAME\tREPLICAS\tSTATUS\tCREATED"
+		if allNamespaces {
+			header = "NAMESPACE\t" + header
+		}
+		fmt.Fprintln(w, header)
+		
+		for _, d := range deployments {
+			created := d.Created.Format("2006-01-02 15:04:05")
+			if allNamespaces {
+				fmt.Fprintf(w, "%s\t", d.Spec.Namespace)
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", 
+				d.Name, len(d.Replicas), d.Status, created)
+		}
+		
+		w.Flush()
+
+		if len(deployments) < total {
+			fmt.Printf("\n%d-%d / %d deployment gösteriliyor (sonraki sayfa: --offset %d)\n",
+				offset+1, offset+len(deployments), total, offset+len(deployments))
+		}
+	},
+}
+
+var deleteDeploymentCmd = &cobra.Command{
+	Use:   "delete-deployment [name]",
+	Short: "Delete a deployment",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		selectorStr, _ := cmd.Flags().GetString("selector")
+		if selectorStr != "" {
+			selector, err := scheduler.ParseSelector(selectorStr)
+			if err != nil {
+				fmt.Printf("Selector parse edilemedi: %v\n", err)
+				os.Exit(exitInvalid)
+			}
+
+			results, err := batchDeleteDeployments(selector)
+			if err != nil {
+				fmt.Printf("Deployment'lar silinemedi: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+
+			printBatchDeleteResults("Deployment", results)
+			return
+		}
+
+		if len(args) != 1 {
+			fmt.Println("Deployment adı veya --selector belirtilmelidir")
+			os.Exit(exitInvalid)
+		}
+		name := args[0]
+		removeVolume, _ := cmd.Flags().GetBool("remove-volume")
+		
+		if err := deleteDeployment(name, removeVolume); err != nil {
+			fmt.Printf("Deployment silinemedi: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("Deployment silindi: %s\n", name)
+	},
+}
+
+var scaleDeploymentCmd = &cobra.Command{
+	Use:   "scale [name] [replicas]",
+	Short: "Scale a deployment, or show its replica counts without a replica count",
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		if len(args) == 1 {
+			status, err := getScale(name)
+			if err != nil {
+				fmt.Printf("Replica sayıları alınamadı: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+			fmt.Printf("%s: %d istenen, %d mevcut, %d hazır\n", status.Name, status.Desired, status.Current, status.Ready)
+			return
+		}
+
+		replicas, err := strconv.Atoi(args[1])
+		if err != nil {
+			fmt.Printf("Geçersiz replica sayısı: %s\n", args[1])
+			os.Exit(exitInvalid)
+		}
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			plan, err := planScaleDeployment(name, replicas)
+			if err != nil {
+				fmt.Printf("Plan alınamadı: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+			printPlan(plan)
+			return
+		}
+
+		status, err := scaleDeployment(name, replicas)
+		if err != nil {
+			fmt.Printf("Deployment ölçeklendirilemedi: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("Deployment ölçeklendirildi: %s (%d/%d replicas, %d hazır)\n", status.Name, status.Current, status.Desired, status.Ready)
+	},
+}
+
+var dumpDeploymentCmd = &cobra.Command{
+	Use:   "dump [name]",
+	Short: "Snapshot inspect output, logs and stats of all replicas of a deployment",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		tail, _ := cmd.Flags().GetString("tail")
+		output, _ := cmd.Flags().GetString("output")
+		asTar, _ := cmd.Flags().GetBool("tar")
+
+		root := fmt.Sprintf("%s-dump-%s", name, time.Now().Format("20060102-150405"))
+		if output == "" {
+			output = root
+			if asTar {
+				output += ".tar.gz"
+			}
+		}
+
+		fmt.Printf("Deployment dump alınıyor: %s\n", name)
+		files, failures, err := collectDump(name, tail)
+		if err != nil {
+			fmt.Printf("Deployment dump alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		if asTar {
+			err = writeDumpTar(output, root, files)
+		} else {
+			err = writeDumpDir(output, files)
+		}
+		if err != nil {
+			fmt.Printf("Dump yazılamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("Dump kaydedildi: %s (%d dosya)\n", output, len(files))
+		for _, failure := range failures {
+			fmt.Printf("Alınamadı: %s\n", failure)
+		}
+	},
+}
+
+var orphansCmd = &cobra.Command{
+	Use:   "orphans",
+	Short: "List replica containers whose deployment no longer exists",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if prune, _ := cmd.Flags().GetBool("prune"); prune {
+			results, err := pruneOrphans()
+			if err != nil {
+				fmt.Printf("Sahipsiz konteynerler silinemedi: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+			if len(results) == 0 {
+				fmt.Println("Sahipsiz konteyner bulunamadı.")
+				return
+			}
+			printBatchDeleteResults("Container", results)
+			return
+		}
+
+		orphans, err := listOrphans()
+		if err != nil {
+			fmt.Printf("Sahipsiz konteynerler listelenemedi: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		if len(orphans) == 0 {
+			fmt.Println("Sahipsiz konteyner bulunamadı.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tDEPLOYMENT\tSTATUS\tCREATED")
+		for _, c := range orphans {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+				c.Name, c.Labels[container.DeploymentLabel], c.Status, c.Created.Format("2006-01-02 15:04:05"))
+		}
+		w.Flush()
+
+		fmt.Printf("\nSilmek için: orca orphans --prune\n")
+	},
+}
+
+var rolloutCmd = &cobra.Command{
+	Use:   "rollout",
+	Short: "Manage deployment rollouts",
+}
+
+var rolloutRestartCmd = &cobra.Command{
+	Use:   "restart [name]",
+	Short: "Recycle all replicas of a deployment one at a time",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			plan, err := planRestartDeployment(name)
+			if err != nil {
+				fmt.Printf("Plan alınamadı: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+			printPlan(plan)
+			return
+		}
+
+		fmt.Printf("Deployment yeniden başlatılıyor: %s\n", name)
+		deployment, err := restartDeployment(name)
+		if err != nil {
+			fmt.Printf("Deployment yeniden başlatılamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("Deployment yeniden başlatıldı: %s (%d replicas)\n", deployment.Name, len(deployment.Replicas))
+	},
+}
+
+var rolloutUpdateCmd = &cobra.Command{
+	Use:   "update [spec-file]",
+	Short: "Roll out a new spec to an existing deployment, optionally as a canary",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		canary, _ := cmd.Flags().GetString("canary")
+		autoPromote, _ := cmd.Flags().GetDuration("auto-promote")
+		forceRecreate, _ := cmd.Flags().GetBool("force-recreate")
+
+		data, err := readSpecFile(args[0])
+		if err != nil {
+			fmt.Printf("Spec dosyası okunamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		var spec container.DeploymentSpec
+		if err := container.DecodeSpec(bytes.NewReader(data), &spec); err != nil {
+			fmt.Printf("Spec dosyası parse edilemedi: %v\n", err)
+			os.Exit(exitInvalid)
+		}
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			plan, err := planUpdateDeployment(spec, canary, autoPromote, forceRecreate)
+			if err != nil {
+				fmt.Printf("Plan alınamadı: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+			printPlan(plan)
+			return
+		}
+
+		fmt.Printf("Deployment güncelleniyor: %s\n", spec.Name)
+		deployment, err := updateDeployment(spec, canary, autoPromote, forceRecreate)
+		if err != nil {
+			fmt.Printf("Deployment güncellenemedi: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		if rollout := deployment.Rollout; rollout != nil {
+			fmt.Printf("Canary hazır: %s (%d/%d replica güncellendi)\n", deployment.Name, rollout.Updated, len(deployment.Replicas))
+			if rollout.PromoteAt != nil {
+				fmt.Printf("Sağlıklı kalırsa %s tarihinde otomatik promote edilecek\n", rollout.PromoteAt.Format("2006-01-02 15:04:05"))
+			}
+			fmt.Printf("Devam etmek için: orca rollout promote %s\n", deployment.Name)
+			fmt.Printf("Geri almak için: orca rollout abort %s\n", deployment.Name)
+			return
+		}
+		fmt.Printf("Deployment güncellendi: %s (%d replicas)\n", deployment.Name, len(deployment.Replicas))
+	},
+}
+
+var rolloutPromoteCmd = &cobra.Command{
+	Use:   "promote [name]",
+	Short: "Roll a paused canary out to the remaining replicas",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		fmt.Printf("Canary promote ediliyor: %s\n", name)
+		deployment, err := promoteDeployment(name)
+		if err != nil {
+			fmt.Printf("Rollout promote edilemedi: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("Rollout tamamlandı: %s (%d replicas)\n", deployment.Name, len(deployment.Replicas))
+	},
+}
+
+var rolloutAbortCmd = &cobra.Command{
+	Use:   "abort [name]",
+	Short: "Revert the canary replicas of a paused rollout to the previous spec",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			plan, err := planAbortRollout(name)
+			if err != nil {
+				fmt.Printf("Plan alınamadı: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+			printPlan(plan)
+			return
+		}
+
+		fmt.Printf("Rollout geri alınıyor: %s\n", name)
+		deployment, err := abortRollout(name)
+		if err != nil {
+			fmt.Printf("Rollout geri alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("Rollout geri alındı: %s (image: %s)\n", deployment.Name, deployment.Spec.Container.Image)
+	},
+}
+
+var rolloutStatusCmd = &cobra.Command{
+	Use:   "status [name]",
+	Short: "Show the rollout state of a deployment",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		status, err := getDeploymentStatus(name)
+		if err != nil {
+			fmt.Printf("Deployment durumu alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		rollout := status.Rollout
+		if rollout == nil {
+			fmt.Printf("Devam eden rollout yok: %s (%d/%d replica hazır)\n", name, status.Ready, status.Desired)
+			return
+		}
+		fmt.Printf("Rollout: %s\n", formatRollout(rollout))
+		if rollout.PromoteAt != nil {
+			fmt.Printf("Otomatik promote: %s\n", rollout.PromoteAt.Format("2006-01-02 15:04:05"))
+		}
+	},
+}
+
+// formatRollout summarizes a rollout in one line
+func formatRollout(rollout *scheduler.RolloutStatus) string {
+	summary := fmt.Sprintf("%s, %d/%d replica güncellendi (%s → %s)", rollout.Phase, rollout.Updated, rollout.Replicas, rollout.PreviousImage, rollout.Image)
+	if rollout.Canary > 0 {
+		summary += fmt.Sprintf(", canary %d", rollout.Canary)
+	}
+	return summary
+}
+
+// Service commands
+var createServiceCmd = &cobra.Command{
+	Use:   "create-service [spec-file]",
+	Short: "Create a service from a spec file, or from stdin with -",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		specFile := args[0]
+		
+		data, err := readSpecFile(specFile)
+		if err != nil {
+			fmt.Printf("Spec dosyası okunamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		var spec container.ServiceSpec
+		if err := container.DecodeSpec(bytes.NewReader(data), &spec); err != nil {
+			fmt.Printf("Spec dosyası parse edilemedi: %v\n", err)
+			os.Exit(exitInvalid)
+		}
+
+		service, err := createService(spec)
+		if err != nil {
+			fmt.Printf("Service oluşturulamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("Service oluşturuldu: %s\n", service.Name)
+	},
+}
+
+var listServicesCmd = &cobra.Command{
+	Use:     "services",
+	Aliases: []string{"svc"},
+	Short:   "List services",
+	Run: func(cmd *cobra.Command, args []string) {
+		services, err := listServices()
+		if err != nil {
+			fmt.Printf("Service listesi alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		if len(services) == 0 {
+			fmt.Println("Hiç service bulunamadı.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		header := "NAME\tTYPE\tPORTS\tSTATUS\tCREATED"
+		if allNamespaces {
+			header = "NAMESPACE\t" + header
+		}
+		fmt.Fprintln(w, header)
+		
+		for _, s := range services {
+			ports := formatServicePorts(s.Spec.Ports)
+			created := s.Created.Format("2006-01-02 15:04:05")
+			if allNamespaces {
+				fmt.Fprintf(w, "%s\t", container.NormalizeNamespace(s.Spec.Namespace))
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", 
+				s.Name, s.Spec.Type, ports, formatServiceStatus(s), created)
+		}
+		
+		w.Flush()
+	},
+}
+
+var deleteServiceCmd = &cobra.Command{
+	Use:   "delete-service [name]",
+	Short: "Delete a service",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		selectorStr, _ := cmd.Flags().GetString("selector")
+		if selectorStr != "" {
+			selector, err := scheduler.ParseSelector(selectorStr)
+			if err != nil {
+				fmt.Printf("Selector parse edilemedi: %v\n", err)
+				os.Exit(exitInvalid)
+			}
+
+			results, err := batchDeleteServices(selector)
+			if err != nil {
+				fmt.Printf("Service'ler silinemedi: %v\n", err)
+				os.Exit(exitCode(err))
+			}
+
+			printBatchDeleteResults("Service", results)
+			return
+		}
+
+		if len(args) != 1 {
+			fmt.Println("Service adı veya --selector belirtilmelidir")
+			os.Exit(exitInvalid)
+		}
+		name := args[0]
+		
+		if err := deleteService(name); err != nil {
+			fmt.Printf("Service silinemedi: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("Service silindi: %s\n", name)
+	},
+}
+
+// Secret commands
+var secretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Manage secrets mounted into containers as files",
+}
+
+var secretCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Create a secret from files",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		fromFiles, _ := cmd.Flags().GetStringArray("from-file")
+		if len(fromFiles) == 0 {
+			fmt.Println("En az bir --from-file belirtilmelidir")
+			os.Exit(exitInvalid)
+		}
+
+		data, err := readSecretFiles(fromFiles)
+		if err != nil {
+			fmt.Printf("Secret dosyası okunamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		secret, err := createSecret(container.Secret{Name: name, Data: data})
+		if err != nil {
+			fmt.Printf("Secret oluşturulamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("Secret oluşturuldu: %s (%s)\n", secret.Name, strings.Join(secret.Keys, ", "))
+	},
+}
+
+var secretListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List secrets without their data",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		secrets, err := listSecrets()
+		if err != nil {
+			fmt.Printf("Secret listesi alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		if len(secrets) == 0 {
+			fmt.Println("Hiç secret bulunamadı.")
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		header := "NAME\tKEYS\tCREATED"
+		if allNamespaces {
+			header = "NAMESPACE\t" + header
+		}
+		fmt.Fprintln(w, header)
+
+		for _, s := range secrets {
+			if allNamespaces {
+				fmt.Fprintf(w, "%s\t", container.NormalizeNamespace(s.Namespace))
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", s.Name, strings.Join(s.Keys, ","), s.Created.Format("2006-01-02 15:04:05"))
+		}
+
+		w.Flush()
+	},
+}
+
+var secretDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Short: "Delete a secret; containers that mount it keep their files",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+
+		if err := deleteSecret(name); err != nil {
+			fmt.Printf("Secret silinemedi: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("Secret silindi: %s\n", name)
+	},
+}
+
+// readSecretFiles reads key=path pairs into base64 encoded secret data. A
+// path without a key is stored under its file name.
+func readSecretFiles(fromFiles []string) (map[string]string, error) {
+	data := make(map[string]string, len(fromFiles))
+	for _, fromFile := range fromFiles {
+		key, path, found := strings.Cut(fromFile, "=")
+		if !found {
+			key, path = filepath.Base(fromFile), fromFile
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		data[key] = base64.StdEncoding.EncodeToString(content)
+	}
+	return data, nil
+}
+
+// Utility commands
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "📊 Sistem istatistiklerini göster",
+	Long: `ORCA sisteminin genel durumu ve istatistiklerini görüntüler.
+
+Örnek kullanım:
+  orca stats`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("📊 Sistem istatistikleri getiriliyor...")
+		stats, err := getStats()
+		if err != nil {
+			fmt.Printf("❌ İstatistikler alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("\n🐋 ORCA Sistem İstatistikleri:\n")
+		fmt.Printf("═══════════════════════════════════════\n")
+		
+		// Containers bilgisini güvenli şekilde al
+		if containers, ok := stats["containers"].(map[string]interface{}); ok {
+			total := int(containers["total"].(float64))
+			running := int(containers["running"].(float64))
 			fmt.Printf("📦 Konteynerler: %d toplam, %d çalışıyor\n", total, running)
 			
 			if total > 0 {
@@ -512,8 +2104,22 @@ var statsCmd = &cobra.Command{
 		}
 		
 		// Deployments ve Services bilgisini güvenli şekilde al
-		if deployments, ok := stats["deployments"].(float64); ok {
-			fmt.Printf("🚀 Deployment'lar: %d\n", int(deployments))
+		healthy := true
+		if deployments, ok := stats["deployments"].(map[string]interface{}); ok {
+			count := func(key string) int {
+				value, _ := deployments[key].(float64)
+				return int(value)
+			}
+			fmt.Printf("🚀 Deployment'lar: %d toplam, %d/%d replica hazır\n", count("total"), count("ready_replicas"), count("desired_replicas"))
+			if count("total") > 0 {
+				fmt.Printf("   🟢 Kullanılabilir: %d\n", count("available"))
+				fmt.Printf("   🟡 Eksik replica: %d\n", count("degraded"))
+				fmt.Printf("   🔴 Başarısız: %d\n", count("failed"))
+				if progressing := count("progressing"); progressing > 0 {
+					fmt.Printf("   ⏳ Oluşturuluyor/siliniyor: %d\n", progressing)
+				}
+			}
+			healthy = count("degraded") == 0 && count("failed") == 0
 		} else {
 			fmt.Printf("🚀 Deployment'lar: 0\n")
 		}
@@ -524,7 +2130,150 @@ var statsCmd = &cobra.Command{
 			fmt.Printf("🌐 Servisler: 0\n")
 		}
 		
-		fmt.Printf("\n✅ Sistem sağlıklı ve çalışıyor!\n")
+		if healthy {
+			fmt.Printf("\n✅ Sistem sağlıklı ve çalışıyor!\n")
+		} else {
+			fmt.Printf("\n⚠️  Eksik replica'sı olan deployment'lar var\n")
+		}
+	},
+}
+
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "🩺 Reconcile döngüsünü yönet",
+	Long: `Çöken veya silinen replica'ları yeniden oluşturan reconcile döngüsünü yönetir.
+Bakım sırasında konteynerlere elle müdahale ederken döngüyü duraklatın.
+
+Örnek kullanım:
+  orca reconcile status
+  orca reconcile pause
+  orca reconcile resume`,
+}
+
+var reconcileStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Reconcile döngüsünün durumunu göster",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		status, err := getReconcileStatus()
+		if err != nil {
+			fmt.Printf("❌ Reconcile durumu alınamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		printReconcileStatus(status)
+	},
+}
+
+var reconcilePauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Reconcile döngüsünü duraklat",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		status, err := setReconcilePaused(true)
+		if err != nil {
+			fmt.Printf("❌ Reconcile döngüsü duraklatılamadı: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		fmt.Printf("⏸️  Reconcile döngüsü duraklatıldı\n")
+		printReconcileStatus(status)
+	},
+}
+
+var reconcileResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Reconcile döngüsünü devam ettir",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		status, err := setReconcilePaused(false)
+		if err != nil {
+			fmt.Printf("❌ Reconcile döngüsü devam ettirilemedi: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		fmt.Printf("▶️  Reconcile döngüsü devam ettirildi\n")
+		printReconcileStatus(status)
+	},
+}
+
+// printReconcileStatus prints the state of the reconcile loop
+func printReconcileStatus(status *scheduler.ReconcileStatus) {
+	state := "aktif"
+	switch {
+	case status.Paused:
+		state = "duraklatıldı"
+	case !status.Active:
+		state = "kapalı"
+	}
+	fmt.Printf("🩺 Reconcile: %s (aralık %s)\n", state, status.Interval)
+	if status.LastRun != nil {
+		fmt.Printf("🕒 Son çalışma: %s\n", status.LastRun.Format("2006-01-02 15:04:05"))
+	} else {
+		fmt.Printf("🕒 Son çalışma: henüz çalışmadı\n")
+	}
+}
+
+// printPlan prints the steps of a dry run in the order they would run
+func printPlan(plan *scheduler.Plan) {
+	fmt.Printf("Dry run: %s %s (namespace %s)", plan.Operation, plan.Deployment, plan.Namespace)
+	if plan.Strategy != "" {
+		fmt.Printf(", strateji %s", plan.Strategy)
+	}
+	if plan.Canary > 0 {
+		fmt.Printf(", canary %d replica", plan.Canary)
+	}
+	fmt.Println()
+
+	if len(plan.Steps) == 0 {
+		fmt.Println("Değişiklik yok")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STEP\tACTION\tINDEX\tREPLICA\tCONTAINER ID\tIMAGE\tPORTS")
+	for i, step := range plan.Steps {
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%s\t%s\n", i+1, step.Action, step.Index, step.Replica,
+			valueOr(truncateString(step.ContainerID, 12), "-"), step.Image, formatPorts(step.Ports))
+	}
+	w.Flush()
+	fmt.Println("Hiçbir değişiklik yapılmadı")
+}
+
+var systemCmd = &cobra.Command{
+	Use:   "system",
+	Short: "Manage Docker resources on the server host",
+}
+
+var systemPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove stopped containers, dangling images and unused networks",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		volumes, _ := cmd.Flags().GetBool("volumes")
+		filters, _ := cmd.Flags().GetStringArray("filter")
+
+		until := ""
+		for _, filter := range filters {
+			key, value, ok := strings.Cut(filter, "=")
+			if !ok || key != "until" || value == "" {
+				fmt.Printf("❌ Geçersiz filtre: %s (desteklenen: until=<süre veya zaman>)\n", filter)
+				os.Exit(exitInvalid)
+			}
+			until = value
+		}
+
+		report, err := systemPrune(volumes, until)
+		if err != nil {
+			fmt.Printf("❌ Sistem temizliği başarısız: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+
+		fmt.Printf("🧹 Silinen container: %d\n", len(report.ContainersDeleted))
+		fmt.Printf("🖼️  Silinen image: %d\n", len(report.ImagesDeleted))
+		fmt.Printf("🌐 Silinen network: %d\n", len(report.NetworksDeleted))
+		if volumes {
+			fmt.Printf("💾 Silinen volume: %d\n", len(report.VolumesDeleted))
+		}
+		fmt.Printf("🧱 Silinen build cache: %d\n", len(report.BuildCacheDeleted))
+		fmt.Printf("✅ Geri kazanılan alan: %s\n", units.HumanSize(float64(report.SpaceReclaimed)))
 	},
 }
 
@@ -536,17 +2285,128 @@ var versionCmd = &cobra.Command{
 Örnek kullanım:
   orca version`,
 	Run: func(cmd *cobra.Command, args []string) {
+		info := build.GetInfo()
+
 		fmt.Print(orcaBanner)
 		fmt.Printf("\n📋 Sürüm Bilgileri:\n")
 		fmt.Printf("═══════════════════════════════════════\n")
-		fmt.Printf("🐋 ORCA CLI: v1.0.0\n")
-		fmt.Printf("🔧 Go Runtime: %s\n", "go1.21+")
-		fmt.Printf("🏗️  Build: Production\n")
-		fmt.Printf("📅 Release Date: 2025-01-28\n")
+		fmt.Printf("🐋 ORCA CLI: v%s\n", info.Version)
+		fmt.Printf("🔧 Go Runtime: %s\n", info.GoVersion)
+		fmt.Printf("🏗️  Commit: %s\n", info.Commit)
+		fmt.Printf("📅 Build Date: %s\n", info.Date)
+
+		server, err := getServerVersion()
+		if err != nil {
+			fmt.Printf("🖥️  ORCA Server: ulaşılamadı (%v)\n", err)
+		} else {
+			fmt.Printf("\n🖥️  ORCA Server: v%s\n", server.Version)
+			fmt.Printf("🔧 Go Runtime: %s\n", server.GoVersion)
+			fmt.Printf("🏗️  Commit: %s\n", server.Commit)
+			fmt.Printf("📅 Build Date: %s\n", server.Date)
+			if server.DockerVersion != "" {
+				fmt.Printf("🐳 Docker: %s (API %s)\n", server.DockerVersion, server.DockerAPIVersion)
+			} else {
+				fmt.Printf("🐳 Docker: ulaşılamadı\n")
+			}
+			if serverInfo, err := getServerInfo(); err == nil {
+				fmt.Printf("⏱️  Uptime: %s\n", serverInfo.Uptime)
+			}
+
+			// Only a major version difference is likely to break the API
+			cliMajor, serverMajor := majorVersion(info.Version), majorVersion(server.Version)
+			if cliMajor != "" && serverMajor != "" && cliMajor != serverMajor {
+				fmt.Printf("\n⚠️  Ana sürüm uyuşmazlığı: CLI v%s, server v%s; komutlar beklendiği gibi çalışmayabilir\n", info.Version, server.Version)
+			} else if server.Version != info.Version {
+				fmt.Printf("\nℹ️  Sürüm farkı: CLI v%s, server v%s\n", info.Version, server.Version)
+			}
+		}
+
 		fmt.Printf("\n💡 Daha fazla bilgi için: orca --help\n")
 	},
 }
 
 func init() {
-	logsContainerCmd.Flags().Int("tail", 100, "Number of lines to show from the end of the logs")
+	logsContainerCmd.Flags().String("tail", "100", "Number of lines to show from the end of the logs, or \"all\"")
+	logsContainerCmd.Flags().String("grep", "", "Only show log lines matching the regular expression (filtered on the server)")
+	logsContainerCmd.Flags().BoolP("follow", "f", false, "Follow log output")
+	eventsCmd.Flags().StringSlice("type", nil, "Only show these Docker actions, e.g. die,start (repeatable or comma separated)")
+	eventsCmd.Flags().String("name", "", "Only show events of the container with this name")
+	eventsCmd.Flags().String("since", "", "Replay events since a timestamp (RFC3339) or relative duration (e.g. 30m, 1d) before following")
+	eventsCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "Show events of all namespaces")
+	auditCmd.Flags().Int("tail", 100, "Number of most recent entries to show (0 for all)")
+	auditCmd.Flags().BoolP("follow", "f", false, "Keep printing new entries as they are recorded")
+	describeCmd.Flags().String("since", "", "Only show events since a timestamp (RFC3339) or relative duration (e.g. 30m, 2h, 1d)")
+	auditCmd.Flags().String("since", "", "Only show entries since a timestamp (RFC3339) or relative duration (e.g. 30m, 2h, 1d)")
+	logsContainerCmd.Flags().String("since", "", "Only show logs since a timestamp (RFC3339) or relative duration (e.g. 10m, 2h, 1d)")
+	logsContainerCmd.Flags().BoolP("timestamps", "t", false, "Show timestamps; deployment logs are interleaved by time")
+	logsContainerCmd.Flags().String("max-bytes", "", "Stop reading the logs after this much data (e.g. 512k, 1MB); at most the server's max_log_bytes")
+	logsContainerCmd.Flags().StringP("output", "o", "", "Write the logs to a file instead of the terminal (defaults to --tail all)")
+	logsContainerCmd.Flags().BoolP("previous", "p", false, "Show the logs of the last exited container of a deployment replica, kept when it was replaced")
+	attachContainerCmd.Flags().Bool("stdin", false, "Send standard input to the process; the container must have an open stdin")
+	listContainersCmd.Flags().StringP("label", "l", "", "Only list containers matching the label selector (e.g. app=web)")
+	listContainersCmd.Flags().StringSlice("show-label", nil, "Show the value of a label as an extra column (repeatable)")
+	listContainersCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List containers of all namespaces")
+	listDeploymentsCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List deployments of all namespaces")
+	listServicesCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List services of all namespaces")
+	secretListCmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List secrets of all namespaces")
+	secretCreateCmd.Flags().StringArray("from-file", nil, "Add a file as key=path; the key defaults to the file name (repeatable)")
+	listContainersCmd.Flags().Bool("size", false, "Show the writable layer and total filesystem size of each container (slow)")
+	for _, c := range []*cobra.Command{startContainerCmd, stopContainerCmd} {
+		c.Flags().Bool("all", false, "Apply to all containers, or to those matching --label")
+		c.Flags().StringP("label", "l", "", "With --all, only containers matching the label selector (e.g. env=staging)")
+		c.Flags().Int("parallel", 5, "With --all, maximum number of concurrent operations")
+	}
+	createContainerCmd.Flags().Bool("start", false, "Start the container after creating it; it is removed again if the start fails")
+	createContainerCmd.Flags().String("image", "", "Image to use; required without a spec file")
+	createContainerCmd.Flags().String("name", "", "Container name (default: from the spec file or derived from the image)")
+	createContainerCmd.Flags().StringArrayP("env", "e", nil, "Set an environment variable as KEY=VALUE (repeatable)")
+	createContainerCmd.Flags().StringArray("label", nil, "Set a label as KEY=VALUE (repeatable)")
+	createContainerCmd.Flags().StringArrayP("port", "p", nil, "Publish a port as hostPort:containerPort[/protocol] (repeatable)")
+	createContainerCmd.Flags().StringArrayP("volume", "v", nil, "Mount a volume as source:destination[:ro] (repeatable)")
+	createContainerCmd.Flags().String("platform", "", "Image platform as os/arch[/variant], e.g. linux/amd64")
+	createContainerCmd.Flags().String("gpus", "", "GPUs to expose: \"all\" or a count")
+	createContainerCmd.Flags().String("stop-signal", "", "Signal sent to stop the container, e.g. SIGQUIT (default: the image's or SIGTERM)")
+	createContainerCmd.Flags().StringArray("device", nil, "Pass a host device through as host[:container][:permissions] (repeatable)")
+	createContainerCmd.Flags().StringArray("ulimit", nil, "Set a ulimit as name=soft[:hard], e.g. nofile=65536:65536 (repeatable)")
+	createContainerCmd.Flags().Bool("replace", false, "Replace the spec file's env, labels, ports or volumes with the given flags instead of merging")
+	runContainerCmd.Flags().String("name", "", "Container name (default: derived from the image)")
+	runContainerCmd.Flags().StringArrayP("port", "p", nil, "Publish a port as hostPort:containerPort[/protocol] (repeatable)")
+	runContainerCmd.Flags().StringArrayP("env", "e", nil, "Set an environment variable as KEY=VALUE (repeatable)")
+	runContainerCmd.Flags().BoolP("detach", "d", false, "Run in the background instead of following the logs")
+	runContainerCmd.Flags().Bool("rm", false, "Automatically remove the container when it exits")
+	runContainerCmd.Flags().String("platform", "", "Image platform as os/arch[/variant], e.g. linux/amd64")
+	runContainerCmd.Flags().String("gpus", "", "GPUs to expose: \"all\" or a count")
+	runContainerCmd.Flags().String("stop-signal", "", "Signal sent to stop the container, e.g. SIGQUIT (default: the image's or SIGTERM)")
+	runContainerCmd.Flags().StringArray("device", nil, "Pass a host device through as host[:container][:permissions] (repeatable)")
+	runContainerCmd.Flags().StringArray("ulimit", nil, "Set a ulimit as name=soft[:hard], e.g. nofile=65536:65536 (repeatable)")
+
+	recreateContainerCmd.Flags().Bool("pull", false, "Pull the image before recreating the container")
+	commitContainerCmd.Flags().StringP("message", "m", "", "Commit message recorded in the image history")
+	commitContainerCmd.Flags().String("author", "", "Author of the image (e.g. \"Name <email>\")")
+	adoptContainerCmd.Flags().String("deployment", "", "Name of the deployment to create (default: the container name)")
+	updateContainerCmd.Flags().String("memory", "", "Memory limit (e.g. 512m, 1GB)")
+	updateContainerCmd.Flags().Float64("cpus", 0, "Number of CPUs (e.g. 1.5)")
+	deployCmd.Flags().Bool("wait", false, "Wait until all replicas of the deployment are ready")
+	deployCmd.Flags().Duration("timeout", 5*time.Minute, "Maximum time to wait with --wait")
+	deployCmd.Flags().Bool("force-recreate", false, "If the deployment exists, roll out the spec and replace every replica even if the spec is unchanged")
+	deployCmd.Flags().Bool("partial", false, "Keep the replicas created before one fails; the deployment is degraded and the reconcile loop creates the missing ones")
+	listDeploymentsCmd.Flags().StringP("selector", "l", "", "Only list deployments matching the label selector (e.g. app=web)")
+	listDeploymentsCmd.Flags().Int("limit", 0, "Maximum number of deployments to list (default: all, fetched page by page)")
+	listDeploymentsCmd.Flags().Int("offset", 0, "Number of deployments to skip when --limit is set")
+	deleteDeploymentCmd.Flags().Bool("remove-volume", false, "Also remove the shared volume of the deployment")
+	deleteDeploymentCmd.Flags().StringP("selector", "l", "", "Delete all deployments matching the label selector (e.g. app=legacy)")
+	scaleDeploymentCmd.Flags().Bool("dry-run", false, "Print the replicas that would be created or removed without changing anything")
+	rolloutRestartCmd.Flags().Bool("dry-run", false, "Print the replicas that would be replaced, in order, without changing anything")
+	rolloutUpdateCmd.Flags().Bool("dry-run", false, "Print the replicas that would be replaced, in order, without changing anything")
+	rolloutAbortCmd.Flags().Bool("dry-run", false, "Print the replicas that would be reverted, in order, without changing anything")
+	rolloutUpdateCmd.Flags().String("canary", "", "Update only this many replicas (or a percentage such as 25%) and pause until promoted")
+	rolloutUpdateCmd.Flags().Duration("auto-promote", 0, "Promote the canary after this long if its replicas stay healthy")
+	rolloutUpdateCmd.Flags().Bool("force-recreate", false, "Replace every replica even if the spec is unchanged")
+	orphansCmd.Flags().Bool("prune", false, "Remove the orphaned containers")
+	systemPruneCmd.Flags().Bool("volumes", false, "Also remove volumes not used by any container")
+	systemPruneCmd.Flags().StringArray("filter", nil, "Only remove resources older than this, as until=<duration or timestamp> (e.g. until=24h)")
+	dumpDeploymentCmd.Flags().String("tail", "1000", "Number of log lines to collect per replica, or \"all\"")
+	dumpDeploymentCmd.Flags().StringP("output", "o", "", "Output directory or tarball (default: <name>-dump-<timestamp>)")
+	dumpDeploymentCmd.Flags().Bool("tar", false, "Write a .tar.gz archive instead of a directory")
+	deleteServiceCmd.Flags().StringP("selector", "l", "", "Delete all services whose selector matches (e.g. app=legacy)")
 }
\ No newline at end of file
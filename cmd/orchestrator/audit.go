@@ -0,0 +1,183 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"orca/pkg/audit"
+	"orca/pkg/timeutil"
+
+	"github.com/gorilla/mux"
+)
+
+// auditKinds maps the collection of a route to the resource kind audited
+var auditKinds = map[string]string{
+	"containers":  "container",
+	"deployments": "deployment",
+	"services":    "service",
+	"secrets":     "secret",
+	"orphans":     "orphan",
+	"reconcile":   "reconcile",
+}
+
+// auditMiddleware records every mutating request in the audit log once it
+// has been handled. Reads and dry runs change nothing and are not recorded.
+func (s *OrcaServer) auditMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.auditLog == nil || !isMutation(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		name, namespace := auditResource(r)
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r)
+
+		kind, action := auditAction(r)
+		entry := audit.Entry{
+			Time:      time.Now(),
+			Actor:     requestActor(r),
+			Action:    action,
+			Kind:      kind,
+			Name:      name,
+			Namespace: namespace,
+			Method:    r.Method,
+			Path:      r.URL.Path,
+			Status:    recorder.Status(),
+			Outcome:   audit.OutcomeSuccess,
+		}
+		if entry.Status >= http.StatusBadRequest {
+			entry.Outcome = audit.OutcomeFailure
+		}
+
+		if err := s.auditLog.Record(entry); err != nil {
+			s.logger.WithError(err).WithField("path", r.URL.Path).Error("Audit kaydı yazılamadı")
+		}
+	})
+}
+
+// isMutation reports whether a request may change state
+func isMutation(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return r.URL.Query().Get("dry_run") != "true"
+	}
+	return false
+}
+
+// auditAction derives the resource kind and the action of a request from its
+// route: the verb ending the path (scale, restart, prune, ...) or, for plain
+// resource paths, the method
+func auditAction(r *http.Request) (string, string) {
+	template := r.URL.Path
+	if route := mux.CurrentRoute(r); route != nil {
+		if t, err := route.GetPathTemplate(); err == nil {
+			template = t
+		}
+	}
+
+	segments := strings.Split(strings.Trim(template, "/"), "/")
+	kind := segments[0]
+	if singular, ok := auditKinds[kind]; ok {
+		kind = singular
+	}
+
+	if last := segments[len(segments)-1]; len(segments) > 1 && last != "{name}" {
+		return kind, last
+	}
+	switch r.Method {
+	case http.MethodPost:
+		return kind, "create"
+	case http.MethodDelete:
+		return kind, "delete"
+	default:
+		return kind, "update"
+	}
+}
+
+// auditResource returns the name and namespace of the resource a request
+// acts on. Creates carry them in the spec, so the body is peeked and put back
+// for the handler.
+func auditResource(r *http.Request) (string, string) {
+	name := mux.Vars(r)["name"]
+	// Requests with an invalid namespace are rejected by the handler
+	namespace, _ := requestNamespace(r)
+	if name != "" || r.Method != http.MethodPost || r.Body == nil {
+		return name, namespace
+	}
+
+	body, err := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return name, namespace
+	}
+
+	var spec struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	}
+	if json.Unmarshal(body, &spec) == nil {
+		name = spec.Name
+		if spec.Namespace != "" {
+			namespace = spec.Namespace
+		}
+	}
+	return name, namespace
+}
+
+// requestActor returns who made a request: the client IP, or "unix" for
+// requests over the Unix socket
+func requestActor(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if host == "" || host == "@" {
+		return "unix"
+	}
+	return host
+}
+
+// listAuditHandler handles reading the most recent audit entries
+func (s *OrcaServer) listAuditHandler(w http.ResponseWriter, r *http.Request) {
+	if s.auditLog == nil {
+		http.Error(w, "Audit log yapılandırılmamış (server.audit_log)", http.StatusNotFound)
+		return
+	}
+
+	tail := 100
+	if value := r.URL.Query().Get("tail"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			http.Error(w, "Geçersiz tail değeri", http.StatusBadRequest)
+			return
+		}
+		tail = n
+	}
+
+	var since time.Time
+	if value := r.URL.Query().Get("since"); value != "" {
+		t, err := timeutil.ParseSince(value, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		since = t
+	}
+
+	entries, err := s.auditLog.Tail(tail, since)
+	if err != nil {
+		s.logger.WithError(err).Error("Audit log okunamadı")
+		http.Error(w, "Audit log okunamadı", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(entries)
+}
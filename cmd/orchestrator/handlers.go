@@ -3,21 +3,30 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"path"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"orca/pkg/build"
 	"orca/pkg/container"
+	"orca/pkg/scheduler"
+	"orca/pkg/timeutil"
 
 	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
 )
 
 // healthHandler handles health check requests
 func (s *OrcaServer) healthHandler(w http.ResponseWriter, r *http.Request) {
 	response := map[string]string{
 		"status":  "healthy",
-		"version": "1.0.0",
+		"version": build.Version,
 		"service": "orca-orchestrator",
 	}
 	
@@ -25,9 +34,67 @@ func (s *OrcaServer) healthHandler(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// infoHandler handles server build and uptime information requests
+func (s *OrcaServer) infoHandler(w http.ResponseWriter, r *http.Request) {
+	response := map[string]interface{}{
+		"build":      build.GetInfo(),
+		"started_at": s.startTime,
+		"uptime":     time.Since(s.startTime).Round(time.Second).String(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// versionResponse is the response of the version endpoint
+type versionResponse struct {
+	build.Info
+	DockerVersion    string `json:"docker_version,omitempty"`
+	DockerAPIVersion string `json:"docker_api_version,omitempty"`
+}
+
+// versionHandler handles server and Docker version requests
+func (s *OrcaServer) versionHandler(w http.ResponseWriter, r *http.Request) {
+	response := versionResponse{Info: build.GetInfo()}
+
+	// The build info is still useful when the daemon cannot be reached
+	dockerVersion, apiVersion, err := s.containerManager.DockerVersion(r.Context())
+	if err != nil {
+		s.logger.WithError(err).Warn("Docker sürümü alınamadı")
+	} else {
+		response.DockerVersion = dockerVersion
+		response.DockerAPIVersion = apiVersion
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 // listContainersHandler handles listing containers
 func (s *OrcaServer) listContainersHandler(w http.ResponseWriter, r *http.Request) {
-	containers, err := s.containerManager.List(r.Context())
+	namespace, err := listNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Optional label filter of the form "key=value,key2=value2"
+	var labels map[string]string
+	if labelStr := r.URL.Query().Get("label"); labelStr != "" {
+		parsed, err := scheduler.ParseSelector(labelStr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		labels = parsed
+	}
+
+	// Sizes are only computed on request since Docker has to walk every layer
+	containers, err := s.containerManager.ListWithOptions(r.Context(), container.ListOptions{
+		Namespace: namespace,
+		Labels:    labels,
+		Size:      r.URL.Query().Get("size") == "true",
+	})
 	if err != nil {
 		s.logger.WithError(err).Error("Container listesi alınamadı")
 		http.Error(w, "Container listesi alınamadı", http.StatusInternalServerError)
@@ -38,56 +105,56 @@ func (s *OrcaServer) listContainersHandler(w http.ResponseWriter, r *http.Reques
 	json.NewEncoder(w).Encode(containers)
 }
 
+// validationErrorResponse is the body returned for an invalid spec
+type validationErrorResponse struct {
+	Error  string                     `json:"error"`
+	Errors container.ValidationErrors `json:"errors"`
+}
+
+// writeValidationErrors responds with 400 and every problem found in a spec
+func writeValidationErrors(w http.ResponseWriter, errs container.ValidationErrors) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(validationErrorResponse{
+		Error:  "Spec doğrulanamadı",
+		Errors: errs,
+	})
+}
+
 // createContainerHandler handles container creation
 func (s *OrcaServer) createContainerHandler(w http.ResponseWriter, r *http.Request) {
-	var spec container.ContainerSpec
-	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
-		http.Error(w, "Geçersiz JSON formatı", http.StatusBadRequest)
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	// Input validation
-	if spec.Name == "" {
-		http.Error(w, "Container adı boş olamaz", http.StatusBadRequest)
+	var spec container.ContainerSpec
+	if err := container.DecodeSpec(r.Body, &spec); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	if spec.Image == "" {
-		http.Error(w, "Container image boş olamaz", http.StatusBadRequest)
+	if err := container.ValidateTypeMeta(spec.TypeMeta, container.KindContainer); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	// Validate ports if specified
-	for hostPortStr, containerPortStr := range spec.Ports {
-		// Parse host port
-		hostPort := 0
-		if hostPortStr != "" {
-			if hp, err := strconv.Atoi(hostPortStr); err != nil {
-				http.Error(w, "Geçersiz host port formatı", http.StatusBadRequest)
-				return
-			} else {
-				hostPort = hp
-			}
-		}
+	// A namespace in the spec wins over the one of the request
+	if spec.Namespace == "" {
+		spec.Namespace = namespace
+	}
 
-		// Parse container port
-		containerPort := 0
-		if containerPortStr != "" {
-			if cp, err := strconv.Atoi(containerPortStr); err != nil {
-				http.Error(w, "Geçersiz container port formatı", http.StatusBadRequest)
-				return
-			} else {
-				containerPort = cp
-			}
-		}
+	if errs := container.ValidateContainerSpec(&spec); errs != nil {
+		writeValidationErrors(w, errs)
+		return
+	}
 
-		// Validate port ranges
-		if hostPort < 1 || hostPort > 65535 {
-			http.Error(w, "Host port numarası 1-65535 arasında olmalıdır", http.StatusBadRequest)
-			return
-		}
-		if containerPort < 1 || containerPort > 65535 {
-			http.Error(w, "Container port numarası 1-65535 arasında olmalıdır", http.StatusBadRequest)
+	// Fail early on host ports bound by other processes instead of leaving a
+	// container that cannot start
+	if !container.IsolatedNetworkMode(spec.NetworkMode) {
+		if err := container.CheckHostPorts(spec.Ports); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
 			return
 		}
 	}
@@ -99,6 +166,27 @@ func (s *OrcaServer) createContainerHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Optionally start the container, removing it again if it fails to start
+	if r.URL.Query().Get("start") == "true" {
+		if err := s.containerManager.Start(r.Context(), c); err != nil {
+			s.logger.WithError(err).WithField("container_id", c.ID).Error("Container başlatılamadı, siliniyor")
+			if rmErr := s.containerManager.Remove(r.Context(), c.ID); rmErr != nil {
+				s.logger.WithError(rmErr).WithField("container_id", c.ID).Warn("Başlatılamayan container silinemedi")
+			}
+			http.Error(w, fmt.Sprintf("Container başlatılamadı: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		// Return the started container as Docker reports it, keeping the
+		// warnings of the create
+		if started, err := s.containerManager.Get(r.Context(), c.ID); err == nil {
+			started.Warnings = c.Warnings
+			c = started
+		} else {
+			c.Status = "running"
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(c)
 }
@@ -108,8 +196,14 @@ func (s *OrcaServer) getContainerHandler(w http.ResponseWriter, r *http.Request)
 	vars := mux.Vars(r)
 	name := vars["name"]
 
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Resolve name to container ID
-	containerID, err := s.resolveContainerID(r.Context(), name)
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
 	if err != nil {
 		s.logger.WithError(err).Error("Container bulunamadı")
 		http.Error(w, "Container bulunamadı", http.StatusNotFound)
@@ -127,27 +221,60 @@ func (s *OrcaServer) getContainerHandler(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(c)
 }
 
-// resolveContainerID resolves container name to ID
-func (s *OrcaServer) resolveContainerID(ctx context.Context, nameOrID string) (string, error) {
-	// First try to get container by name/ID directly
-	container, err := s.containerManager.Get(ctx, nameOrID)
-	if err == nil {
-		return container.ID, nil
+// requestNamespace returns the namespace a request is scoped to, given by
+// the namespace query parameter. Invalid namespaces are rejected, since they
+// also name storage paths.
+func requestNamespace(r *http.Request) (string, error) {
+	namespace := r.URL.Query().Get("namespace")
+	if err := container.ValidateNamespace(namespace); err != nil {
+		return "", err
 	}
+	return container.NormalizeNamespace(namespace), nil
+}
 
-	// If that fails, try to find by name in the container list
-	containers, err := s.containerManager.List(ctx)
+// listNamespace returns the namespace to list, or "" to list all namespaces
+// when all_namespaces=true is given
+func listNamespace(r *http.Request) (string, error) {
+	if r.URL.Query().Get("all_namespaces") == "true" {
+		return "", nil
+	}
+	return requestNamespace(r)
+}
+
+// resolveContainerID resolves a container name or ID to an ID within a
+// namespace; containers of other namespaces are not found
+func (s *OrcaServer) resolveContainerID(ctx context.Context, namespace, nameOrID string) (string, error) {
+	c, err := s.resolveContainer(ctx, namespace, nameOrID)
 	if err != nil {
 		return "", err
 	}
+	return c.ID, nil
+}
+
+// resolveContainer finds a container of namespace by name or ID, like
+// resolveContainerID
+func (s *OrcaServer) resolveContainer(ctx context.Context, namespace, nameOrID string) (*container.Container, error) {
+	// First try to get container by its Docker name, then by ID
+	for _, ref := range []string{s.containerManager.DockerName(namespace, nameOrID), nameOrID} {
+		c, err := s.containerManager.Get(ctx, ref)
+		if err == nil && c.Namespace == namespace {
+			return c, nil
+		}
+	}
+
+	// If that fails, try to find by name in the container list
+	containers, err := s.containerManager.ListWithOptions(ctx, container.ListOptions{Namespace: namespace})
+	if err != nil {
+		return nil, err
+	}
 
 	for _, c := range containers {
 		if c.Name == nameOrID || c.ID == nameOrID || strings.HasPrefix(c.ID, nameOrID) {
-			return c.ID, nil
+			return c, nil
 		}
 	}
 
-	return "", fmt.Errorf("container bulunamadı: %s", nameOrID)
+	return nil, fmt.Errorf("container bulunamadı: %s", nameOrID)
 }
 
 // startContainerHandler handles starting a container
@@ -155,15 +282,20 @@ func (s *OrcaServer) startContainerHandler(w http.ResponseWriter, r *http.Reques
 	vars := mux.Vars(r)
 	name := vars["name"]
 
-	// Resolve name to container ID
-	containerID, err := s.resolveContainerID(r.Context(), name)
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, err := s.resolveContainer(r.Context(), namespace, name)
 	if err != nil {
 		s.logger.WithError(err).Error("Container bulunamadı")
 		http.Error(w, "Container bulunamadı", http.StatusNotFound)
 		return
 	}
 
-	if err := s.containerManager.Start(r.Context(), containerID); err != nil {
+	if err := s.containerManager.Start(r.Context(), c); err != nil {
 		s.logger.WithError(err).Error("Container başlatılamadı")
 		http.Error(w, "Container başlatılamadı", http.StatusInternalServerError)
 		return
@@ -173,20 +305,147 @@ func (s *OrcaServer) startContainerHandler(w http.ResponseWriter, r *http.Reques
 	json.NewEncoder(w).Encode(map[string]string{"status": "started"})
 }
 
+// recreateContainerHandler replaces a container with a fresh one built from
+// its current configuration, optionally pulling the image first
+func (s *OrcaServer) recreateContainerHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Container bulunamadı")
+		http.Error(w, "Container bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	c, err := s.containerManager.Recreate(r.Context(), containerID, r.URL.Query().Get("pull") == "true")
+	if err != nil {
+		if errors.Is(err, container.ErrManagedReplica) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		s.logger.WithError(err).Error("Container yeniden oluşturulamadı")
+		http.Error(w, fmt.Sprintf("Container yeniden oluşturulamadı: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
+}
+
+// commitContainerHandler handles saving the current state of a container as
+// a new image
+func (s *OrcaServer) commitContainerHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+
+	ref := query.Get("ref")
+	if ref == "" {
+		http.Error(w, "Image referansı belirtilmelidir (?ref=image:tag)", http.StatusBadRequest)
+		return
+	}
+
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Container bulunamadı")
+		http.Error(w, "Container bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	imageID, err := s.containerManager.CommitWithOptions(r.Context(), containerID, container.CommitOptions{
+		Reference: ref,
+		Message:   query.Get("message"),
+		Author:    query.Get("author"),
+	})
+	if errors.Is(err, container.ErrMountsSecrets) {
+		http.Error(w, fmt.Sprintf("Secret bağlayan konteynerler image olarak kaydedilemez: %s", name), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("Container image olarak kaydedilemedi")
+		http.Error(w, fmt.Sprintf("Container image olarak kaydedilemedi: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{
+		"id":  imageID,
+		"ref": ref,
+	})
+}
+
+// adoptContainerHandler brings a container created outside ORCA under
+// management as a single replica deployment
+func (s *OrcaServer) adoptContainerHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Container bulunamadı")
+		http.Error(w, "Container bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	deployment, err := s.scheduler.AdoptContainer(r.Context(), containerID, r.URL.Query().Get("deployment"))
+	if err != nil {
+		var errs container.ValidationErrors
+		if errors.As(err, &errs) {
+			writeValidationErrors(w, errs)
+			return
+		}
+		if errors.Is(err, scheduler.ErrAlreadyAdopted) || errors.Is(err, scheduler.ErrDeploymentExists) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		s.logger.WithError(err).Error("Container sahiplenilemedi")
+		http.Error(w, fmt.Sprintf("Container sahiplenilemedi: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deployment)
+}
+
 // stopContainerHandler handles stopping a container
 func (s *OrcaServer) stopContainerHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	name := vars["name"]
 
-	// Resolve name to container ID
-	containerID, err := s.resolveContainerID(r.Context(), name)
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c, err := s.resolveContainer(r.Context(), namespace, name)
 	if err != nil {
 		s.logger.WithError(err).Error("Container bulunamadı")
 		http.Error(w, "Container bulunamadı", http.StatusNotFound)
 		return
 	}
 
-	if err := s.containerManager.Stop(r.Context(), containerID); err != nil {
+	if err := s.containerManager.Stop(r.Context(), c); err != nil {
 		s.logger.WithError(err).Error("Container durdurulamadı")
 		http.Error(w, "Container durdurulamadı", http.StatusInternalServerError)
 		return
@@ -201,8 +460,14 @@ func (s *OrcaServer) removeContainerHandler(w http.ResponseWriter, r *http.Reque
 	vars := mux.Vars(r)
 	name := vars["name"]
 
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Resolve name to container ID
-	containerID, err := s.resolveContainerID(r.Context(), name)
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
 	if err != nil {
 		s.logger.WithError(err).Error("Container bulunamadı")
 		http.Error(w, "Container bulunamadı", http.StatusNotFound)
@@ -219,183 +484,1217 @@ func (s *OrcaServer) removeContainerHandler(w http.ResponseWriter, r *http.Reque
 	json.NewEncoder(w).Encode(map[string]string{"status": "removed"})
 }
 
-// containerLogsHandler handles getting container logs
-func (s *OrcaServer) containerLogsHandler(w http.ResponseWriter, r *http.Request) {
+// updateContainerHandler handles updating container resource limits in place
+func (s *OrcaServer) updateContainerHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	name := vars["name"]
 
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var resources container.Resources
+	if err := json.NewDecoder(r.Body).Decode(&resources); err != nil {
+		http.Error(w, "Geçersiz JSON formatı", http.StatusBadRequest)
+		return
+	}
+
+	if resources.Memory == "" && resources.CPUs == 0 {
+		http.Error(w, "En az bir kaynak sınırı (memory, cpus) belirtilmelidir", http.StatusBadRequest)
+		return
+	}
+
+	if err := container.ValidateResources(resources); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Resolve name to container ID
-	containerID, err := s.resolveContainerID(r.Context(), name)
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
 	if err != nil {
 		s.logger.WithError(err).Error("Container bulunamadı")
 		http.Error(w, "Container bulunamadı", http.StatusNotFound)
 		return
 	}
 
-	// Parse tail parameter from query string
-	tailStr := r.URL.Query().Get("tail")
-	tail := 100 // default value
-	if tailStr != "" {
-		if parsedTail, err := strconv.Atoi(tailStr); err == nil && parsedTail > 0 {
-			tail = parsedTail
-		}
+	if err := s.containerManager.Update(r.Context(), containerID, resources); err != nil {
+		s.logger.WithError(err).Error("Container güncellenemedi")
+		http.Error(w, "Container güncellenemedi", http.StatusInternalServerError)
+		return
 	}
 
-	logs, err := s.containerManager.LogsWithTail(r.Context(), containerID, tail)
+	c, err := s.containerManager.Get(r.Context(), containerID)
 	if err != nil {
-		s.logger.WithError(err).Error("Container logları alınamadı")
-		http.Error(w, "Container logları alınamadı", http.StatusInternalServerError)
+		s.logger.WithError(err).Error("Container bulunamadı")
+		http.Error(w, "Container bulunamadı", http.StatusNotFound)
 		return
 	}
 
-	w.Header().Set("Content-Type", "text/plain")
-	w.Write([]byte(logs))
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
 }
 
-// listDeploymentsHandler handles listing deployments
-func (s *OrcaServer) listDeploymentsHandler(w http.ResponseWriter, r *http.Request) {
-	deployments := s.scheduler.ListDeployments()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(deployments)
+// restartPolicyRequest is the body of a restart policy update
+type restartPolicyRequest struct {
+	RestartPolicy string `json:"restart_policy"`
 }
 
-// createDeploymentHandler handles deployment creation
-func (s *OrcaServer) createDeploymentHandler(w http.ResponseWriter, r *http.Request) {
-	var spec container.DeploymentSpec
-	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
-		http.Error(w, "Geçersiz JSON formatı", http.StatusBadRequest)
+// updateRestartPolicyHandler handles changing the restart policy of a container
+func (s *OrcaServer) updateRestartPolicyHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	// Input validation
-	if spec.Name == "" {
-		http.Error(w, "Deployment adı boş olamaz", http.StatusBadRequest)
+	var req restartPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz JSON formatı", http.StatusBadRequest)
 		return
 	}
 
-	if spec.Replicas < 1 {
-		http.Error(w, "Replica sayısı en az 1 olmalıdır", http.StatusBadRequest)
+	if req.RestartPolicy == "" {
+		http.Error(w, "restart_policy belirtilmelidir", http.StatusBadRequest)
 		return
 	}
 
-	if spec.Replicas > 100 {
-		http.Error(w, "Replica sayısı en fazla 100 olabilir", http.StatusBadRequest)
+	if _, err := container.ParseRestartPolicy(req.RestartPolicy); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	if spec.Container.Name == "" {
-		http.Error(w, "Container adı boş olamaz", http.StatusBadRequest)
+	// Resolve name to container ID
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Container bulunamadı")
+		http.Error(w, "Container bulunamadı", http.StatusNotFound)
 		return
 	}
 
-	if spec.Container.Image == "" {
-		http.Error(w, "Container image boş olamaz", http.StatusBadRequest)
+	if err := s.containerManager.UpdateRestartPolicy(r.Context(), containerID, req.RestartPolicy); err != nil {
+		s.logger.WithError(err).Error("Container restart policy güncellenemedi")
+		http.Error(w, "Container restart policy güncellenemedi", http.StatusInternalServerError)
 		return
 	}
 
-	deployment, err := s.scheduler.CreateDeployment(r.Context(), spec)
+	c, err := s.containerManager.Get(r.Context(), containerID)
 	if err != nil {
-		s.logger.WithError(err).Error("Deployment oluşturulamadı")
-		http.Error(w, "Deployment oluşturulamadı", http.StatusInternalServerError)
+		s.logger.WithError(err).Error("Container bulunamadı")
+		http.Error(w, "Container bulunamadı", http.StatusNotFound)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(deployment)
+	json.NewEncoder(w).Encode(c)
 }
 
-// getDeploymentHandler handles getting a specific deployment
-func (s *OrcaServer) getDeploymentHandler(w http.ResponseWriter, r *http.Request) {
+// containerChangesHandler handles listing filesystem changes of a container
+func (s *OrcaServer) containerChangesHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	name := vars["name"]
 
-	deployment, err := s.scheduler.GetDeployment(name)
+	namespace, err := requestNamespace(r)
 	if err != nil {
-		s.logger.WithError(err).Error("Deployment bulunamadı")
-		http.Error(w, "Deployment bulunamadı", http.StatusNotFound)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(deployment)
-}
-
-// deleteDeploymentHandler handles deployment deletion
-func (s *OrcaServer) deleteDeploymentHandler(w http.ResponseWriter, r *http.Request) {
-	vars := mux.Vars(r)
-	name := vars["name"]
-
-	deployment, err := s.scheduler.GetDeployment(name)
+	// Resolve name to container ID
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
 	if err != nil {
-		s.logger.WithError(err).Error("Deployment bulunamadı")
-		http.Error(w, "Deployment bulunamadı", http.StatusNotFound)
+		s.logger.WithError(err).Error("Container bulunamadı")
+		http.Error(w, "Container bulunamadı", http.StatusNotFound)
 		return
 	}
 
-	if err := s.scheduler.DeleteDeployment(r.Context(), name); err != nil {
-		s.logger.WithError(err).Error("Deployment silinemedi")
-		http.Error(w, "Deployment silinemedi", http.StatusInternalServerError)
+	changes, err := s.containerManager.Changes(r.Context(), containerID)
+	if err != nil {
+		s.logger.WithError(err).Error("Container değişiklikleri alınamadı")
+		http.Error(w, "Container değişiklikleri alınamadı", http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(deployment)
+	json.NewEncoder(w).Encode(changes)
 }
 
-// listServicesHandler handles listing services
-func (s *OrcaServer) listServicesHandler(w http.ResponseWriter, r *http.Request) {
-	services := s.scheduler.ListServices()
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(services)
-}
+// exportContainerHandler handles streaming the filesystem of a container as a
+// tar archive. The Docker export is cancelled when the client disconnects.
+func (s *OrcaServer) exportContainerHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
 
-// createServiceHandler handles service creation
-func (s *OrcaServer) createServiceHandler(w http.ResponseWriter, r *http.Request) {
-	var spec container.ServiceSpec
-	if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
-		http.Error(w, "Geçersiz JSON formatı", http.StatusBadRequest)
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	// Input validation
-	if spec.Name == "" {
-		http.Error(w, "Service adı boş olamaz", http.StatusBadRequest)
+	// Resolve name to container ID
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Container bulunamadı")
+		http.Error(w, "Container bulunamadı", http.StatusNotFound)
 		return
 	}
 
-	if spec.Type == "" {
-		http.Error(w, "Service tipi belirtilmelidir", http.StatusBadRequest)
+	reader, err := s.containerManager.Export(r.Context(), containerID)
+	if errors.Is(err, container.ErrMountsSecrets) {
+		http.Error(w, fmt.Sprintf("Secret bağlayan konteynerler dışa aktarılamaz: %s", name), http.StatusConflict)
 		return
 	}
-
-	if spec.Type != "ClusterIP" && spec.Type != "NodePort" && spec.Type != "LoadBalancer" {
-		http.Error(w, "Geçersiz service tipi. Desteklenen tipler: ClusterIP, NodePort, LoadBalancer", http.StatusBadRequest)
+	if err != nil {
+		s.logger.WithError(err).Error("Container dışa aktarılamadı")
+		http.Error(w, "Container dışa aktarılamadı", http.StatusInternalServerError)
 		return
 	}
+	defer reader.Close()
 
-	if len(spec.Ports) == 0 {
-		http.Error(w, "En az bir port tanımlanmalıdır", http.StatusBadRequest)
+	// Large filesystems take longer than the server write timeout
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".tar"))
+	written, err := io.Copy(w, reader)
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"container_id": containerID,
+			"bytes":        written,
+		}).Warn("Container dışa aktarımı yarıda kaldı")
 		return
 	}
 
-	// Validate ports
-	for _, port := range spec.Ports {
-		if port.Port < 1 || port.Port > 65535 {
-			http.Error(w, "Port numarası 1-65535 arasında olmalıdır", http.StatusBadRequest)
-			return
-		}
-		if port.TargetPort < 1 || port.TargetPort > 65535 {
-			http.Error(w, "Hedef port numarası 1-65535 arasında olmalıdır", http.StatusBadRequest)
+	s.logger.WithFields(logrus.Fields{
+		"container_id": containerID,
+		"bytes":        written,
+	}).Info("Container dışa aktarıldı")
+}
+
+// containerFileHandler handles reading a single file of a container, given
+// by its absolute path in ?path=
+func (s *OrcaServer) containerFileHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filePath := r.URL.Query().Get("path")
+	if filePath == "" || !path.IsAbs(filePath) {
+		http.Error(w, "Dosya yolu mutlak olmalıdır (örn. /etc/app/config.yaml)", http.StatusBadRequest)
+		return
+	}
+
+	// Resolve name to container ID
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Container bulunamadı")
+		http.Error(w, "Container bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	reader, size, err := s.containerManager.ReadFile(r.Context(), containerID, filePath)
+	if err != nil {
+		switch {
+		case errors.Is(err, container.ErrFileNotFound):
+			http.Error(w, fmt.Sprintf("Dosya bulunamadı: %s", filePath), http.StatusNotFound)
+		case errors.Is(err, container.ErrIsDirectory):
+			http.Error(w, fmt.Sprintf("Yol bir dizin, dosya değil: %s", filePath), http.StatusBadRequest)
+		case errors.Is(err, container.ErrSecretFile):
+			http.Error(w, fmt.Sprintf("Secret dosyaları okunamaz: %s", filePath), http.StatusForbidden)
+		default:
+			s.logger.WithError(err).Error("Dosya okunamadı")
+			http.Error(w, fmt.Sprintf("Dosya okunamadı: %v", err), http.StatusInternalServerError)
+		}
+		return
+	}
+	defer reader.Close()
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+	if _, err := io.Copy(w, reader); err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"container_id": containerID,
+			"path":         filePath,
+		}).Warn("Dosya gönderimi yarıda kaldı")
+	}
+}
+
+// containerStatsHandler handles getting a resource usage sample of a container
+func (s *OrcaServer) containerStatsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Resolve name to container ID
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Container bulunamadı")
+		http.Error(w, "Container bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	stats, err := s.containerManager.Stats(r.Context(), containerID)
+	if err != nil {
+		s.logger.WithError(err).Error("Container istatistikleri alınamadı")
+		http.Error(w, "Container istatistikleri alınamadı", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(stats)
+}
+
+// containerTopHandler handles listing the processes running in a container
+func (s *OrcaServer) containerTopHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Resolve name to container ID
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Container bulunamadı")
+		http.Error(w, "Container bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	processes, err := s.containerManager.Top(r.Context(), containerID)
+	if errors.Is(err, container.ErrNotRunning) {
+		http.Error(w, "Container çalışmıyor", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("Container işlemleri alınamadı")
+		http.Error(w, "Container işlemleri alınamadı", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(processes)
+}
+
+// containerLogsHandler handles getting container logs
+func (s *OrcaServer) containerLogsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Resolve name to container ID
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Container bulunamadı")
+		http.Error(w, "Container bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	// Read the last exited container of the replica instead
+	if r.URL.Query().Get("previous") == "true" {
+		previous, err := s.scheduler.PreviousReplica(namespace, containerID)
+		if errors.Is(err, scheduler.ErrNotReplica) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		containerID = previous
+	}
+
+	opts, err := parseLogOptions(r, s.config.Server.MaxLogBytes)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Stream logs as they are written when following
+	if r.URL.Query().Get("follow") == "true" {
+		// Headers are sent before streaming, so check the log driver first
+		if err := s.containerManager.CheckLogsReadable(r.Context(), containerID); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.followContainerLogs(w, r, containerID, opts.Tail)
+		return
+	}
+
+	result, err := s.containerManager.LogsWithOptions(r.Context(), containerID, opts)
+	if errors.Is(err, container.ErrLogsUnsupported) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("Container logları alınamadı")
+		http.Error(w, "Container logları alınamadı", http.StatusInternalServerError)
+		return
+	}
+
+	writeLogs(w, r, name, result)
+}
+
+// parseLogOptions parses the tail, grep, since, timestamps and max_bytes query
+// parameters of the logs endpoints. max_bytes may not exceed maxBytes.
+func parseLogOptions(r *http.Request, maxBytes int) (container.LogOptions, error) {
+	query := r.URL.Query()
+
+	// Parse tail parameter from query string ("all" or -1 returns the whole log)
+	tailStr := query.Get("tail")
+	tail := 100 // default value
+	if tailStr == "all" {
+		tail = container.TailAll
+	} else if tailStr != "" {
+		if parsedTail, err := strconv.Atoi(tailStr); err == nil && (parsedTail > 0 || parsedTail == container.TailAll) {
+			tail = parsedTail
+		}
+	}
+
+	opts := container.LogOptions{
+		Tail:       tail,
+		Timestamps: query.Get("timestamps") == "true",
+	}
+
+	// Relative durations are resolved here, since Docker does not know days
+	if value := query.Get("since"); value != "" {
+		since, err := timeutil.ParseSince(value, time.Now())
+		if err != nil {
+			return opts, err
+		}
+		opts.Since = since.Format(time.RFC3339Nano)
+	}
+
+	// Parse grep parameter; only matching lines are returned
+	if grep := query.Get("grep"); grep != "" {
+		re, err := regexp.Compile(grep)
+		if err != nil {
+			return opts, fmt.Errorf("Geçersiz grep ifadesi: %v", err)
+		}
+		opts.Grep = re
+	}
+
+	if value := query.Get("max_bytes"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return opts, fmt.Errorf("Geçersiz max_bytes değeri: %s", value)
+		}
+		if n > maxBytes {
+			return opts, fmt.Errorf("max_bytes en fazla %d olabilir (server.max_log_bytes)", maxBytes)
+		}
+		opts.MaxBytes = n
+	}
+
+	return opts, nil
+}
+
+// writeLogs writes a logs response, as a file attachment named after name
+// when ?download=true is set
+func writeLogs(w http.ResponseWriter, r *http.Request, name string, result *container.LogResult) {
+	w.Header().Set("Content-Type", "text/plain")
+	if result.Truncated {
+		w.Header().Set("X-Orca-Logs-Truncated", "true")
+	}
+	if r.URL.Query().Get("download") == "true" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".log"))
+		w.Header().Set("Content-Length", strconv.Itoa(len(result.Logs)))
+	}
+	w.Write([]byte(result.Logs))
+}
+
+// followContainerLogs streams container logs to the client until the
+// container stops or the client disconnects
+func (s *OrcaServer) followContainerLogs(w http.ResponseWriter, r *http.Request, containerID string, tail int) {
+	// Long lived stream; lift the server write timeout for this request
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	rc.Flush()
+
+	if err := s.containerManager.FollowLogs(r.Context(), containerID, tail, &flushWriter{w: w, rc: rc}); err != nil {
+		s.logger.WithError(err).Warn("Container log akışı sonlandı")
+	}
+}
+
+// attachContainerHandler handles attaching to the output of the main process
+// of a running container, streaming it until the process exits or the
+// client disconnects. With stdin=true the request body is copied to the
+// process' stdin while the output is streamed.
+func (s *OrcaServer) attachContainerHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	containerID, err := s.resolveContainerID(r.Context(), namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Container bulunamadı")
+		http.Error(w, "Container bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	attachStdin := r.URL.Query().Get("stdin") == "true"
+
+	// Headers are sent before streaming, so check the container first
+	if err := s.containerManager.CheckAttachable(r.Context(), containerID, attachStdin); err != nil {
+		if errors.Is(err, container.ErrNotRunning) || errors.Is(err, container.ErrStdinClosed) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		s.logger.WithError(err).Error("Container'a bağlanılamadı")
+		http.Error(w, "Container'a bağlanılamadı", http.StatusInternalServerError)
+		return
+	}
+
+	// Long lived stream; lift the server timeouts and keep reading stdin
+	// from the request body while the output is written
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+	var stdin io.Reader
+	if attachStdin {
+		rc.SetReadDeadline(time.Time{})
+		if err := rc.EnableFullDuplex(); err != nil {
+			s.logger.WithError(err).Warn("Tam çift yönlü bağlantı açılamadı")
+		}
+		stdin = r.Body
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	rc.Flush()
+
+	if err := s.containerManager.Attach(r.Context(), containerID, stdin, &flushWriter{w: w, rc: rc}); err != nil {
+		s.logger.WithError(err).Warn("Container bağlantısı sonlandı")
+	}
+}
+
+// flushWriter flushes the response after every write so streamed data
+// reaches the client immediately
+type flushWriter struct {
+	w  io.Writer
+	rc *http.ResponseController
+}
+
+// Write writes p and flushes the response
+func (f *flushWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, f.rc.Flush()
+}
+
+// listDeploymentsHandler handles listing deployments
+func (s *OrcaServer) listDeploymentsHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, err := listNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+
+	var deployments []*scheduler.Deployment
+	if selectorStr := query.Get("selector"); selectorStr != "" {
+		selector, err := scheduler.ParseSelector(selectorStr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		deployments = s.scheduler.ListDeploymentsBySelector(namespace, selector)
+	} else {
+		deployments = s.scheduler.ListDeployments(namespace)
+	}
+
+	offset := 0
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err != nil || parsed < 0 {
+			http.Error(w, "Geçersiz offset değeri", http.StatusBadRequest)
+			return
+		}
+		offset = parsed
+	}
+
+	limit := len(deployments)
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 1 {
+			http.Error(w, "Geçersiz limit değeri", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	total := len(deployments)
+	start := offset
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	json.NewEncoder(w).Encode(deployments[start:end])
+}
+
+// createDeploymentHandler handles deployment creation
+func (s *OrcaServer) createDeploymentHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var spec container.DeploymentSpec
+	if err := container.DecodeSpec(r.Body, &spec); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := container.ValidateTypeMeta(spec.TypeMeta, container.KindDeployment); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Fill in unset fields before validating; a namespace in the spec wins
+	// over the one of the request
+	if spec.Namespace == "" {
+		spec.Namespace = namespace
+	}
+	s.scheduler.ApplyDefaults(&spec)
+
+	if errs := container.ValidateDeploymentSpec(&spec, s.scheduler.MaxReplicas()); errs != nil {
+		writeValidationErrors(w, errs)
+		return
+	}
+
+	opts := scheduler.CreateOptions{
+		Partial: r.URL.Query().Get("partial") == "true",
+	}
+
+	if r.URL.Query().Get("progress") == "true" {
+		s.streamCreateDeployment(w, r, spec, opts)
+		return
+	}
+
+	deployment, err := s.scheduler.CreateDeployment(r.Context(), spec, opts)
+	if err != nil {
+		status, message := s.createDeploymentError(err)
+		http.Error(w, message, status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deployment)
+}
+
+// createDeploymentError returns the status code and message of a failed
+// deployment creation
+func (s *OrcaServer) createDeploymentError(err error) (int, string) {
+	var portErr *container.PortInUseError
+	if errors.As(err, &portErr) {
+		return http.StatusConflict, portErr.Error()
+	}
+	if errors.Is(err, scheduler.ErrDeploymentExists) || errors.Is(err, scheduler.ErrDependencyCycle) ||
+		errors.Is(err, scheduler.ErrWaitingForDependencies) {
+		return http.StatusConflict, err.Error()
+	}
+	s.logger.WithError(err).Error("Deployment oluşturulamadı")
+	return http.StatusInternalServerError, "Deployment oluşturulamadı"
+}
+
+// streamCreateDeployment creates a deployment while streaming its progress
+// as server-sent events: progress events while images are pulled and
+// replicas start, then a deployment event with the created deployment or an
+// error event with the status code and message the plain request would have
+// returned. The deployment is created in the background so a slow client
+// never holds up the scheduler; progress events it cannot keep up with are
+// dropped.
+func (s *OrcaServer) streamCreateDeployment(w http.ResponseWriter, r *http.Request, spec container.DeploymentSpec, opts scheduler.CreateOptions) {
+	type result struct {
+		deployment *scheduler.Deployment
+		err        error
+	}
+
+	progress := make(chan scheduler.Progress, 64)
+	done := make(chan result, 1)
+	ctx := scheduler.WithProgress(r.Context(), func(p scheduler.Progress) {
+		select {
+		case progress <- p:
+		default:
+		}
+	})
+	go func() {
+		deployment, err := s.scheduler.CreateDeployment(ctx, spec, opts)
+		done <- result{deployment, err}
+	}()
+
+	// Image pulls can take longer than the server write timeout
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	rc.Flush()
+
+	out := &flushWriter{w: w, rc: rc}
+	for {
+		select {
+		case p := <-progress:
+			writeServerEvent(out, "progress", p)
+		case res := <-done:
+			// Send the steps reported before the result first
+			for len(progress) > 0 {
+				writeServerEvent(out, "progress", <-progress)
+			}
+			if res.err != nil {
+				status, message := s.createDeploymentError(res.err)
+				writeServerEvent(out, "error", map[string]interface{}{"status": status, "error": message})
+				return
+			}
+			writeServerEvent(out, "deployment", res.deployment)
+			return
+		}
+	}
+}
+
+// writeServerEvent writes a server-sent event with a JSON payload
+func writeServerEvent(w io.Writer, event string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
+
+// getDeploymentHandler handles getting a specific deployment
+func (s *OrcaServer) getDeploymentHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	deployment, err := s.scheduler.GetDeployment(namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Deployment bulunamadı")
+		http.Error(w, "Deployment bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deployment)
+}
+
+// deploymentStatusHandler handles getting a live deployment status summary
+func (s *OrcaServer) deploymentStatusHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	status, err := s.scheduler.GetDeploymentStatus(r.Context(), namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Deployment bulunamadı")
+		http.Error(w, "Deployment bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// deploymentLogsHandler handles getting the combined logs of all replicas of a deployment
+func (s *OrcaServer) deploymentLogsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts, err := parseLogOptions(r, s.config.Server.MaxLogBytes)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := s.scheduler.DeploymentLogs(r.Context(), namespace, name, opts)
+	if err != nil {
+		s.logger.WithError(err).Error("Deployment bulunamadı")
+		http.Error(w, "Deployment bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	writeLogs(w, r, name, result)
+}
+
+// getScaleHandler handles reporting the desired, current and ready replica
+// counts of a deployment
+func (s *OrcaServer) getScaleHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	status, err := s.scheduler.GetScale(r.Context(), namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Deployment bulunamadı")
+		http.Error(w, "Deployment bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// scaleDeploymentHandler handles changing the replica count of a deployment
+func (s *OrcaServer) scaleDeploymentHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Replicas *int `json:"replicas"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz JSON formatı", http.StatusBadRequest)
+		return
+	}
+
+	if req.Replicas == nil {
+		http.Error(w, "Replica sayısı belirtilmelidir", http.StatusBadRequest)
+		return
+	}
+
+	if maxReplicas := s.scheduler.MaxReplicas(); *req.Replicas < 0 || *req.Replicas > maxReplicas {
+		http.Error(w, fmt.Sprintf("Replica sayısı 0-%d arasında olmalıdır (scheduler.max_replicas)", maxReplicas), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := s.scheduler.GetDeployment(namespace, name); err != nil {
+		s.logger.WithError(err).Error("Deployment bulunamadı")
+		http.Error(w, "Deployment bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	if r.URL.Query().Get("dry_run") == "true" {
+		plan, err := s.scheduler.PlanScale(namespace, name, *req.Replicas)
+		s.writePlan(w, plan, err)
+		return
+	}
+
+	status, err := s.scheduler.ScaleDeployment(r.Context(), namespace, name, *req.Replicas)
+	if err != nil {
+		var portErr *container.PortInUseError
+		if errors.As(err, &portErr) {
+			http.Error(w, portErr.Error(), http.StatusConflict)
+			return
+		}
+		if errors.Is(err, scheduler.ErrRolloutInProgress) || errors.Is(err, scheduler.ErrWaitingForDependencies) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		s.logger.WithError(err).Error("Deployment ölçeklendirilemedi")
+		http.Error(w, "Deployment ölçeklendirilemedi", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+// restartDeploymentHandler handles a rolling restart of a deployment
+func (s *OrcaServer) restartDeploymentHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := s.scheduler.GetDeployment(namespace, name); err != nil {
+		s.logger.WithError(err).Error("Deployment bulunamadı")
+		http.Error(w, "Deployment bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	if r.URL.Query().Get("dry_run") == "true" {
+		plan, err := s.scheduler.PlanRestart(namespace, name)
+		s.writePlan(w, plan, err)
+		return
+	}
+
+	deployment, err := s.scheduler.RestartDeployment(r.Context(), namespace, name)
+	if err != nil {
+		if errors.Is(err, scheduler.ErrRolloutInProgress) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		s.logger.WithError(err).Error("Deployment yeniden başlatılamadı")
+		http.Error(w, "Deployment yeniden başlatılamadı", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deployment)
+}
+
+// updateDeploymentHandler handles rolling out a new spec to a deployment.
+// canary=N or canary=N% updates only that many replicas and pauses the
+// rollout; auto_promote promotes it after a duration if it stays healthy.
+func (s *OrcaServer) updateDeploymentHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var spec container.DeploymentSpec
+	if err := container.DecodeSpec(r.Body, &spec); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := container.ValidateTypeMeta(spec.TypeMeta, container.KindDeployment); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if spec.Name == "" {
+		spec.Name = name
+	}
+	if spec.Name != name {
+		http.Error(w, fmt.Sprintf("Spec adı (%s) URL'deki deployment adıyla (%s) eşleşmiyor", spec.Name, name), http.StatusBadRequest)
+		return
+	}
+	if spec.Namespace == "" {
+		spec.Namespace = namespace
+	}
+
+	existing, err := s.scheduler.GetDeployment(spec.Namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Deployment bulunamadı")
+		http.Error(w, "Deployment bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	// The replica count is changed with scale, not by an update
+	spec.Replicas = existing.Spec.Replicas
+	s.scheduler.ApplyDefaults(&spec)
+	if errs := container.ValidateDeploymentSpec(&spec, s.scheduler.MaxReplicas()); errs != nil {
+		writeValidationErrors(w, errs)
+		return
+	}
+
+	query := r.URL.Query()
+	var opts scheduler.UpdateOptions
+	if canary := query.Get("canary"); canary != "" {
+		percent := strings.HasSuffix(canary, "%")
+		value, err := strconv.Atoi(strings.TrimSuffix(canary, "%"))
+		if err != nil || value < 1 {
+			http.Error(w, "Geçersiz canary değeri (pozitif bir sayı veya yüzde olmalı)", http.StatusBadRequest)
+			return
+		}
+		if percent {
+			opts.CanaryPercent = value
+		} else {
+			opts.Canary = value
+		}
+	}
+	if autoPromote := query.Get("auto_promote"); autoPromote != "" {
+		duration, err := time.ParseDuration(autoPromote)
+		if err != nil || duration <= 0 {
+			http.Error(w, "Geçersiz auto_promote süresi", http.StatusBadRequest)
+			return
+		}
+		opts.AutoPromote = duration
+	}
+	opts.ForceRecreate = query.Get("force_recreate") == "true"
+
+	if query.Get("dry_run") == "true" {
+		plan, err := s.scheduler.PlanUpdate(spec, opts)
+		s.writePlan(w, plan, err)
+		return
+	}
+
+	deployment, err := s.scheduler.UpdateDeployment(r.Context(), spec, opts)
+	if err != nil {
+		var portErr *container.PortInUseError
+		switch {
+		case errors.As(err, &portErr):
+			http.Error(w, portErr.Error(), http.StatusConflict)
+		case errors.Is(err, scheduler.ErrRolloutInProgress), errors.Is(err, scheduler.ErrDependencyCycle):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			s.logger.WithError(err).Error("Deployment güncellenemedi")
+			http.Error(w, fmt.Sprintf("Deployment güncellenemedi: %v", err), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deployment)
+}
+
+// promoteDeploymentHandler handles rolling a paused canary out to every replica
+func (s *OrcaServer) promoteDeploymentHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := s.scheduler.GetDeployment(namespace, name); err != nil {
+		s.logger.WithError(err).Error("Deployment bulunamadı")
+		http.Error(w, "Deployment bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	deployment, err := s.scheduler.PromoteDeployment(r.Context(), namespace, name)
+	s.writeRolloutResult(w, deployment, err, "Rollout promote edilemedi")
+}
+
+// abortRolloutHandler handles reverting a paused canary to the previous spec
+func (s *OrcaServer) abortRolloutHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if _, err := s.scheduler.GetDeployment(namespace, name); err != nil {
+		s.logger.WithError(err).Error("Deployment bulunamadı")
+		http.Error(w, "Deployment bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	if r.URL.Query().Get("dry_run") == "true" {
+		plan, err := s.scheduler.PlanRollback(namespace, name)
+		s.writePlan(w, plan, err)
+		return
+	}
+
+	deployment, err := s.scheduler.AbortRollout(r.Context(), namespace, name)
+	s.writeRolloutResult(w, deployment, err, "Rollout geri alınamadı")
+}
+
+// writeRolloutResult writes the deployment after a promote or abort
+func (s *OrcaServer) writeRolloutResult(w http.ResponseWriter, deployment *scheduler.Deployment, err error, message string) {
+	if err != nil {
+		if errors.Is(err, scheduler.ErrNoPausedRollout) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		s.logger.WithError(err).Error(message)
+		http.Error(w, fmt.Sprintf("%s: %v", message, err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deployment)
+}
+
+// writePlan writes the plan of a dry run
+func (s *OrcaServer) writePlan(w http.ResponseWriter, plan *scheduler.Plan, err error) {
+	if err != nil {
+		if errors.Is(err, scheduler.ErrRolloutInProgress) || errors.Is(err, scheduler.ErrNoPausedRollout) || errors.Is(err, scheduler.ErrDependencyCycle) {
+			http.Error(w, err.Error(), http.StatusConflict)
 			return
 		}
+		s.logger.WithError(err).Error("Plan oluşturulamadı")
+		http.Error(w, fmt.Sprintf("Plan oluşturulamadı: %v", err), http.StatusInternalServerError)
+		return
+	}
+	s.logger.WithFields(logrus.Fields{
+		"operation":  plan.Operation,
+		"deployment": plan.Deployment,
+		"namespace":  plan.Namespace,
+		"steps":      len(plan.Steps),
+	}).Info("Dry-run planı oluşturuldu")
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(plan)
+}
+
+// deleteDeploymentHandler handles deployment deletion
+func (s *OrcaServer) deleteDeploymentHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	deployment, err := s.scheduler.GetDeployment(namespace, name)
+	if err != nil {
+		s.logger.WithError(err).Error("Deployment bulunamadı")
+		http.Error(w, "Deployment bulunamadı", http.StatusNotFound)
+		return
+	}
+
+	opts := scheduler.DeleteOptions{
+		RemoveVolume: r.URL.Query().Get("remove_volume") == "true",
+	}
+
+	if err := s.scheduler.DeleteDeployment(r.Context(), namespace, name, opts); err != nil {
+		s.logger.WithError(err).Error("Deployment silinemedi")
+		http.Error(w, "Deployment silinemedi", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deployment)
+}
+
+// batchDeleteRequest is the body of a selector-based batch delete
+type batchDeleteRequest struct {
+	Selector map[string]string `json:"selector"`
+}
+
+// batchDeleteDeploymentsHandler handles deleting all deployments matching a selector
+func (s *OrcaServer) batchDeleteDeploymentsHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req batchDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz JSON formatı", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Selector) == 0 {
+		http.Error(w, "Selector boş olamaz", http.StatusBadRequest)
+		return
+	}
+
+	results := s.scheduler.DeleteDeploymentsBySelector(r.Context(), namespace, req.Selector)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// listServicesHandler handles listing services
+func (s *OrcaServer) listServicesHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, err := listNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	services := s.scheduler.ListServices(namespace)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(services)
+}
+
+// createServiceHandler handles service creation
+func (s *OrcaServer) createServiceHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var spec container.ServiceSpec
+	if err := container.DecodeSpec(r.Body, &spec); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := container.ValidateTypeMeta(spec.TypeMeta, container.KindService); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// A namespace in the spec wins over the one of the request
+	if spec.Namespace == "" {
+		spec.Namespace = namespace
+	}
+
+	if errs := container.ValidateServiceSpec(&spec); errs != nil {
+		writeValidationErrors(w, errs)
+		return
 	}
 
 	service, err := s.scheduler.CreateService(r.Context(), spec)
 	if err != nil {
-		s.logger.WithError(err).Error("Service oluşturulamadı")
-		http.Error(w, "Service oluşturulamadı", http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, scheduler.ErrNodePortOutOfRange), errors.Is(err, scheduler.ErrDeploymentRefNotFound):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, scheduler.ErrNodePortInUse), errors.Is(err, scheduler.ErrNodePortsExhausted):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			s.logger.WithError(err).Error("Service oluşturulamadı")
+			http.Error(w, "Service oluşturulamadı", http.StatusInternalServerError)
+		}
 		return
 	}
 
@@ -408,7 +1707,13 @@ func (s *OrcaServer) getServiceHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	name := vars["name"]
 
-	service, err := s.scheduler.GetService(name)
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	service, err := s.scheduler.GetService(namespace, name)
 	if err != nil {
 		s.logger.WithError(err).Error("Service bulunamadı")
 		http.Error(w, "Service bulunamadı", http.StatusNotFound)
@@ -424,14 +1729,20 @@ func (s *OrcaServer) deleteServiceHandler(w http.ResponseWriter, r *http.Request
 	vars := mux.Vars(r)
 	name := vars["name"]
 
-	service, err := s.scheduler.GetService(name)
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	service, err := s.scheduler.GetService(namespace, name)
 	if err != nil {
 		s.logger.WithError(err).Error("Service bulunamadı")
 		http.Error(w, "Service bulunamadı", http.StatusNotFound)
 		return
 	}
 
-	if err := s.scheduler.DeleteService(name); err != nil {
+	if err := s.scheduler.DeleteService(namespace, name); err != nil {
 		s.logger.WithError(err).Error("Service silinemedi")
 		http.Error(w, "Service silinemedi", http.StatusInternalServerError)
 		return
@@ -441,6 +1752,323 @@ func (s *OrcaServer) deleteServiceHandler(w http.ResponseWriter, r *http.Request
 	json.NewEncoder(w).Encode(service)
 }
 
+// batchDeleteServicesHandler handles deleting all services matching a selector
+func (s *OrcaServer) batchDeleteServicesHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req batchDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz JSON formatı", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.Selector) == 0 {
+		http.Error(w, "Selector boş olamaz", http.StatusBadRequest)
+		return
+	}
+
+	results := s.scheduler.DeleteServicesBySelector(namespace, req.Selector)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// listSecretsHandler lists secrets without their data
+func (s *OrcaServer) listSecretsHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, err := listNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	secrets, err := s.storage.ListSecrets(namespace)
+	if err != nil {
+		s.logger.WithError(err).Error("Secret'lar listelenemedi")
+		http.Error(w, "Secret'lar listelenemedi", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(secrets)
+}
+
+// createSecretHandler stores a new secret. The response never contains the
+// secret data.
+func (s *OrcaServer) createSecretHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var secret container.Secret
+	if err := json.NewDecoder(r.Body).Decode(&secret); err != nil {
+		http.Error(w, "Geçersiz JSON formatı", http.StatusBadRequest)
+		return
+	}
+
+	if secret.Namespace == "" {
+		secret.Namespace = namespace
+	}
+	if errs := container.ValidateSecret(&secret); errs != nil {
+		writeValidationErrors(w, errs)
+		return
+	}
+
+	if _, err := s.storage.LoadSecret(secret.Namespace, secret.Name); err == nil {
+		http.Error(w, "Secret zaten mevcut", http.StatusConflict)
+		return
+	}
+
+	secret.Keys = nil
+	secret.Created = time.Now()
+	if err := s.storage.SaveSecret(&secret); err != nil {
+		s.logger.WithError(err).Error("Secret kaydedilemedi")
+		http.Error(w, "Secret kaydedilemedi", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(secret.Redacted())
+}
+
+// deleteSecretHandler deletes a secret. Containers that mount it keep their
+// copy of the files.
+func (s *OrcaServer) deleteSecretHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := container.ValidateSecretName(name); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := s.storage.DeleteSecret(namespace, name); err != nil {
+		if errors.Is(err, container.ErrSecretNotFound) {
+			http.Error(w, "Secret bulunamadı", http.StatusNotFound)
+			return
+		}
+		s.logger.WithError(err).Error("Secret silinemedi")
+		http.Error(w, "Secret silinemedi", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+}
+
+// listNetworksHandler handles listing the Docker networks with their
+// connected containers
+func (s *OrcaServer) listNetworksHandler(w http.ResponseWriter, r *http.Request) {
+	networks, err := s.containerManager.ListNetworks(r.Context())
+	if err != nil {
+		s.logger.WithError(err).Error("Ağlar listelenemedi")
+		http.Error(w, "Ağlar listelenemedi", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(networks)
+}
+
+// getNetworkHandler handles inspecting a Docker network by name or ID
+func (s *OrcaServer) getNetworkHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	name := vars["name"]
+
+	network, err := s.containerManager.InspectNetwork(r.Context(), name)
+	if err != nil {
+		if errors.Is(err, container.ErrNetworkNotFound) {
+			http.Error(w, "Ağ bulunamadı", http.StatusNotFound)
+			return
+		}
+		s.logger.WithError(err).Error("Ağ bilgisi alınamadı")
+		http.Error(w, "Ağ bilgisi alınamadı", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(network)
+}
+
+// listEventsHandler lists the recent events of a deployment or service, or
+// streams container events when no kind or kind=container is given
+func (s *OrcaServer) listEventsHandler(w http.ResponseWriter, r *http.Request) {
+	namespace, err := requestNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	kind := r.URL.Query().Get("kind")
+	name := r.URL.Query().Get("name")
+	if kind == "" || kind == "container" {
+		s.streamContainerEvents(w, r)
+		return
+	}
+	if kind != "deployment" && kind != "service" {
+		http.Error(w, "kind deployment, service veya container olmalıdır", http.StatusBadRequest)
+		return
+	}
+	if name == "" {
+		http.Error(w, "name belirtilmelidir", http.StatusBadRequest)
+		return
+	}
+
+	events := s.scheduler.RecentEvents(namespace, kind, name)
+	if value := r.URL.Query().Get("since"); value != "" {
+		since, err := timeutil.ParseSince(value, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		recent := make([]scheduler.Event, 0, len(events))
+		for _, event := range events {
+			if event.Timestamp.After(since) {
+				recent = append(recent, event)
+			}
+		}
+		events = recent
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(events)
+}
+
+// streamContainerEvents streams Docker container events as JSON lines until
+// the client disconnects. type limits the events to comma separated Docker
+// actions such as die or start, name to a single container and since
+// replays earlier events first.
+func (s *OrcaServer) streamContainerEvents(w http.ResponseWriter, r *http.Request) {
+	namespace, err := listNamespace(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := container.EventFilter{
+		Namespace: namespace,
+		Name:      query.Get("name"),
+	}
+	for _, value := range query["type"] {
+		for _, action := range strings.Split(value, ",") {
+			if action = strings.TrimSpace(action); action != "" {
+				filter.Actions = append(filter.Actions, action)
+			}
+		}
+	}
+	if value := query.Get("since"); value != "" {
+		since, err := timeutil.ParseSince(value, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter.Since = since
+	}
+
+	// Long lived stream; lift the server write timeout for this request
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	rc.Flush()
+
+	encoder := json.NewEncoder(&flushWriter{w: w, rc: rc})
+	err = s.containerManager.Events(r.Context(), filter, func(event container.ContainerEvent) error {
+		return encoder.Encode(event)
+	})
+	if err != nil {
+		s.logger.WithError(err).Warn("Container olay akışı sonlandı")
+	}
+}
+
+// listOrphansHandler handles listing replica containers whose deployment no
+// longer exists
+func (s *OrcaServer) listOrphansHandler(w http.ResponseWriter, r *http.Request) {
+	orphans, err := s.scheduler.ListOrphans(r.Context())
+	if err != nil {
+		s.logger.WithError(err).Error("Sahipsiz container'lar listelenemedi")
+		http.Error(w, "Sahipsiz container'lar listelenemedi", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(orphans)
+}
+
+// pruneOrphansHandler handles removing replica containers whose deployment no
+// longer exists
+func (s *OrcaServer) pruneOrphansHandler(w http.ResponseWriter, r *http.Request) {
+	results, err := s.scheduler.PruneOrphans(r.Context())
+	if err != nil {
+		s.logger.WithError(err).Error("Sahipsiz container'lar silinemedi")
+		http.Error(w, "Sahipsiz container'lar silinemedi", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// systemPruneHandler handles removing unused Docker resources. volumes=true
+// also removes unused volumes; until only removes resources older than a
+// timestamp or duration.
+func (s *OrcaServer) systemPruneHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := container.PruneOptions{Volumes: query.Get("volumes") == "true"}
+	if value := query.Get("until"); value != "" {
+		until, err := timeutil.ParseSince(value, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		opts.Until = until
+	}
+
+	report, err := s.containerManager.SystemPrune(r.Context(), opts)
+	if err != nil {
+		s.logger.WithError(err).Error("Sistem temizliği tamamlanamadı")
+		http.Error(w, fmt.Sprintf("Sistem temizliği tamamlanamadı: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
+
+// reconcileStatusHandler handles getting the state of the reconcile loop
+func (s *OrcaServer) reconcileStatusHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.scheduler.GetReconcileStatus())
+}
+
+// pauseReconcileHandler handles pausing the reconcile loop
+func (s *OrcaServer) pauseReconcileHandler(w http.ResponseWriter, r *http.Request) {
+	s.scheduler.PauseReconcile()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.scheduler.GetReconcileStatus())
+}
+
+// resumeReconcileHandler handles resuming the reconcile loop
+func (s *OrcaServer) resumeReconcileHandler(w http.ResponseWriter, r *http.Request) {
+	s.scheduler.ResumeReconcile()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.scheduler.GetReconcileStatus())
+}
+
 // statsHandler handles getting system statistics
 func (s *OrcaServer) statsHandler(w http.ResponseWriter, r *http.Request) {
 	containers, err := s.containerManager.List(r.Context())
@@ -450,14 +2078,19 @@ func (s *OrcaServer) statsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	deployments := s.scheduler.ListDeployments()
-	services := s.scheduler.ListServices()
+	deployments, err := s.scheduler.GetDeploymentStats(r.Context())
+	if err != nil {
+		s.logger.WithError(err).Error("Deployment istatistikleri alınamadı")
+		http.Error(w, "İstatistikler alınamadı", http.StatusInternalServerError)
+		return
+	}
+	services := s.scheduler.ListServices("")
 
 	stats := map[string]interface{}{
 		"containers":  len(containers),
-		"deployments": len(deployments),
+		"deployments": deployments,
 		"services":    len(services),
-		"uptime":      "N/A", // Bu daha sonra implement edilebilir
+		"uptime":      time.Since(s.startTime).Round(time.Second).String(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// idempotencyKeyHeader is the request header carrying the idempotency key
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyCache remembers the responses of create requests by key so
+// retried requests get the original response instead of a duplicate
+type idempotencyCache struct {
+	ttl     time.Duration
+	mutex   sync.Mutex
+	entries map[string]*idempotencyEntry
+}
+
+// idempotencyEntry is a cached response. done is closed once the first
+// request with the key has completed.
+type idempotencyEntry struct {
+	done    chan struct{}
+	status  int
+	header  http.Header
+	body    []byte
+	expires time.Time
+}
+
+// newIdempotencyCache creates an idempotency cache keeping responses for ttl
+func newIdempotencyCache(ttl time.Duration) *idempotencyCache {
+	return &idempotencyCache{
+		ttl:     ttl,
+		entries: make(map[string]*idempotencyEntry),
+	}
+}
+
+// idempotent wraps a create handler so requests carrying an Idempotency-Key
+// header are executed at most once per key within the cache TTL
+func (s *OrcaServer) idempotent(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(idempotencyKeyHeader)
+		if key == "" || s.idempotency == nil {
+			next(w, r)
+			return
+		}
+
+		// Keys are scoped to the endpoint they were used with
+		cacheKey := r.Method + " " + r.URL.Path + " " + key
+		entry, owner := s.idempotency.acquire(cacheKey)
+		if !owner {
+			// Wait for a concurrent request with the same key to finish
+			select {
+			case <-entry.done:
+			case <-r.Context().Done():
+				return
+			}
+			if entry.status == 0 {
+				http.Error(w, "Aynı Idempotency-Key ile yapılan istek başarısız oldu, tekrar deneyin", http.StatusConflict)
+				return
+			}
+			for name, values := range entry.header {
+				w.Header()[name] = values
+			}
+			w.Header().Set("Idempotent-Replayed", "true")
+			w.WriteHeader(entry.status)
+			w.Write(entry.body)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next(recorder, r)
+		s.idempotency.complete(cacheKey, entry, recorder)
+	}
+}
+
+// acquire returns the entry for key and whether the caller owns it and must
+// execute the request. Expired entries are dropped first.
+func (c *idempotencyCache) acquire(key string) (*idempotencyEntry, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if !entry.expires.IsZero() && now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+
+	if entry, ok := c.entries[key]; ok {
+		return entry, false
+	}
+
+	entry := &idempotencyEntry{done: make(chan struct{})}
+	c.entries[key] = entry
+	return entry, true
+}
+
+// complete stores the recorded response of a request. Server errors are not
+// cached so the request can be retried with the same key.
+func (c *idempotencyCache) complete(key string, entry *idempotencyEntry, recorder *responseRecorder) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if recorder.status >= http.StatusInternalServerError {
+		delete(c.entries, key)
+	} else {
+		entry.status = recorder.status
+		entry.header = recorder.Header().Clone()
+		entry.body = recorder.body.Bytes()
+		entry.expires = time.Now().Add(c.ttl)
+	}
+	close(entry.done)
+}
+
+// responseRecorder passes a response through while keeping a copy of it
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+// WriteHeader records the status code
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the body
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to
+// flush streamed progress
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -2,15 +2,19 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"orca/pkg/audit"
 	"orca/pkg/config"
 	"orca/pkg/container"
+	"orca/pkg/proxy"
 	"orca/pkg/scheduler"
 	"orca/pkg/storage"
 
@@ -21,16 +25,27 @@ import (
 // OrcaServer represents the main orchestrator server
 type OrcaServer struct {
 	config           *config.Config
+	configPath       string
 	logger           *logrus.Logger
 	containerManager *container.Manager
 	scheduler        *scheduler.Scheduler
 	storage          *storage.Storage
+	proxy            *proxy.Proxy
+	idempotency      *idempotencyCache
+	accessLog        *logrus.Logger
+	accessLogFile    *rotatingFile
+	auditLog         *audit.Log
 	router           *mux.Router
+	startTime        time.Time
 }
 
 func main() {
+	// Parse flags; ORCA_CONFIG is used when --config is not given
+	configPath := flag.String("config", os.Getenv("ORCA_CONFIG"), "Konfigürasyon dosyası yolu (varsayılan: $ORCA_CONFIG veya arama yolları)")
+	flag.Parse()
+
 	// Load configuration
-	cfg, err := config.Load("")
+	cfg, err := config.Load(*configPath)
 	if err != nil {
 		fmt.Printf("Konfigürasyon yüklenemedi: %v\n", err)
 		os.Exit(1)
@@ -38,24 +53,17 @@ func main() {
 
 	// Setup logger
 	logger := logrus.New()
-	level, err := logrus.ParseLevel(cfg.Logging.Level)
-	if err != nil {
+	if err := configureLogger(logger, cfg.Logging); err != nil {
 		fmt.Printf("Geçersiz log seviyesi: %v\n", err)
 		os.Exit(1)
 	}
-	logger.SetLevel(level)
-
-	if cfg.Logging.Format == "json" {
-		logger.SetFormatter(&logrus.JSONFormatter{})
-	} else {
-		logger.SetFormatter(&logrus.TextFormatter{})
-	}
 
 	// Create server
 	server, err := NewOrcaServer(cfg, logger)
 	if err != nil {
 		logger.WithError(err).Fatal("Server oluşturulamadı")
 	}
+	server.configPath = *configPath
 
 	// Start server
 	if err := server.Start(); err != nil {
@@ -63,29 +71,103 @@ func main() {
 	}
 }
 
+// configureLogger applies the logging level and format to logger
+func configureLogger(logger *logrus.Logger, cfg config.LoggingConfig) error {
+	level, err := logrus.ParseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+	logger.SetLevel(level)
+
+	if cfg.Format == "json" {
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	} else {
+		logger.SetFormatter(&logrus.TextFormatter{})
+	}
+	return nil
+}
+
+// reloadLogging re-reads the config file and applies its logging section.
+// Other settings need a restart; on error the current logging is kept.
+func (s *OrcaServer) reloadLogging() {
+	cfg, err := config.Load(s.configPath)
+	if err != nil {
+		s.logger.WithError(err).Error("Konfigürasyon yeniden yüklenemedi, log ayarları değiştirilmedi")
+		return
+	}
+	if err := configureLogger(s.logger, cfg.Logging); err != nil {
+		s.logger.WithError(err).Error("Log ayarları uygulanamadı")
+		return
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"level":  cfg.Logging.Level,
+		"format": cfg.Logging.Format,
+	}).Info("Log ayarları yeniden yüklendi")
+}
+
 // NewOrcaServer creates a new Orca server
 func NewOrcaServer(cfg *config.Config, logger *logrus.Logger) (*OrcaServer, error) {
 	// Create container manager
-	containerManager, err := container.NewManager(logger)
+	containerManager, err := container.NewManager(cfg.Docker, logger)
 	if err != nil {
 		return nil, fmt.Errorf("container manager oluşturulamadı: %w", err)
 	}
 
-	// Create scheduler
-	sched := scheduler.NewScheduler(containerManager, logger)
-
 	// Create storage
 	store, err := storage.NewStorage(cfg.Storage.DataDir, logger)
 	if err != nil {
 		return nil, fmt.Errorf("storage oluşturulamadı: %w", err)
 	}
 
+	// Create scheduler
+	sched := scheduler.NewScheduler(cfg.Scheduler, containerManager, store, logger)
+
+	// Front proxy mode deployments on their published ports
+	px := proxy.New(logger)
+	sched.SetRouter(px)
+
+	// Secret mounts are read from storage
+	containerManager.SetSecretStore(store)
+	containerManager.SetMaxLogBytes(cfg.Server.MaxLogBytes)
+
+	// Forward scheduler events to the webhook if configured
+	if cfg.Notifications.WebhookURL != "" {
+		dispatcher := scheduler.NewWebhookDispatcher(cfg.Notifications, logger)
+		sched.OnEvent(dispatcher.Dispatch)
+	}
+
 	server := &OrcaServer{
 		config:           cfg,
 		logger:           logger,
 		containerManager: containerManager,
 		scheduler:        sched,
 		storage:          store,
+		proxy:            px,
+		startTime:        time.Now(),
+	}
+
+	if cfg.Server.IdempotencyTTL > 0 {
+		server.idempotency = newIdempotencyCache(cfg.Server.IdempotencyTTL)
+	}
+
+	if cfg.Server.AccessLog != "" {
+		accessLog, accessLogFile, err := newAccessLogger(cfg.Server)
+		if err != nil {
+			return nil, err
+		}
+		server.accessLog = accessLog
+		server.accessLogFile = accessLogFile
+	}
+
+	if cfg.Server.AuditLog != "" {
+		auditLog, err := audit.Open(cfg.Server.AuditLog)
+		if err != nil {
+			return nil, err
+		}
+		server.auditLog = auditLog
+	} else {
+		logger.Warn("Audit log kapalı (server.audit_log boş): değişiklik yapan istekler kaydedilmeyecek")
 	}
 
 	// Setup routes
@@ -102,7 +184,7 @@ func (s *OrcaServer) Start() error {
 	}
 
 	// Create HTTP server
-	addr := fmt.Sprintf("%s:%d", s.config.Server.Host, s.config.Server.Port)
+	addr := s.config.Server.ListenAddress()
 	httpServer := &http.Server{
 		Addr:         addr,
 		Handler:      s.router,
@@ -111,20 +193,60 @@ func (s *OrcaServer) Start() error {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Listen on the Unix socket if configured, otherwise on host:port
+	var listener net.Listener
+	var err error
+	if socketPath := s.config.Server.UnixSocket; socketPath != "" {
+		listener, err = listenUnix(socketPath)
+		addr = "unix://" + socketPath
+		defer os.Remove(socketPath)
+	} else {
+		listener, err = net.Listen("tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("dinlenemedi (%s): %w", addr, err)
+	}
+
+	// Log the address actually bound, which resolves host names and port 0
+	fields := logrus.Fields{"address": addr}
+	if tcpAddr, ok := listener.Addr().(*net.TCPAddr); ok {
+		fields["address"] = tcpAddr.String()
+		fields["all_interfaces"] = tcpAddr.IP.IsUnspecified()
+	}
+
 	// Start server in goroutine
 	go func() {
-		s.logger.WithField("address", addr).Info("Orca orchestrator başlatılıyor")
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		s.logger.WithFields(fields).Info("Orca orchestrator başlatılıyor")
+		if err := httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
 			s.logger.WithError(err).Fatal("HTTP server hatası")
 		}
 	}()
 
-	// Wait for interrupt signal
+	// Repair failed replicas in the background until shutdown
+	reconcileCtx, stopReconcile := context.WithCancel(context.Background())
+	defer stopReconcile()
+	go s.scheduler.RunReconcile(reconcileCtx)
+	go s.scheduler.RunServiceHealth(reconcileCtx)
+
+	// Wait for interrupt signal, reloading logging settings on SIGHUP
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+
+wait:
+	for {
+		select {
+		case <-reload:
+			s.reloadLogging()
+		case <-quit:
+			break wait
+		}
+	}
 
 	s.logger.Info("Orca orchestrator kapatılıyor...")
+	stopReconcile()
 
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -135,10 +257,45 @@ func (s *OrcaServer) Start() error {
 		return err
 	}
 
+	s.proxy.Close()
+
+	if s.accessLogFile != nil {
+		s.accessLogFile.Close()
+	}
+
+	if s.auditLog != nil {
+		s.auditLog.Close()
+	}
+
 	s.logger.Info("Orca orchestrator başarıyla kapatıldı")
 	return nil
 }
 
+// listenUnix listens on a Unix socket, removing a stale socket file first.
+// Access is restricted to the owner and group through file permissions.
+func listenUnix(socketPath string) (net.Listener, error) {
+	if info, err := os.Stat(socketPath); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s mevcut ve bir soket değil", socketPath)
+		}
+		if err := os.Remove(socketPath); err != nil {
+			return nil, fmt.Errorf("eski soket silinemedi: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(socketPath, 0660); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("soket izinleri ayarlanamadı: %w", err)
+	}
+
+	return listener, nil
+}
+
 // loadFromStorage loads deployments and services from storage
 func (s *OrcaServer) loadFromStorage() error {
 	// Load deployments
@@ -147,6 +304,7 @@ func (s *OrcaServer) loadFromStorage() error {
 		return fmt.Errorf("deployments yüklenemedi: %w", err)
 	}
 
+	s.scheduler.RestoreDeployments(deployments)
 	s.logger.WithField("count", len(deployments)).Info("Deployments storage'dan yüklendi")
 
 	// Load services
@@ -155,8 +313,13 @@ func (s *OrcaServer) loadFromStorage() error {
 		return fmt.Errorf("services yüklenemedi: %w", err)
 	}
 
+	s.scheduler.RestoreServices(services)
 	s.logger.WithField("count", len(services)).Info("Services storage'dan yüklendi")
 
+	// Persisted endpoints point at the host ports replicas had before the
+	// restart
+	s.scheduler.RefreshEndpoints(context.Background())
+
 	return nil
 }
 
@@ -164,47 +327,113 @@ func (s *OrcaServer) loadFromStorage() error {
 func (s *OrcaServer) setupRoutes() {
 	s.router = mux.NewRouter()
 
-	// Health check
+	// Health check and server info
 	s.router.HandleFunc("/health", s.healthHandler).Methods("GET")
+	s.router.HandleFunc("/info", s.infoHandler).Methods("GET")
+	s.router.HandleFunc("/version", s.versionHandler).Methods("GET")
 
 	// Container routes
 	s.router.HandleFunc("/containers", s.listContainersHandler).Methods("GET")
-	s.router.HandleFunc("/containers", s.createContainerHandler).Methods("POST")
+	s.router.HandleFunc("/containers", s.idempotent(s.createContainerHandler)).Methods("POST")
 	s.router.HandleFunc("/containers/{name}/start", s.startContainerHandler).Methods("POST")
 	s.router.HandleFunc("/containers/{name}/stop", s.stopContainerHandler).Methods("POST")
+	s.router.HandleFunc("/containers/{name}/recreate", s.recreateContainerHandler).Methods("POST")
+	s.router.HandleFunc("/containers/{name}/adopt", s.adoptContainerHandler).Methods("POST")
+	s.router.HandleFunc("/containers/{name}/commit", s.commitContainerHandler).Methods("POST")
 	s.router.HandleFunc("/containers/{name}/remove", s.removeContainerHandler).Methods("DELETE")
 	s.router.HandleFunc("/containers/{name}/logs", s.containerLogsHandler).Methods("GET")
+	s.router.HandleFunc("/containers/{name}/attach", s.attachContainerHandler).Methods("POST")
+	s.router.HandleFunc("/containers/{name}/changes", s.containerChangesHandler).Methods("GET")
+	s.router.HandleFunc("/containers/{name}/export", s.exportContainerHandler).Methods("GET")
+	s.router.HandleFunc("/containers/{name}/file", s.containerFileHandler).Methods("GET")
+	s.router.HandleFunc("/containers/{name}/stats", s.containerStatsHandler).Methods("GET")
+	s.router.HandleFunc("/containers/{name}/top", s.containerTopHandler).Methods("GET")
 	s.router.HandleFunc("/containers/{name}", s.getContainerHandler).Methods("GET")
+	s.router.HandleFunc("/containers/{name}", s.updateContainerHandler).Methods("PATCH")
+	s.router.HandleFunc("/containers/{name}/restart-policy", s.updateRestartPolicyHandler).Methods("PATCH")
 
 	// Deployment routes
 	s.router.HandleFunc("/deployments", s.listDeploymentsHandler).Methods("GET")
-	s.router.HandleFunc("/deployments", s.createDeploymentHandler).Methods("POST")
+	s.router.HandleFunc("/deployments", s.idempotent(s.createDeploymentHandler)).Methods("POST")
+	s.router.HandleFunc("/deployments/batch-delete", s.batchDeleteDeploymentsHandler).Methods("POST")
 	s.router.HandleFunc("/deployments/{name}", s.getDeploymentHandler).Methods("GET")
+	s.router.HandleFunc("/deployments/{name}/status", s.deploymentStatusHandler).Methods("GET")
+	s.router.HandleFunc("/deployments/{name}/logs", s.deploymentLogsHandler).Methods("GET")
+	s.router.HandleFunc("/deployments/{name}/scale", s.getScaleHandler).Methods("GET")
+	s.router.HandleFunc("/deployments/{name}/scale", s.scaleDeploymentHandler).Methods("PUT")
+	s.router.HandleFunc("/deployments/{name}/restart", s.restartDeploymentHandler).Methods("POST")
+	s.router.HandleFunc("/deployments/{name}", s.updateDeploymentHandler).Methods("PUT")
+	s.router.HandleFunc("/deployments/{name}/promote", s.promoteDeploymentHandler).Methods("POST")
+	s.router.HandleFunc("/deployments/{name}/abort", s.abortRolloutHandler).Methods("POST")
 	s.router.HandleFunc("/deployments/{name}", s.deleteDeploymentHandler).Methods("DELETE")
 
 	// Service routes
 	s.router.HandleFunc("/services", s.listServicesHandler).Methods("GET")
-	s.router.HandleFunc("/services", s.createServiceHandler).Methods("POST")
+	s.router.HandleFunc("/services", s.idempotent(s.createServiceHandler)).Methods("POST")
+	s.router.HandleFunc("/services/batch-delete", s.batchDeleteServicesHandler).Methods("POST")
 	s.router.HandleFunc("/services/{name}", s.getServiceHandler).Methods("GET")
 	s.router.HandleFunc("/services/{name}", s.deleteServiceHandler).Methods("DELETE")
 
+	// Secret routes
+	s.router.HandleFunc("/secrets", s.listSecretsHandler).Methods("GET")
+	s.router.HandleFunc("/secrets", s.createSecretHandler).Methods("POST")
+	s.router.HandleFunc("/secrets/{name}", s.deleteSecretHandler).Methods("DELETE")
+
+	// Network routes
+	s.router.HandleFunc("/networks", s.listNetworksHandler).Methods("GET")
+	s.router.HandleFunc("/networks/{name}", s.getNetworkHandler).Methods("GET")
+
+	// Event routes
+	s.router.HandleFunc("/events", s.listEventsHandler).Methods("GET")
+
+	// Orphan routes
+	s.router.HandleFunc("/orphans", s.listOrphansHandler).Methods("GET")
+	s.router.HandleFunc("/orphans/prune", s.pruneOrphansHandler).Methods("POST")
+	s.router.HandleFunc("/system/prune", s.systemPruneHandler).Methods("POST")
+
+	// Reconcile routes
+	s.router.HandleFunc("/reconcile/status", s.reconcileStatusHandler).Methods("GET")
+	s.router.HandleFunc("/reconcile/pause", s.pauseReconcileHandler).Methods("POST")
+	s.router.HandleFunc("/reconcile/resume", s.resumeReconcileHandler).Methods("POST")
+
+	// Audit route
+	s.router.HandleFunc("/audit", s.listAuditHandler).Methods("GET")
+
 	// Stats route
 	s.router.HandleFunc("/stats", s.statsHandler).Methods("GET")
 
-	// Add logging middleware
+	// Add logging and audit middleware
 	s.router.Use(s.loggingMiddleware)
+	s.router.Use(s.auditMiddleware)
 }
 
 // Middleware for logging requests
 func (s *OrcaServer) loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
-		next.ServeHTTP(w, r)
+		recorder := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(recorder, r)
+		duration := time.Since(start)
 		s.logger.WithFields(logrus.Fields{
 			"method":   r.Method,
 			"path":     r.URL.Path,
-			"duration": time.Since(start),
+			"status":   recorder.Status(),
+			"bytes":    recorder.bytes,
+			"duration": duration,
 			"remote":   r.RemoteAddr,
 		}).Info("HTTP request")
+
+		if s.accessLog != nil {
+			s.accessLog.WithFields(logrus.Fields{
+				"method":      r.Method,
+				"path":        r.URL.Path,
+				"query":       r.URL.RawQuery,
+				"status":      recorder.Status(),
+				"bytes":       recorder.bytes,
+				"duration_ms": duration.Milliseconds(),
+				"remote":      r.RemoteAddr,
+				"user_agent":  r.UserAgent(),
+			}).Info("access")
+		}
 	})
 }
\ No newline at end of file
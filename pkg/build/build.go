@@ -0,0 +1,30 @@
+package build
+
+import "runtime"
+
+// Build metadata, overridden at build time via -ldflags, e.g.
+//
+//	go build -ldflags "-X orca/pkg/build.Version=1.2.0 -X orca/pkg/build.Commit=abc123"
+var (
+	Version = "dev"
+	Commit  = "unknown"
+	Date    = "unknown"
+)
+
+// Info holds build metadata
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+}
+
+// GetInfo returns the build metadata of the running binary
+func GetInfo() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+	}
+}
@@ -2,30 +2,95 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // Config holds the application configuration
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Docker   DockerConfig   `mapstructure:"docker"`
-	Storage  StorageConfig  `mapstructure:"storage"`
-	Logging  LoggingConfig  `mapstructure:"logging"`
+	Server        ServerConfig        `mapstructure:"server"`
+	Docker        DockerConfig        `mapstructure:"docker"`
+	Storage       StorageConfig       `mapstructure:"storage"`
+	Logging       LoggingConfig       `mapstructure:"logging"`
+	Scheduler     SchedulerConfig     `mapstructure:"scheduler"`
+	Notifications NotificationsConfig `mapstructure:"notifications"`
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Host string `mapstructure:"host"`
-	Port int    `mapstructure:"port"`
+	Host       string `mapstructure:"host"`
+	Port       int    `mapstructure:"port"`
+	UnixSocket string `mapstructure:"unix_socket"`
+	// IdempotencyTTL is how long create responses are kept for replay by
+	// Idempotency-Key; zero disables idempotency keys
+	IdempotencyTTL time.Duration `mapstructure:"idempotency_ttl"`
+	// AccessLog is a file receiving one JSON line per request; empty
+	// disables the access log
+	AccessLog string `mapstructure:"access_log"`
+	// AccessLogMaxSize is the size in megabytes at which the access log is
+	// rotated
+	AccessLogMaxSize int `mapstructure:"access_log_max_size"`
+	// AccessLogMaxBackups is how many rotated access logs are kept
+	AccessLogMaxBackups int `mapstructure:"access_log_max_backups"`
+	// AuditLog is an append-only file receiving one JSON line per mutating
+	// request; empty disables the audit log
+	AuditLog string `mapstructure:"audit_log"`
+	// MaxLogBytes is the most log data a single logs request reads into
+	// memory; requests may ask for less
+	MaxLogBytes int `mapstructure:"max_log_bytes"`
+}
+
+// ListenAddress returns the TCP address the server listens on. IPv6 hosts
+// may be written with or without brackets; an empty host listens on all
+// interfaces.
+func (c ServerConfig) ListenAddress() string {
+	return net.JoinHostPort(strings.TrimSuffix(strings.TrimPrefix(c.Host, "["), "]"), strconv.Itoa(c.Port))
+}
+
+// hostNamePattern allows DNS host names such as localhost or orca.internal
+var hostNamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*$`)
+
+// validateHost checks that host is empty, an IPv4 or IPv6 address (IPv6
+// optionally in brackets) or a host name
+func validateHost(host string) error {
+	if host == "" {
+		return nil
+	}
+	if strings.HasPrefix(host, "[") || strings.HasSuffix(host, "]") {
+		inner := strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+		if len(inner) != len(host)-2 || net.ParseIP(inner) == nil || !strings.Contains(inner, ":") {
+			return fmt.Errorf("geçersiz sunucu adresi: %s (köşeli parantez yalnızca IPv6 adresleri için kullanılır)", host)
+		}
+		return nil
+	}
+	if net.ParseIP(host) != nil || hostNamePattern.MatchString(host) {
+		return nil
+	}
+	return fmt.Errorf("geçersiz sunucu adresi: %s (IP adresi veya host adı olmalı, port ayrıca verilir)", host)
 }
 
 // DockerConfig holds Docker configuration
 type DockerConfig struct {
-	Host    string `mapstructure:"host"`
-	Version string `mapstructure:"version"`
+	Host           string        `mapstructure:"host"`
+	Version        string        `mapstructure:"version"`
+	DefaultNetwork string        `mapstructure:"default_network"`
+	DefaultSubnet  string        `mapstructure:"default_subnet"`
+	OpTimeout      time.Duration `mapstructure:"op_timeout"`
+	// NamePrefix is prepended to the Docker name of every container ORCA
+	// creates, so they never clash with other containers on the host
+	NamePrefix string `mapstructure:"name_prefix"`
+	// SecretsDir holds the secret files mounted into containers. It should
+	// be on a tmpfs so secret data is never written to disk.
+	SecretsDir string `mapstructure:"secrets_dir"`
 }
 
 // StorageConfig holds storage configuration
@@ -33,6 +98,100 @@ type StorageConfig struct {
 	DataDir string `mapstructure:"data_dir"`
 }
 
+// SchedulerConfig holds scheduler configuration
+type SchedulerConfig struct {
+	NodePortMin int `mapstructure:"node_port_min"`
+	NodePortMax int `mapstructure:"node_port_max"`
+	// DefaultReplicas is used for deployments that do not set replicas
+	DefaultReplicas int `mapstructure:"default_replicas"`
+	// DefaultPullPolicy is used for deployments that do not set a pull policy
+	DefaultPullPolicy string `mapstructure:"default_pull_policy"`
+	// DefaultStrategy is used for deployments that do not set a strategy
+	DefaultStrategy string `mapstructure:"default_strategy"`
+	// ReconcileInterval is how often failed replicas are repaired; zero
+	// disables the reconcile loop
+	ReconcileInterval time.Duration `mapstructure:"reconcile_interval"`
+	// ReconcileJitter randomly varies each reconcile interval by up to this
+	// fraction of it, so many orchestrators do not poll Docker in lockstep
+	ReconcileJitter float64 `mapstructure:"reconcile_jitter"`
+	// ReconcileConcurrency caps how many replica states are checked at once
+	ReconcileConcurrency int `mapstructure:"reconcile_concurrency"`
+	// MaxReplicas is the largest replica count a deployment may have, up to
+	// MaxReplicasLimit
+	MaxReplicas int `mapstructure:"max_replicas"`
+	// ServiceHealthInterval is how often service endpoints are probed; zero
+	// disables service health checks
+	ServiceHealthInterval time.Duration `mapstructure:"service_health_interval"`
+	// ServiceHealthTimeout bounds a single endpoint probe
+	ServiceHealthTimeout time.Duration `mapstructure:"service_health_timeout"`
+	// ReplicaNameTemplate is a Go template naming replica containers from
+	// .Deployment and .Index
+	ReplicaNameTemplate string `mapstructure:"replica_name_template"`
+}
+
+// DefaultReplicaNameTemplate names replicas after their deployment and index
+const DefaultReplicaNameTemplate = "{{.Deployment}}-{{.Index}}"
+
+// dockerNamePattern allows the characters Docker accepts in container names
+var dockerNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// ReplicaName returns the container name of replica index of a deployment
+// according to ReplicaNameTemplate
+func (c SchedulerConfig) ReplicaName(deployment string, index int) (string, error) {
+	text := c.ReplicaNameTemplate
+	if text == "" {
+		text = DefaultReplicaNameTemplate
+	}
+	tmpl, err := template.New("replica_name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("geçersiz replica adı şablonu: %w", err)
+	}
+
+	var name strings.Builder
+	fields := struct {
+		Deployment string
+		Index      int
+	}{deployment, index}
+	if err := tmpl.Execute(&name, fields); err != nil {
+		return "", fmt.Errorf("replica adı oluşturulamadı: %w", err)
+	}
+	if !dockerNamePattern.MatchString(name.String()) {
+		return "", fmt.Errorf("geçersiz replica adı: %q (harf veya rakamla başlamalı, yalnızca harf, rakam, '_', '.' ve '-' içermeli)", name.String())
+	}
+	return name.String(), nil
+}
+
+// validateReplicaNameTemplate checks that the template produces Docker
+// names that differ for every deployment and replica index
+func validateReplicaNameTemplate(c SchedulerConfig) error {
+	first, err := c.ReplicaName("web", 0)
+	if err != nil {
+		return err
+	}
+	next, err := c.ReplicaName("web", 1)
+	if err != nil {
+		return err
+	}
+	other, err := c.ReplicaName("api", 0)
+	if err != nil {
+		return err
+	}
+	if first == next || first == other {
+		return fmt.Errorf("geçersiz replica adı şablonu: %s (şablon deployment adını ve replica sırasını içermeli)", c.ReplicaNameTemplate)
+	}
+	return nil
+}
+
+// MaxReplicasLimit bounds the configurable replica cap
+const MaxReplicasLimit = 10000
+
+// NotificationsConfig holds event notification configuration
+type NotificationsConfig struct {
+	WebhookURL string        `mapstructure:"webhook_url"`
+	Timeout    time.Duration `mapstructure:"timeout"`
+	Retries    int           `mapstructure:"retries"`
+}
+
 // LoggingConfig holds logging configuration
 type LoggingConfig struct {
 	Level  string `mapstructure:"level"`
@@ -43,12 +202,19 @@ type LoggingConfig struct {
 func DefaultConfig() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Host: "localhost",
-			Port: 8080,
+			Host:                "localhost",
+			Port:                8080,
+			IdempotencyTTL:      24 * time.Hour,
+			AccessLogMaxSize:    100,
+			AccessLogMaxBackups: 5,
+			AuditLog:            "./data/audit.log",
+			MaxLogBytes:         10 * 1024 * 1024,
 		},
 		Docker: DockerConfig{
-			Host:    "unix:///var/run/docker.sock",
-			Version: "1.41",
+			Host:       "unix:///var/run/docker.sock",
+			Version:    "1.41",
+			OpTimeout:  60 * time.Second,
+			SecretsDir: "/dev/shm/orca-secrets",
 		},
 		Storage: StorageConfig{
 			DataDir: "./data",
@@ -57,6 +223,24 @@ func DefaultConfig() *Config {
 			Level:  "info",
 			Format: "json",
 		},
+		Scheduler: SchedulerConfig{
+			NodePortMin:           30000,
+			NodePortMax:           32767,
+			DefaultReplicas:       1,
+			DefaultPullPolicy:     "missing",
+			DefaultStrategy:       "RollingUpdate",
+			ReconcileInterval:     30 * time.Second,
+			ReconcileJitter:       0.1,
+			ReconcileConcurrency:  10,
+			MaxReplicas:           100,
+			ServiceHealthInterval: 10 * time.Second,
+			ServiceHealthTimeout:  2 * time.Second,
+			ReplicaNameTemplate:   DefaultReplicaNameTemplate,
+		},
+		Notifications: NotificationsConfig{
+			Timeout: 5 * time.Second,
+			Retries: 3,
+		},
 	}
 }
 
@@ -76,9 +260,14 @@ func Load(configPath string) (*Config, error) {
 		viper.AddConfigPath("$HOME/.orca")
 	}
 
-	// Environment variables
+	// Environment variables; nested keys map to ORCA_SECTION_KEY, e.g.
+	// server.port to ORCA_SERVER_PORT
 	viper.SetEnvPrefix("ORCA")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
+	if err := bindEnv("", reflect.TypeOf(Config{})); err != nil {
+		return nil, fmt.Errorf("ortam değişkenleri bağlanamadı: %w", err)
+	}
 
 	// Read config file
 	if err := viper.ReadInConfig(); err != nil {
@@ -101,6 +290,33 @@ func Load(configPath string) (*Config, error) {
 	return config, nil
 }
 
+// bindEnv binds every config key below prefix to its environment variable.
+// AutomaticEnv only applies to keys viper already knows, so keys missing
+// from the config file would otherwise never be read from the environment.
+func bindEnv(prefix string, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := field.Tag.Get("mapstructure")
+		if key == "" {
+			continue
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+			if err := bindEnv(key, field.Type); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := viper.BindEnv(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // validateConfig validates configuration and creates necessary directories
 func validateConfig(config *Config) error {
 	// Create data directory if it doesn't exist
@@ -132,6 +348,98 @@ func validateConfig(config *Config) error {
 		return fmt.Errorf("geçersiz log formatı: %s", config.Logging.Format)
 	}
 
+	// Validate listen address; the host is used as is, without a port
+	if err := validateHost(config.Server.Host); err != nil {
+		return err
+	}
+
+	if config.Server.Port < 0 || config.Server.Port > 65535 {
+		return fmt.Errorf("geçersiz sunucu portu: %d", config.Server.Port)
+	}
+
+	// Validate NodePort range
+	if config.Scheduler.NodePortMin < 1 || config.Scheduler.NodePortMax > 65535 || config.Scheduler.NodePortMin > config.Scheduler.NodePortMax {
+		return fmt.Errorf("geçersiz NodePort aralığı: %d-%d", config.Scheduler.NodePortMin, config.Scheduler.NodePortMax)
+	}
+
+	if config.Scheduler.MaxReplicas < 1 || config.Scheduler.MaxReplicas > MaxReplicasLimit {
+		return fmt.Errorf("geçersiz en fazla replica sayısı: %d (1-%d arasında olmalı)", config.Scheduler.MaxReplicas, MaxReplicasLimit)
+	}
+
+	if config.Scheduler.DefaultReplicas < 1 || config.Scheduler.DefaultReplicas > config.Scheduler.MaxReplicas {
+		return fmt.Errorf("geçersiz varsayılan replica sayısı: %d", config.Scheduler.DefaultReplicas)
+	}
+
+	validPullPolicies := map[string]bool{
+		"always":  true,
+		"missing": true,
+		"never":   true,
+	}
+
+	if !validPullPolicies[config.Scheduler.DefaultPullPolicy] {
+		return fmt.Errorf("geçersiz varsayılan pull policy: %s", config.Scheduler.DefaultPullPolicy)
+	}
+
+	if config.Scheduler.DefaultStrategy != "RollingUpdate" && config.Scheduler.DefaultStrategy != "Recreate" {
+		return fmt.Errorf("geçersiz varsayılan strategy: %s", config.Scheduler.DefaultStrategy)
+	}
+
+	if config.Scheduler.ReconcileInterval < 0 {
+		return fmt.Errorf("geçersiz reconcile aralığı: %s", config.Scheduler.ReconcileInterval)
+	}
+
+	if config.Scheduler.ReconcileJitter < 0 || config.Scheduler.ReconcileJitter > 1 {
+		return fmt.Errorf("geçersiz reconcile jitter değeri: %g (0 ile 1 arasında olmalı)", config.Scheduler.ReconcileJitter)
+	}
+
+	if config.Scheduler.ReconcileConcurrency < 1 {
+		return fmt.Errorf("geçersiz reconcile eşzamanlılık sınırı: %d", config.Scheduler.ReconcileConcurrency)
+	}
+
+	if config.Scheduler.ServiceHealthInterval < 0 {
+		return fmt.Errorf("geçersiz service health check aralığı: %s", config.Scheduler.ServiceHealthInterval)
+	}
+
+	if config.Scheduler.ServiceHealthTimeout <= 0 {
+		return fmt.Errorf("geçersiz service health check zaman aşımı: %s", config.Scheduler.ServiceHealthTimeout)
+	}
+
+	if err := validateReplicaNameTemplate(config.Scheduler); err != nil {
+		return err
+	}
+
+	if config.Notifications.Retries < 0 {
+		return fmt.Errorf("geçersiz webhook tekrar sayısı: %d", config.Notifications.Retries)
+	}
+
+	if config.Server.IdempotencyTTL < 0 {
+		return fmt.Errorf("geçersiz idempotency süresi: %s", config.Server.IdempotencyTTL)
+	}
+
+	if config.Server.AccessLogMaxSize < 1 {
+		return fmt.Errorf("geçersiz access log boyutu: %d", config.Server.AccessLogMaxSize)
+	}
+
+	if config.Server.AccessLogMaxBackups < 0 {
+		return fmt.Errorf("geçersiz access log yedek sayısı: %d", config.Server.AccessLogMaxBackups)
+	}
+
+	if config.Server.MaxLogBytes < 1 {
+		return fmt.Errorf("geçersiz log boyut sınırı: %d", config.Server.MaxLogBytes)
+	}
+
+	if config.Docker.OpTimeout < 0 {
+		return fmt.Errorf("geçersiz docker işlem zaman aşımı: %s", config.Docker.OpTimeout)
+	}
+
+	if !filepath.IsAbs(config.Docker.SecretsDir) {
+		return fmt.Errorf("secret dizini mutlak bir yol olmalıdır: %q", config.Docker.SecretsDir)
+	}
+
+	if config.Docker.NamePrefix != "" && !dockerNamePattern.MatchString(config.Docker.NamePrefix) {
+		return fmt.Errorf("geçersiz container adı öneki: %s (harf veya rakamla başlamalı, yalnızca harf, rakam, '_', '.' ve '-' içermeli)", config.Docker.NamePrefix)
+	}
+
 	return nil
 }
 
@@ -150,6 +458,8 @@ func SaveConfig(config *Config, configPath string) error {
 	viper.Set("docker", config.Docker)
 	viper.Set("storage", config.Storage)
 	viper.Set("logging", config.Logging)
+	viper.Set("scheduler", config.Scheduler)
+	viper.Set("notifications", config.Notifications)
 
 	if configPath == "" {
 		configDir, err := GetConfigDir()
@@ -0,0 +1,92 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// ErrStdinClosed is returned when attaching stdin to a container that was
+// not created with an open stdin
+var ErrStdinClosed = errors.New("container stdin'i açık değil")
+
+// Attach attaches to the streams of the main process of a running container
+// and copies its stdout and stderr to w until the process exits or ctx is
+// cancelled. Unlike FollowLogs nothing is read from the log driver, so it
+// also works with log drivers that cannot be read back. If stdin is not nil
+// it is copied to the process; the container must have been created with an
+// open stdin. TTY containers produce a single raw stream, others a
+// multiplexed one that is demultiplexed here. The operation timeout does not
+// apply.
+func (m *Manager) Attach(ctx context.Context, containerID string, stdin io.Reader, w io.Writer) error {
+	inspect, err := m.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("container bulunamadı: %w", err)
+	}
+	if err := checkAttachable(inspect, stdin != nil); err != nil {
+		return err
+	}
+	tty := inspect.Config.Tty
+
+	resp, err := m.client.ContainerAttach(ctx, containerID, types.ContainerAttachOptions{
+		Stream: true,
+		Stdin:  stdin != nil,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return fmt.Errorf("container'a bağlanılamadı: %w", err)
+	}
+	defer resp.Close()
+
+	// Closing the connection unblocks the copies below on cancellation
+	stop := context.AfterFunc(ctx, resp.Close)
+	defer stop()
+
+	if stdin != nil {
+		go func() {
+			io.Copy(resp.Conn, stdin)
+			resp.CloseWrite()
+		}()
+	}
+
+	if tty {
+		_, err = io.Copy(w, resp.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(w, w, resp.Reader)
+	}
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("container çıktısı okunamadı: %w", err)
+	}
+
+	return nil
+}
+
+// CheckAttachable returns ErrNotRunning or ErrStdinClosed when Attach would
+// fail, so callers can report it before streaming
+func (m *Manager) CheckAttachable(ctx context.Context, containerID string, stdin bool) error {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	inspect, err := m.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("container bulunamadı: %w", err)
+	}
+	return checkAttachable(inspect, stdin)
+}
+
+// checkAttachable checks that an inspected container is running and, if
+// stdin is attached, has an open stdin
+func checkAttachable(inspect types.ContainerJSON, stdin bool) error {
+	if inspect.State == nil || !inspect.State.Running || inspect.Config == nil {
+		return fmt.Errorf("%w: %s", ErrNotRunning, inspect.Name)
+	}
+	if stdin && !inspect.Config.OpenStdin {
+		return fmt.Errorf("%w: %s", ErrStdinClosed, inspect.Name)
+	}
+	return nil
+}
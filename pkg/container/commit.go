@@ -0,0 +1,58 @@
+package container
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/sirupsen/logrus"
+)
+
+// CommitOptions controls the image created from a container
+type CommitOptions struct {
+	// Reference is the repository and optional tag of the new image
+	Reference string
+	// Message is the commit message recorded in the image history
+	Message string
+	// Author is recorded as the image author
+	Author string
+}
+
+// Commit creates an image tagged ref from the current state of a container
+// and returns its ID
+func (m *Manager) Commit(ctx context.Context, containerID, ref string) (string, error) {
+	return m.CommitWithOptions(ctx, containerID, CommitOptions{Reference: ref})
+}
+
+// CommitWithOptions creates an image from the current state of a container.
+// The container is paused while its filesystem is captured. Containers that
+// mount secrets are refused with ErrMountsSecrets.
+func (m *Manager) CommitWithOptions(ctx context.Context, containerID string, opts CommitOptions) (string, error) {
+	if opts.Reference == "" {
+		return "", fmt.Errorf("image referansı boş olamaz")
+	}
+
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	if err := m.checkNoSecrets(ctx, containerID); err != nil {
+		return "", err
+	}
+
+	resp, err := m.client.ContainerCommit(ctx, containerID, types.ContainerCommitOptions{
+		Reference: opts.Reference,
+		Comment:   opts.Message,
+		Author:    opts.Author,
+		Pause:     true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("container image olarak kaydedilemedi: %w", err)
+	}
+
+	m.logger.WithFields(logrus.Fields{
+		"container_id": containerID,
+		"image":        opts.Reference,
+		"image_id":     resp.ID,
+	}).Info("Container image olarak kaydedildi")
+	return resp.ID, nil
+}
@@ -0,0 +1,155 @@
+package container
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// defaultDevicePermissions are the cgroup permissions of a device mapping
+// that does not give any
+const defaultDevicePermissions = "rwm"
+
+// ParseDevice parses a device mapping of the form
+// host[:container][:permissions], e.g. /dev/ttyUSB0:/dev/ttyUSB0:rw. The
+// container path defaults to the host path and permissions, a combination of
+// r, w and m, default to rwm.
+func ParseDevice(device string) (container.DeviceMapping, error) {
+	parts := strings.Split(device, ":")
+	if len(parts) > 3 {
+		return container.DeviceMapping{}, fmt.Errorf("geçersiz device tanımı: %s (host[:konteyner][:izinler] olmalı)", device)
+	}
+
+	mapping := container.DeviceMapping{
+		PathOnHost:        parts[0],
+		PathInContainer:   parts[0],
+		CgroupPermissions: defaultDevicePermissions,
+	}
+	switch len(parts) {
+	case 2:
+		// The second part is a container path unless it only holds permissions
+		if path.IsAbs(parts[1]) {
+			mapping.PathInContainer = parts[1]
+		} else {
+			mapping.CgroupPermissions = parts[1]
+		}
+	case 3:
+		mapping.PathInContainer = parts[1]
+		mapping.CgroupPermissions = parts[2]
+	}
+
+	if !path.IsAbs(mapping.PathOnHost) || !path.IsAbs(mapping.PathInContainer) {
+		return container.DeviceMapping{}, fmt.Errorf("device yolları mutlak olmalıdır: %s", device)
+	}
+	if !validDevicePermissions(mapping.CgroupPermissions) {
+		return container.DeviceMapping{}, fmt.Errorf("geçersiz device izinleri: %s (r, w ve m harflerinden oluşmalı)", mapping.CgroupPermissions)
+	}
+	return mapping, nil
+}
+
+// validDevicePermissions reports whether permissions is a non-empty
+// combination of r, w and m without repeats
+func validDevicePermissions(permissions string) bool {
+	if permissions == "" {
+		return false
+	}
+	seen := make(map[rune]bool, len(permissions))
+	for _, p := range permissions {
+		if !strings.ContainsRune(defaultDevicePermissions, p) || seen[p] {
+			return false
+		}
+		seen[p] = true
+	}
+	return true
+}
+
+// toDeviceMappings parses device mappings for the host config
+func toDeviceMappings(devices []string) ([]container.DeviceMapping, error) {
+	mappings := make([]container.DeviceMapping, 0, len(devices))
+	for _, device := range devices {
+		mapping, err := ParseDevice(device)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings, nil
+}
+
+// fromDeviceMappings formats device mappings in the form accepted by
+// ParseDevice
+func fromDeviceMappings(mappings []container.DeviceMapping) []string {
+	if len(mappings) == 0 {
+		return nil
+	}
+
+	devices := make([]string, 0, len(mappings))
+	for _, m := range mappings {
+		devices = append(devices, fmt.Sprintf("%s:%s:%s", m.PathOnHost, m.PathInContainer, m.CgroupPermissions))
+	}
+	return devices
+}
+
+// GPUsAll requests every GPU of the host
+const GPUsAll = "all"
+
+// ValidateGPUs checks that gpus is empty, "all" or a positive GPU count
+func ValidateGPUs(gpus string) error {
+	if gpus == "" || gpus == GPUsAll {
+		return nil
+	}
+	if count, err := strconv.Atoi(gpus); err != nil || count < 1 {
+		return fmt.Errorf("geçersiz gpus değeri: %s (all veya pozitif bir sayı olmalı)", gpus)
+	}
+	return nil
+}
+
+// toDeviceRequests converts a validated GPU request to an NVIDIA device
+// request, returning nil if no GPUs are requested
+func toDeviceRequests(gpus string) []container.DeviceRequest {
+	if gpus == "" {
+		return nil
+	}
+
+	count := -1
+	if gpus != GPUsAll {
+		count, _ = strconv.Atoi(gpus)
+	}
+	return []container.DeviceRequest{{
+		Driver:       "nvidia",
+		Count:        count,
+		Capabilities: [][]string{{"gpu"}},
+	}}
+}
+
+// fromDeviceRequests formats the GPUs requested by device requests in the
+// form accepted by ValidateGPUs
+func fromDeviceRequests(requests []container.DeviceRequest) string {
+	for _, request := range requests {
+		if !isGPURequest(request) {
+			continue
+		}
+		if request.Count < 0 {
+			return GPUsAll
+		}
+		if request.Count > 0 {
+			return strconv.Itoa(request.Count)
+		}
+	}
+	return ""
+}
+
+// isGPURequest reports whether a device request asks for GPUs
+func isGPURequest(request container.DeviceRequest) bool {
+	for _, capabilities := range request.Capabilities {
+		for _, capability := range capabilities {
+			if capability == "gpu" {
+				return true
+			}
+		}
+	}
+	return false
+}
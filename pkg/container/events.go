@@ -0,0 +1,99 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// EventFilter selects the container events streamed by Events
+type EventFilter struct {
+	// Namespace limits events to the containers of one namespace; empty
+	// allows all of them
+	Namespace string
+	// Name limits events to the container with this name in Namespace
+	Name string
+	// Actions limits events to Docker actions such as start or die
+	Actions []string
+	// Since replays the events recorded after this time before streaming
+	// new ones
+	Since time.Time
+}
+
+// ContainerEvent is a Docker event about a container
+type ContainerEvent struct {
+	Action      string    `json:"action"`
+	ContainerID string    `json:"container_id"`
+	Name        string    `json:"name"`
+	Namespace   string    `json:"namespace"`
+	Image       string    `json:"image,omitempty"`
+	ExitCode    string    `json:"exit_code,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+// Events streams the container events matching filter to handle until ctx
+// is cancelled or handle returns an error. Actions and the container name
+// are filtered by Docker; namespaces are filtered here, since containers of
+// the default namespace may predate the namespace label. The operation
+// timeout does not apply.
+func (m *Manager) Events(ctx context.Context, filter EventFilter, handle func(ContainerEvent) error) error {
+	args := filters.NewArgs(filters.Arg("type", string(events.ContainerEventType)))
+	for _, action := range filter.Actions {
+		args.Add("event", action)
+	}
+	if filter.Name != "" {
+		args.Add("container", m.DockerName(filter.Namespace, filter.Name))
+	}
+
+	opts := types.EventsOptions{Filters: args}
+	if !filter.Since.IsZero() {
+		opts.Since = strconv.FormatInt(filter.Since.Unix(), 10)
+	}
+
+	messages, errs := m.client.Events(ctx, opts)
+	for {
+		select {
+		case msg := <-messages:
+			event := m.containerEventFrom(msg)
+			if filter.Namespace != "" && event.Namespace != filter.Namespace {
+				continue
+			}
+			if err := handle(event); err != nil {
+				return err
+			}
+		case err := <-errs:
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("docker olayları alınamadı: %w", err)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// containerEventFrom converts a Docker container event
+func (m *Manager) containerEventFrom(msg events.Message) ContainerEvent {
+	attributes := msg.Actor.Attributes
+	namespace := NamespaceOf(attributes)
+
+	timestamp := time.Unix(msg.Time, 0)
+	if msg.TimeNano != 0 {
+		timestamp = time.Unix(0, msg.TimeNano)
+	}
+
+	return ContainerEvent{
+		Action:      msg.Action,
+		ContainerID: msg.Actor.ID,
+		Name:        m.containerName(namespace, attributes["name"]),
+		Namespace:   namespace,
+		Image:       attributes["image"],
+		ExitCode:    attributes["exitCode"],
+		Time:        timestamp,
+	}
+}
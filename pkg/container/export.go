@@ -0,0 +1,26 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Export opens a tar stream of the container's filesystem. The stream is read
+// straight from Docker so large containers are never buffered; it ends when
+// the reader is closed or ctx is cancelled. The operation timeout does not
+// apply. Containers that mount secrets are refused with ErrMountsSecrets.
+func (m *Manager) Export(ctx context.Context, containerID string) (io.ReadCloser, error) {
+	checkCtx, cancel := m.withTimeout(ctx)
+	err := m.checkNoSecrets(checkCtx, containerID)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := m.client.ContainerExport(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("container dışa aktarılamadı: %w", err)
+	}
+	return reader, nil
+}
@@ -0,0 +1,105 @@
+package container
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/docker/docker/errdefs"
+)
+
+// ErrFileNotFound is returned when reading a path that does not exist in a
+// container
+var ErrFileNotFound = errors.New("dosya bulunamadı")
+
+// ErrIsDirectory is returned when reading a path that is a directory
+var ErrIsDirectory = errors.New("yol bir dizin")
+
+// ErrSecretFile is returned when reading a file of a secret mounted into a
+// container
+var ErrSecretFile = errors.New("secret dosyaları okunamaz")
+
+// ReadFile opens a single file of a container for reading, following a
+// symlink to its target. Only that file is copied out of the container;
+// size is its length in bytes. The reader must be closed.
+func (m *Manager) ReadFile(ctx context.Context, containerID, filePath string) (io.ReadCloser, int64, error) {
+	statCtx, cancel := m.withTimeout(ctx)
+	inspect, err := m.client.ContainerInspect(statCtx, containerID)
+	if err != nil {
+		cancel()
+		return nil, 0, fmt.Errorf("container incelenemedi: %w", err)
+	}
+	var secretMounts []SecretMount
+	if inspect.Config != nil {
+		secretMounts = decodeSecretMounts(inspect.Config.Labels)
+	}
+
+	if inSecretMount(secretMounts, filePath) {
+		cancel()
+		return nil, 0, fmt.Errorf("%w: %s", ErrSecretFile, filePath)
+	}
+	stat, err := m.client.ContainerStatPath(statCtx, containerID, filePath)
+	if err == nil && stat.LinkTarget != "" && stat.Mode&os.ModeSymlink != 0 {
+		filePath = stat.LinkTarget
+		if inSecretMount(secretMounts, filePath) {
+			cancel()
+			return nil, 0, fmt.Errorf("%w: %s", ErrSecretFile, filePath)
+		}
+		stat, err = m.client.ContainerStatPath(statCtx, containerID, filePath)
+	}
+	cancel()
+	if errdefs.IsNotFound(err) {
+		return nil, 0, fmt.Errorf("%w: %s", ErrFileNotFound, filePath)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("dosya bilgisi alınamadı: %w", err)
+	}
+	if stat.Mode.IsDir() {
+		return nil, 0, fmt.Errorf("%w: %s", ErrIsDirectory, filePath)
+	}
+
+	// The archive holds just the file, so it is read straight from Docker
+	reader, _, err := m.client.CopyFromContainer(ctx, containerID, filePath)
+	if errdefs.IsNotFound(err) {
+		return nil, 0, fmt.Errorf("%w: %s", ErrFileNotFound, filePath)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("dosya kopyalanamadı: %w", err)
+	}
+
+	tr := tar.NewReader(reader)
+	header, err := tr.Next()
+	if err != nil {
+		reader.Close()
+		return nil, 0, fmt.Errorf("dosya arşivi okunamadı: %w", err)
+	}
+	if header.Typeflag != tar.TypeReg {
+		reader.Close()
+		return nil, 0, fmt.Errorf("normal bir dosya değil: %s", filePath)
+	}
+
+	return fileReader{Reader: tr, Closer: reader}, header.Size, nil
+}
+
+// inSecretMount reports whether filePath lies in the target of a secret mount
+func inSecretMount(secretMounts []SecretMount, filePath string) bool {
+	filePath = path.Clean(filePath)
+	for _, secretMount := range secretMounts {
+		target := path.Clean(secretMount.Target)
+		if filePath == target || strings.HasPrefix(filePath, target+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// fileReader reads one file out of a tar stream and closes the stream
+type fileReader struct {
+	io.Reader
+	io.Closer
+}
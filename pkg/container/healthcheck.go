@@ -0,0 +1,138 @@
+package container
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// Health states Docker reports for containers with a health check
+const (
+	HealthStarting  = "starting"
+	HealthHealthy   = "healthy"
+	HealthUnhealthy = "unhealthy"
+)
+
+// HealthCheck is a Docker health check run inside the container. It replaces
+// the HEALTHCHECK of the image. Durations are Go durations such as 30s.
+type HealthCheck struct {
+	// Test is ["CMD", args...], ["CMD-SHELL", command] or ["NONE"] to turn
+	// off the image's health check
+	Test        []string `json:"test"`
+	Interval    string   `json:"interval,omitempty"`
+	Timeout     string   `json:"timeout,omitempty"`
+	StartPeriod string   `json:"start_period,omitempty"`
+	// Retries is the number of consecutive failures after which the
+	// container is unhealthy
+	Retries int `json:"retries,omitempty"`
+}
+
+// ValidateHealthCheck checks the test command, durations and retries of a
+// health check
+func ValidateHealthCheck(check HealthCheck) error {
+	if len(check.Test) == 0 {
+		return fmt.Errorf("health check test komutu boş olamaz")
+	}
+	switch check.Test[0] {
+	case "CMD":
+		if len(check.Test) < 2 {
+			return fmt.Errorf("CMD health check'i en az bir komut içermelidir")
+		}
+	case "CMD-SHELL":
+		if len(check.Test) != 2 || check.Test[1] == "" {
+			return fmt.Errorf("CMD-SHELL health check'i tek bir shell komutu içermelidir")
+		}
+	case "NONE":
+		if len(check.Test) != 1 {
+			return fmt.Errorf("NONE health check'i başka değer içeremez")
+		}
+	default:
+		return fmt.Errorf("geçersiz health check testi: %s (CMD, CMD-SHELL veya NONE ile başlamalı)", check.Test[0])
+	}
+
+	for name, value := range map[string]string{
+		"interval":     check.Interval,
+		"timeout":      check.Timeout,
+		"start_period": check.StartPeriod,
+	} {
+		if _, err := parseHealthDuration(value); err != nil {
+			return fmt.Errorf("geçersiz health check %s değeri: %s", name, value)
+		}
+	}
+
+	if check.Retries < 0 {
+		return fmt.Errorf("health check retries negatif olamaz: %d", check.Retries)
+	}
+	return nil
+}
+
+// parseHealthDuration parses a health check duration. Docker rejects
+// durations below a millisecond other than zero, which uses its default.
+func parseHealthDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d != 0 && d < time.Millisecond {
+		return 0, fmt.Errorf("süre en az 1ms olmalıdır: %s", value)
+	}
+	return d, nil
+}
+
+// toDockerHealthcheck converts a validated health check for the container
+// config
+func toDockerHealthcheck(check *HealthCheck) (*container.HealthConfig, error) {
+	if check == nil {
+		return nil, nil
+	}
+	if err := ValidateHealthCheck(*check); err != nil {
+		return nil, err
+	}
+
+	// Durations were validated above
+	interval, _ := parseHealthDuration(check.Interval)
+	timeout, _ := parseHealthDuration(check.Timeout)
+	startPeriod, _ := parseHealthDuration(check.StartPeriod)
+	return &container.HealthConfig{
+		Test:        check.Test,
+		Interval:    interval,
+		Timeout:     timeout,
+		StartPeriod: startPeriod,
+		Retries:     check.Retries,
+	}, nil
+}
+
+// fromDockerHealthcheck converts the health check of a container config
+func fromDockerHealthcheck(config *container.HealthConfig) *HealthCheck {
+	if config == nil || len(config.Test) == 0 {
+		return nil
+	}
+
+	check := &HealthCheck{
+		Test:    config.Test,
+		Retries: config.Retries,
+	}
+	if config.Interval > 0 {
+		check.Interval = config.Interval.String()
+	}
+	if config.Timeout > 0 {
+		check.Timeout = config.Timeout.String()
+	}
+	if config.StartPeriod > 0 {
+		check.StartPeriod = config.StartPeriod.String()
+	}
+	return check
+}
+
+// Ready reports whether the container is running and, if it has a health
+// check, healthy
+func (c *Container) Ready() bool {
+	if c.Status != "running" {
+		return false
+	}
+	return c.Health == "" || c.Health == HealthHealthy
+}
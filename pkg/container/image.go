@@ -0,0 +1,126 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/sirupsen/logrus"
+)
+
+// Image pull policies
+const (
+	// PullAlways pulls the image before every create
+	PullAlways = "always"
+	// PullMissing pulls the image only when it is not present locally
+	PullMissing = "missing"
+	// PullNever never pulls; the image must already be present
+	PullNever = "never"
+)
+
+// ValidatePullPolicy checks that policy is empty or a known pull policy
+func ValidatePullPolicy(policy string) error {
+	switch policy {
+	case "", PullAlways, PullMissing, PullNever:
+		return nil
+	}
+	return fmt.Errorf("geçersiz pull policy: %s (always, missing veya never olmalı)", policy)
+}
+
+// ValidateImageReference checks that image is a reference Docker can pull or
+// find locally, such as nginx, nginx:1.25, ghcr.io/org/app@sha256:<digest>
+// or an image ID, and explains what is wrong with it otherwise
+func ValidateImageReference(image string) error {
+	if strings.IndexFunc(image, unicode.IsSpace) >= 0 {
+		return fmt.Errorf("geçersiz image referansı %q: boşluk içeremez", image)
+	}
+
+	_, err := reference.ParseAnyReference(image)
+	if err == nil {
+		return nil
+	}
+
+	var reason string
+	switch {
+	case errors.Is(err, reference.ErrNameContainsUppercase) || strings.Contains(err.Error(), "must be lowercase"):
+		reason = "depo adı küçük harf olmalıdır (etiket büyük harf içerebilir)"
+	case errors.Is(err, reference.ErrNameTooLong):
+		reason = fmt.Sprintf("depo adı en fazla %d karakter olabilir", reference.NameTotalLengthMax)
+	case errors.Is(err, reference.ErrTagInvalidFormat):
+		reason = "etiket harf, rakam, '_', '.' ve '-' içeren en fazla 128 karakter olmalıdır"
+	case errors.Is(err, reference.ErrDigestInvalidFormat):
+		reason = "digest <algoritma>:<hex> biçiminde olmalıdır, örn. sha256:<64 hex karakter>"
+	case errors.Is(err, reference.ErrNameEmpty):
+		reason = "depo adı boş olamaz"
+	case strings.Contains(image, "::"):
+		reason = "etiket tek ':' ile ayrılır, örn. nginx:latest"
+	case strings.HasSuffix(image, ":"):
+		reason = "etiket boş olamaz"
+	case strings.Contains(image, "@"):
+		reason = "digest <algoritma>:<hex> biçiminde olmalıdır, örn. sha256:<64 hex karakter>"
+	default:
+		reason = "[kayıt[:port]/]ad[:etiket][@digest] biçiminde olmalıdır, örn. nginx:1.25 veya ghcr.io/org/app:v1"
+	}
+	return fmt.Errorf("geçersiz image referansı %q: %s", image, reason)
+}
+
+// ResolveImage makes sure image is present according to policy and returns
+// its digest, the content addressed ID of the local image
+func (m *Manager) ResolveImage(ctx context.Context, image, policy, platform string) (string, error) {
+	if err := m.ensureImage(ctx, image, policy, platform); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	inspect, _, err := m.client.ImageInspectWithRaw(ctx, image)
+	if err != nil {
+		return "", fmt.Errorf("image bilgisi alınamadı (%s): %w", image, err)
+	}
+	return inspect.ID, nil
+}
+
+// ensureImage pulls image for platform according to policy. An empty policy
+// behaves like PullNever and an empty platform means the daemon's native one.
+// A local image built for another platform counts as missing. Pulls are
+// bounded by ctx only, not by the operation timeout.
+func (m *Manager) ensureImage(ctx context.Context, image, policy, platform string) error {
+	switch policy {
+	case PullAlways:
+	case PullMissing:
+		inspect, _, err := m.client.ImageInspectWithRaw(ctx, image)
+		if err == nil && platformMatches(inspect, platform) {
+			return nil
+		}
+		if err != nil && !client.IsErrNotFound(err) {
+			return fmt.Errorf("image bilgisi alınamadı (%s): %w", image, err)
+		}
+	default:
+		return nil
+	}
+
+	m.logger.WithFields(logrus.Fields{
+		"image":    image,
+		"platform": platform,
+	}).Info("Image çekiliyor")
+
+	reader, err := m.client.ImagePull(ctx, image, types.ImagePullOptions{Platform: platform})
+	if err != nil {
+		return fmt.Errorf("image çekilemedi (%s): %w", image, err)
+	}
+	defer reader.Close()
+
+	// The pull only completes once the progress stream is consumed
+	if _, err := io.Copy(io.Discard, reader); err != nil {
+		return fmt.Errorf("image çekilemedi (%s): %w", image, err)
+	}
+
+	return nil
+}
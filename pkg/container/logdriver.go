@@ -0,0 +1,86 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+)
+
+// ErrLogsUnsupported is returned when the logs of a container cannot be read
+// back because its log driver does not keep them locally
+var ErrLogsUnsupported = errors.New("log sürücüsü logların okunmasını desteklemiyor")
+
+// logDrivers are the log drivers shipped with Docker
+var logDrivers = map[string]bool{
+	"none":      true,
+	"local":     true,
+	"json-file": true,
+	"syslog":    true,
+	"journald":  true,
+	"gelf":      true,
+	"fluentd":   true,
+	"awslogs":   true,
+	"splunk":    true,
+	"etwlogs":   true,
+	"gcplogs":   true,
+}
+
+// readableLogDrivers are the log drivers whose logs orca logs can read
+var readableLogDrivers = map[string]bool{
+	"local":     true,
+	"json-file": true,
+	"journald":  true,
+}
+
+// ValidateLogDriver checks that driver is empty or a known log driver. Log
+// options require a driver.
+func ValidateLogDriver(driver string, opts map[string]string) error {
+	if driver == "" {
+		if len(opts) > 0 {
+			return fmt.Errorf("log_opts için log_driver belirtilmelidir")
+		}
+		return nil
+	}
+
+	if !logDrivers[driver] {
+		names := make([]string, 0, len(logDrivers))
+		for name := range logDrivers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("geçersiz log sürücüsü: %s (%s olmalı)", driver, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// checkLogsReadable returns ErrLogsUnsupported when the log driver of an
+// inspected container does not allow reading its logs. An empty driver means
+// the daemon default, which is left to Docker.
+func checkLogsReadable(inspect types.ContainerJSON) error {
+	if inspect.HostConfig == nil {
+		return nil
+	}
+
+	driver := inspect.HostConfig.LogConfig.Type
+	if driver == "" || readableLogDrivers[driver] {
+		return nil
+	}
+	return fmt.Errorf("%w: %s (yalnızca json-file, local ve journald loglanan konteynerlerin logları okunabilir)", ErrLogsUnsupported, driver)
+}
+
+// CheckLogsReadable returns ErrLogsUnsupported when the logs of a container
+// cannot be read because of its log driver
+func (m *Manager) CheckLogsReadable(ctx context.Context, containerID string) error {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	inspect, err := m.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("container bulunamadı: %w", err)
+	}
+	return checkLogsReadable(inspect)
+}
@@ -0,0 +1,231 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/sirupsen/logrus"
+)
+
+// TailAll requests the whole container log instead of the last N lines
+const TailAll = -1
+
+// DefaultMaxLogBytes caps the amount of log data read into memory when no
+// limit is configured
+const DefaultMaxLogBytes = 10 * 1024 * 1024 // 10MB limit
+
+// TruncatedMarker is the line prepended to logs cut at the size limit
+const TruncatedMarker = "[truncated]\n"
+
+// errLogLimitReached is returned by limitedBuffer once it is full
+var errLogLimitReached = errors.New("log buffer limit reached")
+
+// LogOptions controls which container logs are returned
+type LogOptions struct {
+	// Tail is the number of lines from the end of the log, or TailAll
+	Tail int
+	// Grep keeps only the lines matching the expression; applied after Tail
+	Grep *regexp.Regexp
+	// Since only returns logs after a timestamp or relative duration (e.g.
+	// 10m) in a format Docker accepts
+	Since string
+	// Timestamps prefixes every line with its RFC3339Nano timestamp
+	Timestamps bool
+	// MaxBytes lowers the size limit of the returned logs; zero or a value
+	// above the configured maximum uses the maximum
+	MaxBytes int
+}
+
+// LogResult holds container logs
+type LogResult struct {
+	Logs string
+	// Truncated is set when the log exceeded the buffer size limit
+	Truncated bool
+}
+
+// SetMaxLogBytes sets how much log data a single request may read into
+// memory
+func (m *Manager) SetMaxLogBytes(n int) {
+	m.maxLogBytes = n
+}
+
+// logLimit returns the size limit of a log request
+func (m *Manager) logLimit(opts LogOptions) int {
+	limit := m.maxLogBytes
+	if limit <= 0 {
+		limit = DefaultMaxLogBytes
+	}
+	if opts.MaxBytes > 0 && opts.MaxBytes < limit {
+		limit = opts.MaxBytes
+	}
+	return limit
+}
+
+// Logs gets container logs with default tail of 100 lines
+func (m *Manager) Logs(ctx context.Context, containerID string) (string, error) {
+	return m.LogsWithTail(ctx, containerID, 100)
+}
+
+// LogsWithTail gets container logs with specified tail count
+func (m *Manager) LogsWithTail(ctx context.Context, containerID string, tail int) (string, error) {
+	result, err := m.LogsWithOptions(ctx, containerID, LogOptions{Tail: tail})
+	if err != nil {
+		return "", err
+	}
+	return result.Logs, nil
+}
+
+// LogsWithOptions gets demultiplexed container logs according to opts
+func (m *Manager) LogsWithOptions(ctx context.Context, containerID string, opts LogOptions) (*LogResult, error) {
+	// Limit tail to prevent excessive memory usage
+	const maxTail = 10000
+	tail := fmt.Sprintf("%d", opts.Tail)
+	switch {
+	case opts.Tail == TailAll:
+		tail = "all"
+	case opts.Tail <= 0:
+		tail = "100"
+	case opts.Tail > maxTail:
+		tail = fmt.Sprintf("%d", maxTail)
+	}
+
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	// TTY containers produce a raw stream, others a multiplexed one
+	inspect, err := m.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("container bulunamadı: %w", err)
+	}
+	if err := checkLogsReadable(inspect); err != nil {
+		return nil, err
+	}
+
+	options := types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       tail,
+		Since:      opts.Since,
+		Timestamps: opts.Timestamps,
+	}
+
+	reader, err := m.client.ContainerLogs(ctx, containerID, options)
+	if err != nil {
+		return nil, fmt.Errorf("container logları alınamadı: %w", err)
+	}
+	defer reader.Close()
+
+	// Use limited buffer to prevent memory issues
+	limit := m.logLimit(opts)
+	buf := &limitedBuffer{limit: limit}
+	if inspect.Config != nil && inspect.Config.Tty {
+		_, err = io.Copy(buf, reader)
+	} else {
+		_, err = stdcopy.StdCopy(buf, buf, reader)
+	}
+	if err != nil && !errors.Is(err, errLogLimitReached) {
+		return nil, fmt.Errorf("loglar okunamadı: %w", err)
+	}
+
+	result := &LogResult{
+		Logs:      buf.String(),
+		Truncated: buf.truncated,
+	}
+
+	if opts.Grep != nil {
+		result.Logs = filterLines(result.Logs, opts.Grep)
+	}
+
+	if result.Truncated {
+		m.logger.WithFields(logrus.Fields{
+			"container_id": containerID,
+			"limit_bytes":  limit,
+		}).Warn("Container logları boyut sınırında kesildi")
+		result.Logs = TruncatedMarker + result.Logs
+	}
+
+	return result, nil
+}
+
+// FollowLogs streams demultiplexed container logs to w until the container
+// stops or ctx is cancelled. The operation timeout does not apply.
+func (m *Manager) FollowLogs(ctx context.Context, containerID string, tail int, w io.Writer) error {
+	tailStr := "all"
+	if tail >= 0 {
+		tailStr = fmt.Sprintf("%d", tail)
+	}
+
+	inspect, err := m.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("container bulunamadı: %w", err)
+	}
+	if err := checkLogsReadable(inspect); err != nil {
+		return err
+	}
+
+	reader, err := m.client.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Tail:       tailStr,
+	})
+	if err != nil {
+		return fmt.Errorf("container logları alınamadı: %w", err)
+	}
+	defer reader.Close()
+
+	if inspect.Config != nil && inspect.Config.Tty {
+		_, err = io.Copy(w, reader)
+	} else {
+		_, err = stdcopy.StdCopy(w, w, reader)
+	}
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("loglar okunamadı: %w", err)
+	}
+
+	return nil
+}
+
+// filterLines keeps only the lines of logs matching re
+func filterLines(logs string, re *regexp.Regexp) string {
+	var out strings.Builder
+	for _, line := range strings.SplitAfter(logs, "\n") {
+		if line == "" {
+			continue
+		}
+		if re.MatchString(strings.TrimRight(line, "\r\n")) {
+			out.WriteString(line)
+		}
+	}
+	return out.String()
+}
+
+// limitedBuffer is a buffer that stops accepting data past a size limit
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+// Write appends p to the buffer until the limit is reached
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	remaining := b.limit - b.buf.Len()
+	if len(p) > remaining {
+		b.buf.Write(p[:remaining])
+		b.truncated = true
+		return remaining, errLogLimitReached
+	}
+	return b.buf.Write(p)
+}
+
+// String returns the buffered data
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
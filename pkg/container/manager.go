@@ -2,14 +2,21 @@ package container
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
+	"os"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	"orca/pkg/config"
+
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/docker/go-connections/nat"
@@ -18,42 +25,191 @@ import (
 
 // Manager handles container operations
 type Manager struct {
-	client *client.Client
-	logger *logrus.Logger
+	client         *client.Client
+	logger         *logrus.Logger
+	defaultNetwork string
+	defaultSubnet  string
+	opTimeout      time.Duration
+	networkMutex   sync.Mutex
+	networkReady   bool
+	secrets        SecretStore
+	secretsDir     string
+	maxLogBytes    int
+	namePrefix     string
 }
 
 // NewManager creates a new container manager
-func NewManager(logger *logrus.Logger) (*Manager, error) {
+func NewManager(cfg config.DockerConfig, logger *logrus.Logger) (*Manager, error) {
 	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
 	if err != nil {
 		return nil, fmt.Errorf("docker client oluşturulamadı: %w", err)
 	}
 
-	return &Manager{
-		client: cli,
-		logger: logger,
-	}, nil
+	// Ping the daemon so permission problems surface at startup instead of
+	// on the first container operation
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := cli.Ping(ctx); err != nil {
+		if isPermissionError(err) {
+			return nil, fmt.Errorf("docker soketine erişim izni yok (%s): kullanıcınızı 'docker' grubuna ekleyin (sudo usermod -aG docker $USER) veya DOCKER_HOST ile erişilebilir bir daemon belirtin: %w", cli.DaemonHost(), err)
+		}
+		logger.WithError(err).WithField("docker_host", cli.DaemonHost()).Warn("Docker daemon'a ulaşılamadı, container işlemleri başarısız olabilir")
+	}
+
+	m := &Manager{
+		client:         cli,
+		logger:         logger,
+		defaultNetwork: cfg.DefaultNetwork,
+		defaultSubnet:  cfg.DefaultSubnet,
+		opTimeout:      cfg.OpTimeout,
+		namePrefix:     cfg.NamePrefix,
+		secretsDir:     cfg.SecretsDir,
+	}
+
+	if m.defaultNetwork != "" {
+		if err := m.ensureDefaultNetwork(ctx); err != nil {
+			logger.WithError(err).WithField("network", m.defaultNetwork).Warn("Varsayılan ağ hazırlanamadı, ilk container oluşturulurken tekrar denenecek")
+		}
+	}
+
+	return m, nil
+}
+
+// withTimeout bounds a single Docker operation by the configured operation
+// timeout, independently of the caller's deadline
+func (m *Manager) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if m.opTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, m.opTimeout)
+}
+
+// ensureDefaultNetwork creates the configured default bridge network if it
+// does not exist yet
+func (m *Manager) ensureDefaultNetwork(ctx context.Context) error {
+	m.networkMutex.Lock()
+	defer m.networkMutex.Unlock()
+
+	if m.networkReady {
+		return nil
+	}
+
+	_, err := m.client.NetworkInspect(ctx, m.defaultNetwork, types.NetworkInspectOptions{})
+	if err == nil {
+		m.networkReady = true
+		return nil
+	}
+	if !client.IsErrNotFound(err) {
+		return fmt.Errorf("ağ bilgisi alınamadı (%s): %w", m.defaultNetwork, err)
+	}
+
+	options := types.NetworkCreate{
+		CheckDuplicate: true,
+		Driver:         "bridge",
+		Labels:         map[string]string{ManagedLabel: "true"},
+	}
+	if m.defaultSubnet != "" {
+		options.IPAM = &network.IPAM{
+			Config: []network.IPAMConfig{{Subnet: m.defaultSubnet}},
+		}
+	}
+
+	if _, err := m.client.NetworkCreate(ctx, m.defaultNetwork, options); err != nil {
+		return fmt.Errorf("ağ oluşturulamadı (%s): %w", m.defaultNetwork, err)
+	}
+
+	m.logger.WithFields(logrus.Fields{
+		"network": m.defaultNetwork,
+		"subnet":  m.defaultSubnet,
+	}).Info("Varsayılan ağ oluşturuldu")
+
+	m.networkReady = true
+	return nil
+}
+
+// isPermissionError reports whether err is caused by a denied socket access
+func isPermissionError(err error) bool {
+	if errors.Is(err, syscall.EACCES) || errors.Is(err, os.ErrPermission) {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "permission denied")
 }
 
 // Create creates a new container from spec
 func (m *Manager) Create(ctx context.Context, spec ContainerSpec) (*Container, error) {
+	if err := m.ensureImage(ctx, spec.Image, spec.PullPolicy, spec.Platform); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	// Stamp the namespace so the container is only visible within it
+	namespace := NormalizeNamespace(spec.Namespace)
+	labels := make(map[string]string, len(spec.Labels)+2)
+	for key, value := range spec.Labels {
+		labels[key] = value
+	}
+	labels[NamespaceLabel] = namespace
+	labels[ManagedLabel] = "true"
+	delete(labels, secretDirLabel)
+
+	// Load secrets up front so a missing one fails before anything is
+	// created. Their files are deleted again unless the container is.
+	var secretDir string
+	var secretMounts []mount.Mount
+	if len(spec.Secrets) > 0 {
+		dir, mounts, err := m.writeSecretFiles(namespace, spec.Secrets)
+		if err != nil {
+			return nil, err
+		}
+		secretDir, secretMounts = dir, mounts
+		defer func() {
+			if secretDir != "" {
+				os.RemoveAll(secretDir)
+			}
+		}()
+		labels[secretsLabel] = encodeSecretMounts(spec.Secrets)
+		labels[secretDirLabel] = dir
+	}
+
+	fields := logrus.Fields{
+		"name":  spec.Name,
+		"image": spec.Image,
+	}
+	if namespace != DefaultNamespace {
+		fields["namespace"] = namespace
+	}
+	if spec.Platform != "" {
+		fields["platform"] = spec.Platform
+	}
+	if deployment := spec.Labels[DeploymentLabel]; deployment != "" {
+		fields["deployment"] = deployment
+	}
+
+	// Ports cannot be published without a network of the container's own
+	var warnings []string
+	ports := spec.Ports
+	if IsolatedNetworkMode(spec.NetworkMode) && len(ports) > 0 {
+		warning := fmt.Sprintf("%s ağ modunda port yönlendirmeleri yok sayıldı", spec.NetworkMode)
+		m.logger.WithFields(fields).Warn(warning)
+		warnings = append(warnings, warning)
+		ports = nil
+	}
+
 	// Port bindings
 	portBindings := nat.PortMap{}
 	exposedPorts := nat.PortSet{}
 
-	for containerPort, hostPort := range spec.Ports {
-		// Parse container port (remove protocol if present)
-		portStr := containerPort
-		protocol := "tcp"
-		if strings.Contains(containerPort, "/") {
-			parts := strings.Split(containerPort, "/")
-			if len(parts) == 2 {
-				portStr = parts[0]
-				protocol = parts[1]
-			}
+	for containerPort, hostPort := range ports {
+		// Parse container port (protocol defaults to tcp)
+		portNum, protocol, err := ParsePortKey(containerPort)
+		if err != nil {
+			return nil, err
 		}
 
-		port, err := nat.NewPort(protocol, portStr)
+		port, err := nat.NewPort(protocol, strconv.Itoa(portNum))
 		if err != nil {
 			return nil, fmt.Errorf("geçersiz port: %s", containerPort)
 		}
@@ -77,136 +233,346 @@ func (m *Manager) Create(ctx context.Context, spec ContainerSpec) (*Container, e
 	config := &container.Config{
 		Image:        spec.Image,
 		Env:          env,
-		Labels:       spec.Labels,
+		Labels:       labels,
 		ExposedPorts: exposedPorts,
 		WorkingDir:   spec.WorkingDir,
+		StopSignal:   spec.StopSignal,
 	}
 
 	if len(spec.Command) > 0 {
 		config.Cmd = spec.Command
 	}
 
+	healthcheck, err := toDockerHealthcheck(spec.HealthCheck)
+	if err != nil {
+		return nil, err
+	}
+	config.Healthcheck = healthcheck
+
+	restartPolicy, err := ParseRestartPolicy(spec.RestartPolicy)
+	if err != nil {
+		return nil, err
+	}
+
 	// Host config
 	hostConfig := &container.HostConfig{
-		PortBindings: portBindings,
+		PortBindings:  portBindings,
+		RestartPolicy: restartPolicy,
+		AutoRemove:    spec.AutoRemove,
+		LogConfig: container.LogConfig{
+			Type:   spec.LogDriver,
+			Config: spec.LogOpts,
+		},
+	}
+
+	if len(spec.Volumes) > 0 {
+		mounts, err := toDockerMounts(spec.Volumes)
+		if err != nil {
+			return nil, err
+		}
+		hostConfig.Mounts = mounts
 	}
+	hostConfig.Mounts = append(hostConfig.Mounts, secretMounts...)
 
-	// Network config
+	if spec.Resources != nil {
+		resources, err := toDockerResources(*spec.Resources)
+		if err != nil {
+			return nil, err
+		}
+		hostConfig.Resources = resources
+	}
+	hostConfig.Ulimits = toDockerUlimits(spec.Ulimits)
+	hostConfig.DeviceRequests = toDeviceRequests(spec.GPUs)
+	if len(spec.Devices) > 0 {
+		devices, err := toDeviceMappings(spec.Devices)
+		if err != nil {
+			return nil, err
+		}
+		hostConfig.Devices = devices
+	}
+
+	// Network config; the spec overrides the configured default network
 	networkConfig := &network.NetworkingConfig{}
+	networkName := spec.Network
+	if IsolatedNetworkMode(spec.NetworkMode) {
+		hostConfig.NetworkMode = container.NetworkMode(spec.NetworkMode)
+	} else if networkName == "" && m.defaultNetwork != "" {
+		if err := m.ensureDefaultNetwork(ctx); err != nil {
+			return nil, err
+		}
+		networkName = m.defaultNetwork
+	}
+	if networkName != "" {
+		hostConfig.NetworkMode = container.NetworkMode(networkName)
+		networkConfig.EndpointsConfig = map[string]*network.EndpointSettings{
+			networkName: {},
+		}
+	}
 
 	// Create container
-	resp, err := m.client.ContainerCreate(ctx, config, hostConfig, networkConfig, nil, spec.Name)
+	resp, err := m.client.ContainerCreate(ctx, config, hostConfig, networkConfig, parsePlatform(spec.Platform), m.DockerName(namespace, spec.Name))
 	if err != nil {
+		m.logger.WithFields(fields).WithError(err).Error("Container oluşturulamadı")
 		return nil, fmt.Errorf("docker container oluşturulamadı: %w", err)
 	}
 
-	m.logger.WithFields(logrus.Fields{
-		"container_id": resp.ID,
-		"name":         spec.Name,
-		"image":        spec.Image,
-	}).Info("Container oluşturuldu")
+	secretDir = ""
+
+	m.logger.WithFields(fields).WithField("container_id", resp.ID).Info("Container oluşturuldu")
+
+	// Docker reports settings it could only partially honor as warnings
+	for _, warning := range resp.Warnings {
+		m.logger.WithFields(fields).WithField("container_id", resp.ID).Warn(warning)
+	}
+	warnings = append(warnings, resp.Warnings...)
 
 	return &Container{
-		ID:          resp.ID,
-		Name:        spec.Name,
-		Image:       spec.Image,
-		Status:      "created",
-		Ports:       spec.Ports,
-		Environment: spec.Environment,
-		Labels:      spec.Labels,
-		Created:     time.Now(),
+		ID:            resp.ID,
+		Name:          spec.Name,
+		Namespace:     namespace,
+		Image:         spec.Image,
+		Status:        "created",
+		Ports:         ports,
+		Environment:   spec.Environment,
+		Labels:        labels,
+		Created:       time.Now(),
+		Resources:     spec.Resources,
+		WorkingDir:    spec.WorkingDir,
+		RestartPolicy: formatRestartPolicy(restartPolicy),
+		LogDriver:     spec.LogDriver,
+		AutoRemove:    spec.AutoRemove,
+		NetworkMode:   spec.NetworkMode,
+		Platform:      spec.Platform,
+		GPUs:          spec.GPUs,
+		Devices:       spec.Devices,
+		Secrets:       spec.Secrets,
+		Ulimits:       spec.Ulimits,
+		HealthCheck:   spec.HealthCheck,
+		Warnings:      warnings,
 	}, nil
 }
 
-// Start starts a container
-func (m *Manager) Start(ctx context.Context, containerID string) error {
+// Start starts a container. It is logged with the name, image and deployment
+// c already carries, so no inspect is needed.
+func (m *Manager) Start(ctx context.Context, c *Container) error {
+	return m.start(ctx, c.ID, containerLogFields(c))
+}
+
+// start starts a container, logging it with fields
+func (m *Manager) start(ctx context.Context, containerID string, fields logrus.Fields) error {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
 	err := m.client.ContainerStart(ctx, containerID, types.ContainerStartOptions{})
 	if err != nil {
+		m.logger.WithFields(fields).WithError(err).Error("Container başlatılamadı")
 		return fmt.Errorf("container başlatılamadı: %w", err)
 	}
 
-	m.logger.WithField("container_id", containerID).Info("Container başlatıldı")
+	m.logger.WithFields(fields).Info("Container başlatıldı")
 	return nil
 }
 
-// Stop stops a container
-func (m *Manager) Stop(ctx context.Context, containerID string) error {
+// Stop stops a container, logging it like Start
+func (m *Manager) Stop(ctx context.Context, c *Container) error {
+	return m.stop(ctx, c.ID, containerLogFields(c))
+}
+
+// stop stops a container, logging it with fields
+func (m *Manager) stop(ctx context.Context, containerID string, fields logrus.Fields) error {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
 	timeout := 30
 	err := m.client.ContainerStop(ctx, containerID, container.StopOptions{
 		Timeout: &timeout,
 	})
 	if err != nil {
+		m.logger.WithFields(fields).WithError(err).Warn("Container durdurulamadı")
 		return fmt.Errorf("container durdurulamadı: %w", err)
 	}
 
-	m.logger.WithField("container_id", containerID).Info("Container durduruldu")
+	m.logger.WithFields(fields).Info("Container durduruldu")
 	return nil
 }
 
 // Remove removes a container
 func (m *Manager) Remove(ctx context.Context, containerID string) error {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	// The inspect needed to find its secret files also describes it in the
+	// logs
+	fields := logrus.Fields{"container_id": containerID}
+	var labels map[string]string
+	if inspect, err := m.client.ContainerInspect(ctx, containerID); err == nil {
+		fields = inspectFields(inspect)
+		if inspect.Config != nil {
+			labels = inspect.Config.Labels
+		}
+	}
+
 	err := m.client.ContainerRemove(ctx, containerID, types.ContainerRemoveOptions{
 		Force: true,
 	})
 	if err != nil {
+		m.logger.WithFields(fields).WithError(err).Warn("Container silinemedi")
 		return fmt.Errorf("container silinemedi: %w", err)
 	}
+	m.removeSecretFiles(labels)
 
-	m.logger.WithField("container_id", containerID).Info("Container silindi")
+	m.logger.WithFields(fields).Info("Container silindi")
 	return nil
 }
 
 // List lists all containers
 func (m *Manager) List(ctx context.Context) ([]*Container, error) {
-	containers, err := m.client.ContainerList(ctx, types.ContainerListOptions{All: true})
+	return m.ListByLabels(ctx, nil)
+}
+
+// ListByLabels lists the containers carrying every key/value in labels. An
+// empty labels map lists all containers.
+func (m *Manager) ListByLabels(ctx context.Context, labels map[string]string) ([]*Container, error) {
+	return m.ListWithOptions(ctx, ListOptions{Labels: labels})
+}
+
+// ListOptions selects the containers returned by ListWithOptions
+type ListOptions struct {
+	// Namespace limits the list to one namespace; empty lists all of them
+	Namespace string
+	// Labels every listed container must carry
+	Labels map[string]string
+	// Size computes the filesystem usage of each container, which is slow
+	Size bool
+}
+
+// ListWithOptions lists containers according to opts
+func (m *Manager) ListWithOptions(ctx context.Context, opts ListOptions) ([]*Container, error) {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	labelFilters := filters.NewArgs()
+	for key, value := range opts.Labels {
+		labelFilters.Add("label", fmt.Sprintf("%s=%s", key, value))
+	}
+
+	containers, err := m.client.ContainerList(ctx, types.ContainerListOptions{
+		All:     true,
+		Size:    opts.Size,
+		Filters: labelFilters,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("container listesi alınamadı: %w", err)
 	}
 
 	result := make([]*Container, 0, len(containers))
 	for _, c := range containers {
-		name := ""
-		if len(c.Names) > 0 {
-			name = strings.TrimPrefix(c.Names[0], "/")
+		// Containers of the default namespace may predate the label, so the
+		// namespace is filtered here rather than by Docker
+		namespace := NamespaceOf(c.Labels)
+		if opts.Namespace != "" && namespace != opts.Namespace {
+			continue
 		}
 
-		ports := make(map[string]string)
-		for _, port := range c.Ports {
-			if port.PublicPort > 0 {
-				containerPort := strconv.Itoa(int(port.PrivatePort))
-				hostPort := strconv.Itoa(int(port.PublicPort))
-				ports[containerPort] = hostPort
-			}
+		converted := m.containerFromSummary(c, namespace)
+		if converted.Error != "" {
+			m.logger.WithFields(logrus.Fields{
+				"container_id": c.ID,
+				"error":        converted.Error,
+			}).Warn("Container bilgileri eksik okundu")
 		}
-
-		result = append(result, &Container{
-			ID:      c.ID,
-			Name:    name,
-			Image:   c.Image,
-			Status:  c.Status,
-			Ports:   ports,
-			Labels:  c.Labels,
-			Created: time.Unix(c.Created, 0),
-		})
+		result = append(result, converted)
 	}
 
 	return result, nil
 }
 
+// containerFromSummary converts a container returned by Docker's list API.
+// Fields that cannot be read are left empty and described in Error, so a
+// container with malformed data is still listed.
+func (m *Manager) containerFromSummary(c types.Container, namespace string) *Container {
+	var problems []string
+
+	name := ""
+	if len(c.Names) > 0 {
+		name = m.containerName(namespace, c.Names[0])
+	}
+	if name == "" {
+		problems = append(problems, "container adı okunamadı")
+		if len(c.ID) >= 12 {
+			name = c.ID[:12]
+		} else {
+			name = c.ID
+		}
+	}
+
+	ports := make(map[string]string)
+	for _, port := range c.Ports {
+		if port.PublicPort == 0 {
+			continue
+		}
+		if port.PrivatePort == 0 {
+			problems = append(problems, fmt.Sprintf("%d host portunun container portu okunamadı", port.PublicPort))
+			continue
+		}
+		protocol := port.Type
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		// Keyed like normalized spec ports
+		containerPort := fmt.Sprintf("%d/%s", port.PrivatePort, protocol)
+		hostPort := strconv.Itoa(int(port.PublicPort))
+		ports[containerPort] = hostPort
+	}
+
+	if c.Image == "" {
+		problems = append(problems, "image bilgisi okunamadı")
+	}
+	if c.Status == "" {
+		problems = append(problems, "durum bilgisi okunamadı")
+	}
+
+	var created time.Time
+	if c.Created > 0 {
+		created = time.Unix(c.Created, 0)
+	} else {
+		problems = append(problems, "oluşturulma zamanı okunamadı")
+	}
+
+	return &Container{
+		ID:         c.ID,
+		Name:       name,
+		Namespace:  namespace,
+		Image:      c.Image,
+		Status:     c.Status,
+		Ports:      ports,
+		Labels:     c.Labels,
+		Created:    created,
+		SizeRw:     c.SizeRw,
+		SizeRootFs: c.SizeRootFs,
+		Error:      strings.Join(problems, "; "),
+	}
+}
+
 // Get gets a container by ID
 func (m *Manager) Get(ctx context.Context, containerID string) (*Container, error) {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
 	inspect, err := m.client.ContainerInspect(ctx, containerID)
 	if err != nil {
 		return nil, fmt.Errorf("container bulunamadı: %w", err)
 	}
 
-	name := strings.TrimPrefix(inspect.Name, "/")
+	namespace := NamespaceOf(inspect.Config.Labels)
+	name := m.containerName(namespace, inspect.Name)
 
 	ports := make(map[string]string)
 	if inspect.NetworkSettings != nil && inspect.NetworkSettings.Ports != nil {
 		for port, bindings := range inspect.NetworkSettings.Ports {
 			if len(bindings) > 0 {
-				containerPort := port.Port()
+				// "port/proto", like normalized spec ports
+				containerPort := string(port)
 				hostPort := bindings[0].HostPort
 				ports[containerPort] = hostPort
 			}
@@ -222,57 +588,117 @@ func (m *Manager) Get(ctx context.Context, containerID string) (*Container, erro
 		}
 	}
 
+	var resources *Resources
+	restartPolicy := ""
+	logDriver := ""
+	autoRemove := false
+	networkMode := ""
+	gpus := ""
+	var devices []string
+	var ulimits []Ulimit
+	health := ""
+	if inspect.State.Health != nil {
+		health = inspect.State.Health.Status
+	}
+	if inspect.HostConfig != nil {
+		resources = fromDockerResources(inspect.HostConfig.Resources)
+		restartPolicy = formatRestartPolicy(inspect.HostConfig.RestartPolicy)
+		logDriver = inspect.HostConfig.LogConfig.Type
+		autoRemove = inspect.HostConfig.AutoRemove
+		networkMode = string(inspect.HostConfig.NetworkMode)
+		gpus = fromDeviceRequests(inspect.HostConfig.DeviceRequests)
+		devices = fromDeviceMappings(inspect.HostConfig.Devices)
+		ulimits = fromDockerUlimits(inspect.HostConfig.Ulimits)
+	}
+
 	return &Container{
-		ID:          inspect.ID,
-		Name:        name,
-		Image:       inspect.Config.Image,
-		Status:      inspect.State.Status,
-		Ports:       ports,
-		Environment: parseEnvVars(inspect.Config.Env),
-		Labels:      inspect.Config.Labels,
-		Created:     created,
-		Started:     started,
+		ID:            inspect.ID,
+		Name:          name,
+		Namespace:     namespace,
+		Image:         inspect.Config.Image,
+		ImageID:       inspect.Image,
+		Status:        inspect.State.Status,
+		Ports:         ports,
+		Environment:   parseEnvVars(inspect.Config.Env),
+		Labels:        inspect.Config.Labels,
+		Created:       created,
+		Started:       started,
+		RestartCount:  inspect.RestartCount,
+		Resources:     resources,
+		WorkingDir:    inspect.Config.WorkingDir,
+		RestartPolicy: restartPolicy,
+		LogDriver:     logDriver,
+		AutoRemove:    autoRemove,
+		NetworkMode:   networkMode,
+		GPUs:          gpus,
+		Devices:       devices,
+		Secrets:       decodeSecretMounts(inspect.Config.Labels),
+		StopSignal:    inspect.Config.StopSignal,
+		Ulimits:       ulimits,
+		HealthCheck:   fromDockerHealthcheck(inspect.Config.Healthcheck),
+		Health:        health,
 	}, nil
 }
 
-// Logs gets container logs with default tail of 100 lines
-func (m *Manager) Logs(ctx context.Context, containerID string) (string, error) {
-	return m.LogsWithTail(ctx, containerID, 100)
-}
+// DockerVersion returns the version and API version of the Docker daemon
+func (m *Manager) DockerVersion(ctx context.Context) (string, string, error) {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
 
-// LogsWithTail gets container logs with specified tail count
-func (m *Manager) LogsWithTail(ctx context.Context, containerID string, tail int) (string, error) {
-	// Limit tail to prevent excessive memory usage
-	const maxTail = 10000
-	if tail <= 0 {
-		tail = 100
-	}
-	if tail > maxTail {
-		tail = maxTail
+	version, err := m.client.ServerVersion(ctx)
+	if err != nil {
+		return "", "", fmt.Errorf("docker sürümü alınamadı: %w", err)
 	}
+	return version.Version, version.APIVersion, nil
+}
 
-	options := types.ContainerLogsOptions{
-		ShowStdout: true,
-		ShowStderr: true,
-		Tail:       fmt.Sprintf("%d", tail),
-	}
+// Changes lists filesystem changes of a container relative to its image
+func (m *Manager) Changes(ctx context.Context, containerID string) ([]FilesystemChange, error) {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
 
-	reader, err := m.client.ContainerLogs(ctx, containerID, options)
+	changes, err := m.client.ContainerDiff(ctx, containerID)
 	if err != nil {
-		return "", fmt.Errorf("container logları alınamadı: %w", err)
+		return nil, fmt.Errorf("container değişiklikleri alınamadı: %w", err)
 	}
-	defer reader.Close()
 
-	// Use limited buffer to prevent memory issues
-	const maxBufferSize = 10 * 1024 * 1024 // 10MB limit
-	limitedReader := io.LimitReader(reader, maxBufferSize)
-	
-	logs, err := io.ReadAll(limitedReader)
-	if err != nil {
-		return "", fmt.Errorf("loglar okunamadı: %w", err)
+	result := make([]FilesystemChange, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, FilesystemChange{
+			Kind: change.Kind.String(),
+			Path: change.Path,
+		})
 	}
 
-	return string(logs), nil
+	return result, nil
+}
+
+// containerLogFields returns log fields identifying a container: its ID,
+// name, image and owning deployment
+func containerLogFields(c *Container) logrus.Fields {
+	fields := logrus.Fields{
+		"container_id": c.ID,
+		"name":         c.Name,
+		"image":        c.Image,
+	}
+	if deployment := c.Labels[DeploymentLabel]; deployment != "" {
+		fields["deployment"] = deployment
+	}
+	return fields
+}
+
+// inspectFields returns log fields identifying an inspected container: its
+// ID, name, image and owning deployment
+func inspectFields(inspect types.ContainerJSON) logrus.Fields {
+	fields := logrus.Fields{"container_id": inspect.ID}
+	fields["name"] = strings.TrimPrefix(inspect.Name, "/")
+	if inspect.Config != nil {
+		fields["image"] = inspect.Config.Image
+		if deployment := inspect.Config.Labels[DeploymentLabel]; deployment != "" {
+			fields["deployment"] = deployment
+		}
+	}
+	return fields
 }
 
 // parseEnvVars parses environment variables from Docker format
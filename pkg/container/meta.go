@@ -0,0 +1,52 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// APIVersion is the current spec format version
+const APIVersion = "orca/v1"
+
+// Spec kinds
+const (
+	KindContainer  = "Container"
+	KindDeployment = "Deployment"
+	KindService    = "Service"
+)
+
+// TypeMeta identifies the format version and kind of a spec. Both fields
+// are optional; an absent version means the current one.
+type TypeMeta struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+}
+
+// ValidateTypeMeta checks that a spec's version is supported and its kind,
+// if given, matches the expected kind
+func ValidateTypeMeta(meta TypeMeta, kind string) error {
+	if meta.APIVersion != "" && meta.APIVersion != APIVersion {
+		return fmt.Errorf("desteklenmeyen apiVersion: %s (desteklenen: %s)", meta.APIVersion, APIVersion)
+	}
+	if meta.Kind != "" && meta.Kind != kind {
+		return fmt.Errorf("geçersiz kind: %s (beklenen: %s)", meta.Kind, kind)
+	}
+	return nil
+}
+
+// DecodeSpec decodes a JSON spec from r into v, rejecting fields the spec
+// does not define
+func DecodeSpec(r io.Reader, v interface{}) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return fmt.Errorf("bilinmeyen alan: %s", field)
+		}
+		return fmt.Errorf("geçersiz JSON formatı: %w", err)
+	}
+	return nil
+}
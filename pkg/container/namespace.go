@@ -0,0 +1,69 @@
+package container
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// NamespaceLabel is the container label naming the namespace a container
+// belongs to
+const NamespaceLabel = "orca.namespace"
+
+// DefaultNamespace holds resources created without a namespace, including
+// those created before namespaces existed
+const DefaultNamespace = "default"
+
+// namespacePattern allows lowercase DNS labels, so a namespace never
+// contains the separator of qualified container names
+var namespacePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// ValidateNamespace checks that namespace is empty or a lowercase DNS label
+func ValidateNamespace(namespace string) error {
+	if namespace == "" || namespacePattern.MatchString(namespace) {
+		return nil
+	}
+	return fmt.Errorf("geçersiz namespace: %s (küçük harf, rakam ve '-' içeren en fazla 63 karakter olmalı)", namespace)
+}
+
+// NormalizeNamespace returns namespace, or the default namespace if it is empty
+func NormalizeNamespace(namespace string) string {
+	if namespace == "" {
+		return DefaultNamespace
+	}
+	return namespace
+}
+
+// NamespaceOf returns the namespace recorded in container labels. Containers
+// without the label belong to the default namespace.
+func NamespaceOf(labels map[string]string) string {
+	return NormalizeNamespace(labels[NamespaceLabel])
+}
+
+// QualifiedName returns the Docker name of a container. Docker names are
+// unique per host, so containers outside the default namespace are prefixed
+// with their namespace; default namespace names are kept as they are.
+func QualifiedName(namespace, name string) string {
+	namespace = NormalizeNamespace(namespace)
+	if namespace == DefaultNamespace {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// DockerName returns the Docker name of a container: its qualified name
+// behind the configured name prefix
+func (m *Manager) DockerName(namespace, name string) string {
+	return m.namePrefix + QualifiedName(namespace, name)
+}
+
+// containerName strips the leading slash, the name prefix and the namespace
+// prefix from a Docker container name. Containers created without the prefix
+// keep their name.
+func (m *Manager) containerName(namespace, dockerName string) string {
+	name := strings.TrimPrefix(strings.TrimPrefix(dockerName, "/"), m.namePrefix)
+	if namespace == DefaultNamespace {
+		return name
+	}
+	return strings.TrimPrefix(name, namespace+".")
+}
@@ -0,0 +1,43 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Network modes accepted in ContainerSpec.NetworkMode besides container:<name>
+const (
+	NetworkModeBridge = "bridge"
+	NetworkModeHost   = "host"
+	NetworkModeNone   = "none"
+)
+
+// networkModeContainerPrefix selects sharing the network of another container
+const networkModeContainerPrefix = "container:"
+
+// ValidateNetworkMode checks that mode is empty, bridge, host, none or
+// container:<name>. Modes other than bridge cannot be combined with a network.
+func ValidateNetworkMode(mode, network string) error {
+	switch {
+	case mode == "" || mode == NetworkModeBridge:
+		return nil
+	case mode == NetworkModeHost || mode == NetworkModeNone:
+	case strings.HasPrefix(mode, networkModeContainerPrefix):
+		if strings.TrimPrefix(mode, networkModeContainerPrefix) == "" {
+			return fmt.Errorf("container ağ modu için konteyner adı belirtilmelidir: %s", mode)
+		}
+	default:
+		return fmt.Errorf("geçersiz ağ modu: %s (bridge, host, none veya container:<ad> olmalı)", mode)
+	}
+
+	if network != "" {
+		return fmt.Errorf("%s ağ modu network ile birlikte kullanılamaz", mode)
+	}
+	return nil
+}
+
+// IsolatedNetworkMode reports whether mode gives the container no network of
+// its own to publish ports from
+func IsolatedNetworkMode(mode string) bool {
+	return mode != "" && mode != NetworkModeBridge
+}
@@ -0,0 +1,130 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+)
+
+// ErrNetworkNotFound is returned when a Docker network does not exist
+var ErrNetworkNotFound = errors.New("ağ bulunamadı")
+
+// Network is a Docker network with its address ranges and the containers
+// connected to it. Networks are shared by all namespaces.
+type Network struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Driver   string            `json:"driver"`
+	Scope    string            `json:"scope"`
+	Internal bool              `json:"internal,omitempty"`
+	Managed  bool              `json:"managed"`
+	Subnets  []NetworkSubnet   `json:"subnets,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Created  time.Time         `json:"created"`
+	// Containers are sorted by name
+	Containers []NetworkContainer `json:"containers"`
+}
+
+// NetworkSubnet is an address range of a network and its gateway
+type NetworkSubnet struct {
+	Subnet  string `json:"subnet"`
+	Gateway string `json:"gateway,omitempty"`
+}
+
+// NetworkContainer is a container connected to a network. Name is the
+// Docker name, prefixed with the namespace outside the default namespace.
+type NetworkContainer struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	IPv4Address string `json:"ipv4_address,omitempty"`
+	IPv6Address string `json:"ipv6_address,omitempty"`
+	MacAddress  string `json:"mac_address,omitempty"`
+}
+
+// ListNetworks lists the Docker networks sorted by name, each with its
+// connected containers. Docker only reports containers when inspecting a
+// network, so every network is inspected; networks removed in the meantime
+// are skipped.
+func (m *Manager) ListNetworks(ctx context.Context) ([]*Network, error) {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	resources, err := m.client.NetworkList(ctx, types.NetworkListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("ağlar listelenemedi: %w", err)
+	}
+
+	networks := make([]*Network, 0, len(resources))
+	for _, resource := range resources {
+		inspect, err := m.client.NetworkInspect(ctx, resource.ID, types.NetworkInspectOptions{})
+		if err != nil {
+			if client.IsErrNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("ağ bilgisi alınamadı (%s): %w", resource.Name, err)
+		}
+		networks = append(networks, networkFrom(inspect))
+	}
+
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].Name < networks[j].Name
+	})
+	return networks, nil
+}
+
+// InspectNetwork returns the Docker network with the given name or ID
+func (m *Manager) InspectNetwork(ctx context.Context, name string) (*Network, error) {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	inspect, err := m.client.NetworkInspect(ctx, name, types.NetworkInspectOptions{})
+	if err != nil {
+		if client.IsErrNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNetworkNotFound, name)
+		}
+		return nil, fmt.Errorf("ağ bilgisi alınamadı (%s): %w", name, err)
+	}
+	return networkFrom(inspect), nil
+}
+
+// networkFrom converts an inspected Docker network
+func networkFrom(resource types.NetworkResource) *Network {
+	network := &Network{
+		ID:         resource.ID,
+		Name:       resource.Name,
+		Driver:     resource.Driver,
+		Scope:      resource.Scope,
+		Internal:   resource.Internal,
+		Managed:    resource.Labels[ManagedLabel] == "true",
+		Labels:     resource.Labels,
+		Created:    resource.Created,
+		Containers: make([]NetworkContainer, 0, len(resource.Containers)),
+	}
+
+	for _, config := range resource.IPAM.Config {
+		network.Subnets = append(network.Subnets, NetworkSubnet{
+			Subnet:  config.Subnet,
+			Gateway: config.Gateway,
+		})
+	}
+
+	for id, endpoint := range resource.Containers {
+		network.Containers = append(network.Containers, NetworkContainer{
+			ID:          id,
+			Name:        endpoint.Name,
+			IPv4Address: endpoint.IPv4Address,
+			IPv6Address: endpoint.IPv6Address,
+			MacAddress:  endpoint.MacAddress,
+		})
+	}
+	sort.Slice(network.Containers, func(i, j int) bool {
+		return network.Containers[i].Name < network.Containers[j].Name
+	})
+
+	return network
+}
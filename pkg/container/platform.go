@@ -0,0 +1,60 @@
+package container
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// platformPattern matches os/arch with an optional variant, e.g. linux/amd64
+// or linux/arm/v7
+var platformPattern = regexp.MustCompile(`^[a-z0-9_]+/[a-z0-9_]+(/[a-z0-9_]+)?$`)
+
+// ValidatePlatform checks that platform is empty or of the form
+// os/arch[/variant]
+func ValidatePlatform(platform string) error {
+	if platform != "" && !platformPattern.MatchString(platform) {
+		return fmt.Errorf("geçersiz platform: %s (os/arch[/variant] biçiminde olmalı, örn. linux/amd64)", platform)
+	}
+	return nil
+}
+
+// parsePlatform converts a validated platform to the OCI representation,
+// returning nil for the daemon's native platform
+func parsePlatform(platform string) *ocispec.Platform {
+	if platform == "" {
+		return nil
+	}
+
+	parts := strings.Split(platform, "/")
+	result := &ocispec.Platform{OS: parts[0], Architecture: parts[1]}
+	if len(parts) == 3 {
+		result.Variant = parts[2]
+	}
+	return result
+}
+
+// platformMatches reports whether image was built for platform. An empty
+// platform matches any image.
+func platformMatches(image types.ImageInspect, platform string) bool {
+	p := parsePlatform(platform)
+	if p == nil {
+		return true
+	}
+	return image.Os == p.OS && image.Architecture == p.Architecture && (p.Variant == "" || p.Variant == image.Variant)
+}
+
+// imagePlatform formats the platform an image was built for, or returns an
+// empty string if the image does not record it
+func imagePlatform(image types.ImageInspect) string {
+	if image.Os == "" || image.Architecture == "" {
+		return ""
+	}
+	if image.Variant != "" {
+		return image.Os + "/" + image.Architecture + "/" + image.Variant
+	}
+	return image.Os + "/" + image.Architecture
+}
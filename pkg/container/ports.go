@@ -0,0 +1,112 @@
+package container
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParsePortKey parses a container port key of the form "port" or
+// "port/protocol". The protocol defaults to tcp and must be tcp or udp.
+func ParsePortKey(key string) (int, string, error) {
+	portStr := key
+	protocol := "tcp"
+	if strings.Contains(key, "/") {
+		parts := strings.SplitN(key, "/", 2)
+		portStr = parts[0]
+		protocol = strings.ToLower(strings.TrimSpace(parts[1]))
+	}
+
+	port, err := strconv.Atoi(strings.TrimSpace(portStr))
+	if err != nil {
+		return 0, "", fmt.Errorf("geçersiz port formatı: %s", key)
+	}
+
+	if protocol != "tcp" && protocol != "udp" {
+		return 0, "", fmt.Errorf("geçersiz port protokolü: %s (tcp veya udp olmalı)", protocol)
+	}
+
+	return port, protocol, nil
+}
+
+// NormalizePorts rewrites port keys into the canonical "port/protocol" form
+func NormalizePorts(ports map[string]string) (map[string]string, error) {
+	if ports == nil {
+		return nil, nil
+	}
+
+	result := make(map[string]string, len(ports))
+	for key, hostPort := range ports {
+		port, protocol, err := ParsePortKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		normalized := fmt.Sprintf("%d/%s", port, protocol)
+		if _, exists := result[normalized]; exists {
+			return nil, fmt.Errorf("port birden fazla tanımlanmış: %s", normalized)
+		}
+		result[normalized] = hostPort
+	}
+
+	return result, nil
+}
+
+// ServicePortProtocol returns the protocol of a service port, defaulting to tcp
+func ServicePortProtocol(port ServicePort) string {
+	if port.Protocol == "" {
+		return "tcp"
+	}
+	return strings.ToLower(port.Protocol)
+}
+
+// PortInUseError reports a host port that is already bound on this host
+type PortInUseError struct {
+	Port     string
+	Protocol string
+}
+
+func (e *PortInUseError) Error() string {
+	return fmt.Sprintf("host portu zaten kullanımda: %s/%s", e.Port, e.Protocol)
+}
+
+// CheckHostPorts verifies that every host port in ports is free by briefly
+// binding it. Empty host ports are left to Docker and skipped.
+func CheckHostPorts(ports map[string]string) error {
+	keys := make([]string, 0, len(ports))
+	for key := range ports {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		hostPort := ports[key]
+		if hostPort == "" {
+			continue
+		}
+
+		_, protocol, err := ParsePortKey(key)
+		if err != nil {
+			return err
+		}
+
+		addr := net.JoinHostPort("", hostPort)
+		if protocol == "udp" {
+			conn, err := net.ListenPacket("udp", addr)
+			if err != nil {
+				return &PortInUseError{Port: hostPort, Protocol: protocol}
+			}
+			conn.Close()
+		} else {
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return &PortInUseError{Port: hostPort, Protocol: protocol}
+			}
+			listener.Close()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/sirupsen/logrus"
+)
+
+// PruneOptions selects what SystemPrune removes
+type PruneOptions struct {
+	// Volumes also removes unused volumes
+	Volumes bool
+	// Until only removes containers, images, networks and build cache
+	// created before this time; zero removes them regardless of age
+	Until time.Time
+}
+
+// PruneReport lists what SystemPrune removed and the disk space it freed
+type PruneReport struct {
+	ContainersDeleted []string `json:"containers_deleted"`
+	ImagesDeleted     []string `json:"images_deleted"`
+	NetworksDeleted   []string `json:"networks_deleted"`
+	VolumesDeleted    []string `json:"volumes_deleted"`
+	BuildCacheDeleted []string `json:"build_cache_deleted"`
+	SpaceReclaimed    uint64   `json:"space_reclaimed"`
+}
+
+// SystemPrune removes stopped containers, dangling images, unused networks,
+// unused build cache and, with opts.Volumes, unused volumes. Deployment
+// replicas, which the scheduler recreates, and the networks and volumes ORCA
+// manages are kept. Pruning stops at the first failing step; the report
+// holds what was removed until then. The operation timeout does not apply.
+func (m *Manager) SystemPrune(ctx context.Context, opts PruneOptions) (*PruneReport, error) {
+	report := &PruneReport{
+		ContainersDeleted: []string{},
+		ImagesDeleted:     []string{},
+		NetworksDeleted:   []string{},
+		VolumesDeleted:    []string{},
+		BuildCacheDeleted: []string{},
+	}
+
+	withUntil := func(args filters.Args) filters.Args {
+		if !opts.Until.IsZero() {
+			args.Add("until", strconv.FormatInt(opts.Until.Unix(), 10))
+		}
+		return args
+	}
+
+	containers, err := m.client.ContainersPrune(ctx, withUntil(filters.NewArgs(filters.Arg("label!", DeploymentLabel))))
+	if err != nil {
+		return report, fmt.Errorf("konteynerler temizlenemedi: %w", err)
+	}
+	report.ContainersDeleted = append(report.ContainersDeleted, containers.ContainersDeleted...)
+	report.SpaceReclaimed += containers.SpaceReclaimed
+
+	images, err := m.client.ImagesPrune(ctx, withUntil(filters.NewArgs(filters.Arg("dangling", "true"))))
+	if err != nil {
+		return report, fmt.Errorf("image'lar temizlenemedi: %w", err)
+	}
+	for _, image := range images.ImagesDeleted {
+		if image.Deleted != "" {
+			report.ImagesDeleted = append(report.ImagesDeleted, image.Deleted)
+		}
+	}
+	report.SpaceReclaimed += images.SpaceReclaimed
+
+	networks, err := m.client.NetworksPrune(ctx, withUntil(filters.NewArgs(filters.Arg("label!", ManagedLabel))))
+	if err != nil {
+		return report, fmt.Errorf("ağlar temizlenemedi: %w", err)
+	}
+	report.NetworksDeleted = append(report.NetworksDeleted, networks.NetworksDeleted...)
+
+	// Volumes have no creation time filter
+	if opts.Volumes {
+		volumes, err := m.client.VolumesPrune(ctx, filters.NewArgs(filters.Arg("label!", ManagedLabel)))
+		if err != nil {
+			return report, fmt.Errorf("volume'lar temizlenemedi: %w", err)
+		}
+		report.VolumesDeleted = append(report.VolumesDeleted, volumes.VolumesDeleted...)
+		report.SpaceReclaimed += volumes.SpaceReclaimed
+	}
+
+	// The build cache filter takes an age rather than a time
+	cacheFilters := filters.NewArgs()
+	if !opts.Until.IsZero() {
+		cacheFilters.Add("until", time.Since(opts.Until).Round(time.Second).String())
+	}
+	cache, err := m.client.BuildCachePrune(ctx, types.BuildCachePruneOptions{Filters: cacheFilters})
+	if err != nil {
+		return report, fmt.Errorf("build cache temizlenemedi: %w", err)
+	}
+	report.BuildCacheDeleted = append(report.BuildCacheDeleted, cache.CachesDeleted...)
+	report.SpaceReclaimed += cache.SpaceReclaimed
+
+	m.logger.WithFields(logrus.Fields{
+		"containers":      len(report.ContainersDeleted),
+		"images":          len(report.ImagesDeleted),
+		"networks":        len(report.NetworksDeleted),
+		"volumes":         len(report.VolumesDeleted),
+		"build_cache":     len(report.BuildCacheDeleted),
+		"space_reclaimed": report.SpaceReclaimed,
+	}).Info("Kullanılmayan Docker kaynakları temizlendi")
+
+	return report, nil
+}
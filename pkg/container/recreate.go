@@ -0,0 +1,298 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrManagedReplica is returned when recreating a deployment replica, which
+// the scheduler replaces on its own
+var ErrManagedReplica = errors.New("deployment replica'ları tek tek yeniden oluşturulamaz")
+
+// SpecFromContainer builds a spec that creates an equivalent container from
+// an existing one. Environment variables, labels, command and working
+// directory inherited from the image are left out so that a newer image for
+// the same tag supplies its own.
+func (m *Manager) SpecFromContainer(ctx context.Context, containerID string) (*ContainerSpec, error) {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	inspect, err := m.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("container bulunamadı: %w", err)
+	}
+	return m.specFromInspect(ctx, inspect)
+}
+
+// specFromInspect builds a spec from an inspected container
+func (m *Manager) specFromInspect(ctx context.Context, inspect types.ContainerJSON) (*ContainerSpec, error) {
+	if inspect.Config == nil || inspect.HostConfig == nil {
+		return nil, fmt.Errorf("container yapılandırması okunamadı: %s", inspect.ID)
+	}
+	config := inspect.Config
+	hostConfig := inspect.HostConfig
+
+	// Settings equal to the image defaults are not part of the spec
+	var imageEnv map[string]string
+	var imageLabels map[string]string
+	var imageCmd []string
+	imageWorkingDir := ""
+	imageStopSignal := ""
+	var imageHealthcheck *container.HealthConfig
+	platform := ""
+	if image, _, err := m.client.ImageInspectWithRaw(ctx, inspect.Image); err == nil && image.Config != nil {
+		imageEnv = parseEnvVars(image.Config.Env)
+		imageLabels = image.Config.Labels
+		imageCmd = image.Config.Cmd
+		imageWorkingDir = image.Config.WorkingDir
+		imageStopSignal = image.Config.StopSignal
+		imageHealthcheck = image.Config.Healthcheck
+		// Keeping the platform keeps emulated containers on the same image
+		platform = imagePlatform(image)
+	} else if err != nil {
+		m.logger.WithError(err).WithField("image", config.Image).Warn("Image bilgisi alınamadı, tüm ortam değişkenleri korunuyor")
+	}
+
+	namespace := NamespaceOf(config.Labels)
+	spec := &ContainerSpec{
+		Name:          m.containerName(namespace, inspect.Name),
+		Namespace:     namespace,
+		Image:         config.Image,
+		RestartPolicy: formatRestartPolicy(hostConfig.RestartPolicy),
+		LogDriver:     hostConfig.LogConfig.Type,
+		LogOpts:       hostConfig.LogConfig.Config,
+		AutoRemove:    hostConfig.AutoRemove,
+		Resources:     fromDockerResources(hostConfig.Resources),
+		Platform:      platform,
+		GPUs:          fromDeviceRequests(hostConfig.DeviceRequests),
+		Devices:       fromDeviceMappings(hostConfig.Devices),
+		Secrets:       decodeSecretMounts(config.Labels),
+		Ulimits:       fromDockerUlimits(hostConfig.Ulimits),
+	}
+	if spec.RestartPolicy == "no" {
+		spec.RestartPolicy = ""
+	}
+
+	for key, value := range parseEnvVars(config.Env) {
+		if imageValue, ok := imageEnv[key]; ok && imageValue == value {
+			continue
+		}
+		if spec.Environment == nil {
+			spec.Environment = make(map[string]string)
+		}
+		spec.Environment[key] = value
+	}
+
+	for key, value := range config.Labels {
+		if imageValue, ok := imageLabels[key]; ok && imageValue == value {
+			continue
+		}
+		// Set again on create from the spec
+		if key == secretsLabel || key == secretDirLabel {
+			continue
+		}
+		if spec.Labels == nil {
+			spec.Labels = make(map[string]string)
+		}
+		spec.Labels[key] = value
+	}
+
+	if !slices.Equal(config.Cmd, imageCmd) {
+		spec.Command = config.Cmd
+	}
+	if config.WorkingDir != imageWorkingDir {
+		spec.WorkingDir = config.WorkingDir
+	}
+	if config.StopSignal != imageStopSignal {
+		spec.StopSignal = config.StopSignal
+	}
+	if !reflect.DeepEqual(config.Healthcheck, imageHealthcheck) {
+		spec.HealthCheck = fromDockerHealthcheck(config.Healthcheck)
+	}
+
+	// Bindings are read from the host config so that stopped containers keep
+	// their ports
+	for port, bindings := range hostConfig.PortBindings {
+		if len(bindings) == 0 {
+			continue
+		}
+		if spec.Ports == nil {
+			spec.Ports = make(map[string]string)
+		}
+		spec.Ports[string(port)] = bindings[0].HostPort
+	}
+
+	// Secret files are written again for the new container
+	secretDir := m.secretFilesDir(config.Labels)
+	for _, mnt := range hostConfig.Mounts {
+		if mnt.Type != mount.TypeBind && mnt.Type != mount.TypeVolume {
+			continue
+		}
+		if secretDir != "" && filepath.Dir(mnt.Source) == secretDir {
+			continue
+		}
+		spec.Volumes = append(spec.Volumes, VolumeMount{
+			Source:      mnt.Source,
+			Destination: mnt.Target,
+			ReadOnly:    mnt.ReadOnly,
+		})
+	}
+
+	// The configured default network is applied again on create
+	switch mode := string(hostConfig.NetworkMode); {
+	case mode == NetworkModeHost || mode == NetworkModeNone || strings.HasPrefix(mode, networkModeContainerPrefix):
+		spec.NetworkMode = mode
+	case mode == "" || mode == "default" || mode == NetworkModeBridge || mode == m.defaultNetwork:
+	default:
+		spec.Network = mode
+	}
+
+	return spec, nil
+}
+
+// Recreate replaces a container with a fresh one built from the same spec,
+// keeping its volumes. With pull set the image is pulled first, so a newer
+// image for the same tag is picked up. The old container is kept under a
+// temporary name until the new one has started and is restored if it fails.
+func (m *Manager) Recreate(ctx context.Context, containerID string, pull bool) (*Container, error) {
+	inspectCtx, cancel := m.withTimeout(ctx)
+	inspect, err := m.client.ContainerInspect(inspectCtx, containerID)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("container bulunamadı: %w", err)
+	}
+
+	spec, err := m.specFromInspect(ctx, inspect)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Labels[DeploymentLabel] != "" {
+		return nil, fmt.Errorf("%w: %s (%s deployment'ı için orca rollout restart kullanın)", ErrManagedReplica, spec.Name, spec.Labels[DeploymentLabel])
+	}
+
+	// Pull before touching the old container so a failed pull leaves it as is
+	if pull {
+		if err := m.ensureImage(ctx, spec.Image, PullAlways, spec.Platform); err != nil {
+			return nil, err
+		}
+	}
+
+	return m.replace(ctx, inspect, *spec)
+}
+
+// Replace replaces a container with a new one created and started from spec,
+// which may have another name. The old container is kept under a temporary
+// name until the new one has started and is restored if it fails.
+func (m *Manager) Replace(ctx context.Context, containerID string, spec ContainerSpec) (*Container, error) {
+	inspectCtx, cancel := m.withTimeout(ctx)
+	inspect, err := m.client.ContainerInspect(inspectCtx, containerID)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("container bulunamadı: %w", err)
+	}
+	return m.replace(ctx, inspect, spec)
+}
+
+// replace swaps an inspected container for a new one built from spec
+func (m *Manager) replace(ctx context.Context, inspect types.ContainerJSON, spec ContainerSpec) (*Container, error) {
+	fields := logrus.Fields{
+		"name":         spec.Name,
+		"image":        spec.Image,
+		"container_id": inspect.ID,
+	}
+	wasRunning := inspect.State != nil && inspect.State.Running
+
+	// Docker removes auto-remove containers once they stop, so there is
+	// nothing to roll back to
+	if inspect.HostConfig != nil && inspect.HostConfig.AutoRemove {
+		if err := m.Remove(ctx, inspect.ID); err != nil {
+			return nil, err
+		}
+		return m.startRecreated(ctx, spec)
+	}
+
+	if wasRunning {
+		if err := m.stop(ctx, inspect.ID, inspectFields(inspect)); err != nil {
+			return nil, err
+		}
+	}
+
+	oldName := strings.TrimPrefix(inspect.Name, "/")
+	if err := m.Rename(ctx, inspect.ID, oldName+"-orca-old"); err != nil {
+		m.restoreRecreated(ctx, inspect.ID, "", wasRunning, fields)
+		return nil, err
+	}
+
+	c, err := m.startRecreated(ctx, spec)
+	if err != nil {
+		m.logger.WithFields(fields).WithError(err).Error("Yeni container başlatılamadı, eski container geri yükleniyor")
+		m.restoreRecreated(ctx, inspect.ID, oldName, wasRunning, fields)
+		return nil, err
+	}
+
+	if err := m.Remove(ctx, inspect.ID); err != nil {
+		m.logger.WithFields(fields).WithError(err).Warn("Eski container silinemedi")
+	}
+
+	m.logger.WithFields(fields).WithField("new_container_id", c.ID).Info("Container yeniden oluşturuldu")
+	return c, nil
+}
+
+// startRecreated creates and starts the replacement container, removing it
+// again if it does not start
+func (m *Manager) startRecreated(ctx context.Context, spec ContainerSpec) (*Container, error) {
+	c, err := m.Create(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.Start(ctx, c); err != nil {
+		if rmErr := m.Remove(ctx, c.ID); rmErr != nil {
+			m.logger.WithError(rmErr).WithField("container_id", c.ID).Warn("Başlatılamayan container silinemedi")
+		}
+		return nil, err
+	}
+
+	started, err := m.Get(ctx, c.ID)
+	if err != nil {
+		return c, nil
+	}
+	started.Warnings = c.Warnings
+	return started, nil
+}
+
+// restoreRecreated gives the old container back its name and restarts it if
+// it was running
+func (m *Manager) restoreRecreated(ctx context.Context, containerID, name string, running bool, fields logrus.Fields) {
+	if name != "" {
+		if err := m.Rename(ctx, containerID, name); err != nil {
+			m.logger.WithFields(fields).WithError(err).Error("Eski container adı geri yüklenemedi")
+		}
+	}
+	if running {
+		if err := m.start(ctx, containerID, fields); err != nil {
+			m.logger.WithFields(fields).WithError(err).Error("Eski container yeniden başlatılamadı")
+		}
+	}
+}
+
+// Rename changes the name of a container
+func (m *Manager) Rename(ctx context.Context, containerID, name string) error {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	if err := m.client.ContainerRename(ctx, containerID, name); err != nil {
+		return fmt.Errorf("container yeniden adlandırılamadı: %w", err)
+	}
+	return nil
+}
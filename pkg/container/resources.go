@@ -0,0 +1,102 @@
+package container
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+	"github.com/sirupsen/logrus"
+)
+
+// minMemoryBytes is the smallest memory limit Docker accepts
+const minMemoryBytes = 6 * 1024 * 1024
+
+// Resources defines container resource limits
+type Resources struct {
+	// Memory is a human readable size such as "512m" or "1GB"
+	Memory string  `json:"memory,omitempty"`
+	CPUs   float64 `json:"cpus,omitempty"`
+}
+
+// ValidateResources checks that resource limits are well formed
+func ValidateResources(resources Resources) error {
+	if resources.Memory != "" {
+		memory, err := units.RAMInBytes(resources.Memory)
+		if err != nil {
+			return fmt.Errorf("geçersiz bellek değeri: %s", resources.Memory)
+		}
+		if memory < minMemoryBytes {
+			return fmt.Errorf("bellek sınırı en az 6MB olmalıdır: %s", resources.Memory)
+		}
+	}
+
+	if resources.CPUs < 0 {
+		return fmt.Errorf("geçersiz CPU değeri: %g", resources.CPUs)
+	}
+
+	return nil
+}
+
+// toDockerResources converts resource limits to the Docker representation
+func toDockerResources(resources Resources) (container.Resources, error) {
+	if err := ValidateResources(resources); err != nil {
+		return container.Resources{}, err
+	}
+
+	var result container.Resources
+	if resources.Memory != "" {
+		memory, _ := units.RAMInBytes(resources.Memory)
+		result.Memory = memory
+	}
+	if resources.CPUs > 0 {
+		result.NanoCPUs = int64(resources.CPUs * 1e9)
+	}
+
+	return result, nil
+}
+
+// fromDockerResources converts Docker resource limits, returning nil if none are set
+func fromDockerResources(resources container.Resources) *Resources {
+	if resources.Memory == 0 && resources.NanoCPUs == 0 {
+		return nil
+	}
+
+	result := &Resources{}
+	if resources.Memory > 0 {
+		result.Memory = units.BytesSize(float64(resources.Memory))
+	}
+	if resources.NanoCPUs > 0 {
+		result.CPUs = float64(resources.NanoCPUs) / 1e9
+	}
+	return result
+}
+
+// Update changes the resource limits of a running container without restarting it
+func (m *Manager) Update(ctx context.Context, containerID string, resources Resources) error {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	dockerResources, err := toDockerResources(resources)
+	if err != nil {
+		return err
+	}
+
+	resp, err := m.client.ContainerUpdate(ctx, containerID, container.UpdateConfig{
+		Resources: dockerResources,
+	})
+	if err != nil {
+		return fmt.Errorf("container kaynakları güncellenemedi: %w", err)
+	}
+
+	for _, warning := range resp.Warnings {
+		m.logger.WithField("container_id", containerID).Warn(warning)
+	}
+
+	m.logger.WithFields(logrus.Fields{
+		"container_id": containerID,
+		"memory":       resources.Memory,
+		"cpus":         resources.CPUs,
+	}).Info("Container kaynakları güncellendi")
+	return nil
+}
@@ -0,0 +1,88 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/sirupsen/logrus"
+)
+
+// ParseRestartPolicy parses a restart policy of the form "no", "always",
+// "unless-stopped" or "on-failure[:max-retries]"
+func ParseRestartPolicy(policy string) (container.RestartPolicy, error) {
+	name, retries, hasRetries := strings.Cut(policy, ":")
+
+	var result container.RestartPolicy
+	switch name {
+	case "", "no", "always", "unless-stopped", "on-failure":
+		result.Name = name
+	default:
+		return result, fmt.Errorf("geçersiz restart policy: %s (no, always, unless-stopped veya on-failure[:N] olmalı)", policy)
+	}
+
+	if hasRetries {
+		if name != "on-failure" {
+			return result, fmt.Errorf("tekrar sayısı yalnızca on-failure ile kullanılabilir: %s", policy)
+		}
+		count, err := strconv.Atoi(retries)
+		if err != nil || count < 0 {
+			return result, fmt.Errorf("geçersiz restart tekrar sayısı: %s", retries)
+		}
+		result.MaximumRetryCount = count
+	}
+
+	return result, nil
+}
+
+// ValidateAutoRemove checks that auto-remove is not combined with a restart
+// policy, since Docker cannot restart a container it has removed
+func ValidateAutoRemove(autoRemove bool, policy string) error {
+	if autoRemove && policy != "" && policy != "no" {
+		return fmt.Errorf("auto_remove restart policy ile birlikte kullanılamaz: %s", policy)
+	}
+	return nil
+}
+
+// formatRestartPolicy formats a Docker restart policy in the form accepted by
+// ParseRestartPolicy
+func formatRestartPolicy(policy container.RestartPolicy) string {
+	if policy.Name == "" {
+		return "no"
+	}
+	if policy.Name == "on-failure" && policy.MaximumRetryCount > 0 {
+		return fmt.Sprintf("on-failure:%d", policy.MaximumRetryCount)
+	}
+	return policy.Name
+}
+
+// UpdateRestartPolicy changes the restart policy of a container without
+// recreating it
+func (m *Manager) UpdateRestartPolicy(ctx context.Context, containerID string, policy string) error {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	restartPolicy, err := ParseRestartPolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	resp, err := m.client.ContainerUpdate(ctx, containerID, container.UpdateConfig{
+		RestartPolicy: restartPolicy,
+	})
+	if err != nil {
+		return fmt.Errorf("container restart policy güncellenemedi: %w", err)
+	}
+
+	for _, warning := range resp.Warnings {
+		m.logger.WithField("container_id", containerID).Warn(warning)
+	}
+
+	m.logger.WithFields(logrus.Fields{
+		"container_id":   containerID,
+		"restart_policy": formatRestartPolicy(restartPolicy),
+	}).Info("Container restart policy güncellendi")
+	return nil
+}
@@ -0,0 +1,246 @@
+package container
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/mount"
+)
+
+// ErrSecretNotFound is returned when a secret does not exist
+var ErrSecretNotFound = errors.New("secret bulunamadı")
+
+// ErrMountsSecrets is returned when copying the filesystem of a container
+// that mounts secrets, which could carry them out of the container
+var ErrMountsSecrets = errors.New("container secret bağlıyor")
+
+// secretsLabel records the secret mounts of a container, without any secret
+// data, so they survive a recreate and show up in inspect
+const secretsLabel = "orca.secrets"
+
+// secretDirLabel records the host directory the secret files of a container
+// are mounted from, so it is deleted along with the container
+const secretDirLabel = "orca.secrets.dir"
+
+// secretNamePattern allows names that are safe as file names
+var secretNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Secret is named sensitive data that containers mount as files
+type Secret struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	// Data maps file names to base64 encoded contents. It is never returned
+	// by the API; Keys lists the file names instead.
+	Data    map[string]string `json:"data,omitempty"`
+	Keys    []string          `json:"keys,omitempty"`
+	Created time.Time         `json:"created"`
+}
+
+// Redacted returns a copy of the secret that lists its keys but carries no data
+func (s *Secret) Redacted() *Secret {
+	keys := make([]string, 0, len(s.Data))
+	for key := range s.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return &Secret{
+		Name:      s.Name,
+		Namespace: s.Namespace,
+		Keys:      keys,
+		Created:   s.Created,
+	}
+}
+
+// SecretMount mounts every key of a secret as a file in the Target directory
+type SecretMount struct {
+	Secret string `json:"secret"`
+	Target string `json:"target"`
+}
+
+// SecretStore looks up the secrets mounted into containers
+type SecretStore interface {
+	LoadSecret(namespace, name string) (*Secret, error)
+}
+
+// SetSecretStore sets where secret data is read from when creating
+// containers with secret mounts
+func (m *Manager) SetSecretStore(store SecretStore) {
+	m.secrets = store
+}
+
+// ValidateSecret checks a secret's name and that its data is non-empty,
+// keyed by plain file names and base64 encoded
+func ValidateSecret(secret *Secret) ValidationErrors {
+	var errs ValidationErrors
+
+	errs.addErr("name", ValidateSecretName(secret.Name))
+	errs.addErr("namespace", ValidateNamespace(secret.Namespace))
+
+	if len(secret.Data) == 0 {
+		errs.add("data", "Secret en az bir anahtar içermelidir")
+	}
+	for key, value := range secret.Data {
+		field := "data." + key
+		if !secretNamePattern.MatchString(key) {
+			errs.add(field, "Geçersiz anahtar: %q (dosya adı olarak kullanılır)", key)
+		}
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			errs.add(field, "Değer base64 kodlanmış olmalıdır")
+		}
+	}
+
+	return errs
+}
+
+// ValidateSecretName checks that name is a valid secret name. Secret names
+// are used as file names, so they never contain a path separator.
+func ValidateSecretName(name string) error {
+	if !secretNamePattern.MatchString(name) {
+		return fmt.Errorf("Geçersiz secret adı: %q (harf, rakam, '_', '.' ve '-' içermeli)", name)
+	}
+	return nil
+}
+
+// validateSecretMount checks a single secret mount of a container spec
+func validateSecretMount(mount SecretMount) error {
+	if mount.Secret == "" {
+		return fmt.Errorf("secret adı boş olamaz")
+	}
+	if err := ValidateSecretName(mount.Secret); err != nil {
+		return err
+	}
+	if !path.IsAbs(mount.Target) || path.Clean(mount.Target) == "/" {
+		return fmt.Errorf("secret hedefi mutlak bir dizin olmalıdır: %s", mount.Target)
+	}
+	return nil
+}
+
+// writeSecretFiles loads the secrets of secretMounts and writes their keys as
+// read-only files into a new directory under the secrets directory. It
+// returns the directory and a read-only bind mount of it for every target,
+// so secret data never enters the container's writable layer.
+func (m *Manager) writeSecretFiles(namespace string, secretMounts []SecretMount) (string, []mount.Mount, error) {
+	if m.secrets == nil {
+		return "", nil, fmt.Errorf("secret deposu yapılandırılmamış")
+	}
+
+	secrets := make([]*Secret, 0, len(secretMounts))
+	for _, secretMount := range secretMounts {
+		secret, err := m.secrets.LoadSecret(namespace, secretMount.Secret)
+		if err != nil {
+			return "", nil, err
+		}
+		secrets = append(secrets, secret)
+	}
+
+	if err := os.MkdirAll(m.secretsDir, 0700); err != nil {
+		return "", nil, fmt.Errorf("secret dizini oluşturulamadı: %w", err)
+	}
+	dir, err := os.MkdirTemp(m.secretsDir, "secrets-")
+	if err != nil {
+		return "", nil, fmt.Errorf("secret dizini oluşturulamadı: %w", err)
+	}
+
+	mounts := make([]mount.Mount, 0, len(secretMounts))
+	for i, secretMount := range secretMounts {
+		source := filepath.Join(dir, strconv.Itoa(i))
+		if err := writeSecretDir(source, secretMount.Secret, secrets[i]); err != nil {
+			os.RemoveAll(dir)
+			return "", nil, err
+		}
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.TypeBind,
+			Source:   source,
+			Target:   path.Clean(secretMount.Target),
+			ReadOnly: true,
+		})
+	}
+	return dir, mounts, nil
+}
+
+// writeSecretDir writes every key of secret as a read-only file in dir
+func writeSecretDir(dir, name string, secret *Secret) error {
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return fmt.Errorf("secret dizini oluşturulamadı: %w", err)
+	}
+	for _, key := range secret.Redacted().Keys {
+		data, err := base64.StdEncoding.DecodeString(secret.Data[key])
+		if err != nil {
+			return fmt.Errorf("secret çözülemedi (%s/%s): %w", name, key, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, key), data, 0444); err != nil {
+			return fmt.Errorf("secret dosyası yazılamadı (%s/%s): %w", name, key, err)
+		}
+	}
+	return nil
+}
+
+// secretFilesDir returns the secret directory recorded in container labels,
+// or "" unless it lies directly in the secrets directory, so a label set by
+// hand never points removal elsewhere
+func (m *Manager) secretFilesDir(labels map[string]string) string {
+	dir := labels[secretDirLabel]
+	if dir == "" || filepath.Dir(filepath.Clean(dir)) != filepath.Clean(m.secretsDir) {
+		return ""
+	}
+	return filepath.Clean(dir)
+}
+
+// removeSecretFiles deletes the secret files a removed container mounted
+func (m *Manager) removeSecretFiles(labels map[string]string) {
+	dir := m.secretFilesDir(labels)
+	if dir == "" {
+		return
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		m.logger.WithError(err).WithField("dir", dir).Warn("Secret dosyaları silinemedi")
+	}
+}
+
+// checkNoSecrets returns ErrMountsSecrets if the container mounts secrets.
+// Containers created before secrets were bind mounted keep them in their
+// writable layer, so the label alone decides.
+func (m *Manager) checkNoSecrets(ctx context.Context, containerID string) error {
+	inspect, err := m.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return fmt.Errorf("container incelenemedi: %w", err)
+	}
+	if inspect.Config != nil {
+		if _, ok := inspect.Config.Labels[secretsLabel]; ok {
+			return fmt.Errorf("%w: %s", ErrMountsSecrets, strings.TrimPrefix(inspect.Name, "/"))
+		}
+	}
+	return nil
+}
+
+// encodeSecretMounts returns the label value recording mounts
+func encodeSecretMounts(mounts []SecretMount) string {
+	data, _ := json.Marshal(mounts)
+	return string(data)
+}
+
+// decodeSecretMounts reads the secret mounts recorded in container labels
+func decodeSecretMounts(labels map[string]string) []SecretMount {
+	value := labels[secretsLabel]
+	if value == "" {
+		return nil
+	}
+
+	var mounts []SecretMount
+	if err := json.Unmarshal([]byte(value), &mounts); err != nil {
+		return nil
+	}
+	return mounts
+}
@@ -0,0 +1,37 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+)
+
+// stopSignals are the signals a container may be stopped with
+var stopSignals = map[string]bool{
+	"SIGHUP":   true,
+	"SIGINT":   true,
+	"SIGQUIT":  true,
+	"SIGKILL":  true,
+	"SIGUSR1":  true,
+	"SIGUSR2":  true,
+	"SIGTERM":  true,
+	"SIGPWR":   true,
+	"SIGWINCH": true,
+}
+
+// NormalizeStopSignal returns the canonical name of a stop signal given with
+// or without the SIG prefix in any case, e.g. "quit" becomes "SIGQUIT". An
+// empty signal means Docker's default, SIGTERM, unless the image sets one.
+func NormalizeStopSignal(signal string) (string, error) {
+	if signal == "" {
+		return "", nil
+	}
+
+	name := strings.ToUpper(signal)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	if !stopSignals[name] {
+		return "", fmt.Errorf("geçersiz stop sinyali: %s (SIGTERM, SIGQUIT, SIGINT, SIGHUP, SIGUSR1, SIGUSR2, SIGPWR, SIGWINCH veya SIGKILL olmalı)", signal)
+	}
+	return name, nil
+}
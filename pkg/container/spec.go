@@ -4,7 +4,10 @@ import "time"
 
 // ContainerSpec defines the specification for a container
 type ContainerSpec struct {
+	TypeMeta
+
 	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
 	Image       string            `json:"image"`
 	Ports       map[string]string `json:"ports,omitempty"`
 	Environment map[string]string `json:"environment,omitempty"`
@@ -13,6 +16,38 @@ type ContainerSpec struct {
 	Args        []string          `json:"args,omitempty"`
 	WorkingDir  string            `json:"working_dir,omitempty"`
 	Volumes     []VolumeMount     `json:"volumes,omitempty"`
+	Network     string            `json:"network,omitempty"`
+	Resources   *Resources        `json:"resources,omitempty"`
+	// PullPolicy controls when the image is pulled: always, missing or never
+	PullPolicy string `json:"pull_policy,omitempty"`
+	// RestartPolicy is one of no, always, unless-stopped or on-failure[:N]
+	RestartPolicy string `json:"restart_policy,omitempty"`
+	// LogDriver overrides the daemon's log driver (e.g. journald, syslog)
+	LogDriver string            `json:"log_driver,omitempty"`
+	LogOpts   map[string]string `json:"log_opts,omitempty"`
+	// AutoRemove lets Docker remove the container once it exits
+	AutoRemove bool `json:"auto_remove,omitempty"`
+	// NetworkMode is bridge, host, none or container:<name>
+	NetworkMode string `json:"network_mode,omitempty"`
+	// Platform selects the image platform as os/arch[/variant], e.g.
+	// linux/amd64 to run under emulation; empty means the native platform
+	Platform string `json:"platform,omitempty"`
+	// GPUs requests NVIDIA GPUs: "all" or a GPU count
+	GPUs string `json:"gpus,omitempty"`
+	// Devices are host devices passed through as
+	// host[:container][:permissions], e.g. /dev/ttyUSB0:/dev/ttyUSB0:rwm
+	Devices []string `json:"devices,omitempty"`
+	// Secrets are mounted as read-only files; their data never appears in
+	// the environment or in inspect output
+	Secrets []SecretMount `json:"secrets,omitempty"`
+	// StopSignal is sent to the container on stop before it is killed after
+	// the timeout, e.g. SIGQUIT; empty uses the image's or SIGTERM
+	StopSignal string `json:"stop_signal,omitempty"`
+	// Ulimits raise or lower resource limits such as nofile for the
+	// container's processes; unset ones keep the daemon defaults
+	Ulimits []Ulimit `json:"ulimits,omitempty"`
+	// HealthCheck replaces the HEALTHCHECK of the image
+	HealthCheck *HealthCheck `json:"health_check,omitempty"`
 }
 
 // VolumeMount defines a volume mount
@@ -24,35 +59,144 @@ type VolumeMount struct {
 
 // Container represents a running container
 type Container struct {
-	ID          string            `json:"id"`
-	Name        string            `json:"name"`
-	Image       string            `json:"image"`
-	Status      string            `json:"status"`
-	Ports       map[string]string `json:"ports,omitempty"`
-	Environment map[string]string `json:"environment,omitempty"`
-	Labels      map[string]string `json:"labels,omitempty"`
-	Created     time.Time         `json:"created"`
-	Started     *time.Time        `json:"started,omitempty"`
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	Namespace     string            `json:"namespace,omitempty"`
+	Image         string            `json:"image"`
+	ImageID       string            `json:"image_id,omitempty"`
+	Status        string            `json:"status"`
+	Ports         map[string]string `json:"ports,omitempty"`
+	Environment   map[string]string `json:"environment,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Created       time.Time         `json:"created"`
+	Started       *time.Time        `json:"started,omitempty"`
+	RestartCount  int               `json:"restart_count"`
+	Resources     *Resources        `json:"resources,omitempty"`
+	WorkingDir    string            `json:"working_dir,omitempty"`
+	RestartPolicy string            `json:"restart_policy,omitempty"`
+	LogDriver     string            `json:"log_driver,omitempty"`
+	AutoRemove    bool              `json:"auto_remove,omitempty"`
+	NetworkMode   string            `json:"network_mode,omitempty"`
+	Platform      string            `json:"platform,omitempty"`
+	GPUs          string            `json:"gpus,omitempty"`
+	Devices       []string          `json:"devices,omitempty"`
+	Secrets       []SecretMount     `json:"secrets,omitempty"`
+	StopSignal    string            `json:"stop_signal,omitempty"`
+	Ulimits       []Ulimit          `json:"ulimits,omitempty"`
+	// HealthCheck is the health check Docker runs, from the spec or the
+	// image; Health is its last result: starting, healthy or unhealthy
+	HealthCheck *HealthCheck `json:"health_check,omitempty"`
+	Health      string       `json:"health,omitempty"`
+	// SizeRw and SizeRootFs are the sizes of the writable layer and of all
+	// layers in bytes; they are only filled when listing with sizes
+	SizeRw     int64 `json:"size_rw,omitempty"`
+	SizeRootFs int64 `json:"size_root_fs,omitempty"`
+	// Warnings holds the warnings Docker returned when creating the container
+	Warnings []string `json:"warnings,omitempty"`
+	// Error describes the fields that could not be read when listing the
+	// container; they are left empty
+	Error string `json:"error,omitempty"`
+}
+
+// FilesystemChange describes a change to a container's root filesystem
+type FilesystemChange struct {
+	// Kind is A (added), C (changed) or D (deleted)
+	Kind string `json:"kind"`
+	Path string `json:"path"`
 }
 
 // DeploymentSpec defines the specification for a deployment
 type DeploymentSpec struct {
+	TypeMeta
+
 	Name      string        `json:"name"`
+	Namespace string        `json:"namespace,omitempty"`
 	Replicas  int           `json:"replicas"`
 	Container ContainerSpec `json:"container"`
-	Strategy  string        `json:"strategy,omitempty"`
+	// Strategy is how replicas are replaced on restart: RollingUpdate
+	// (one at a time) or Recreate (all removed first)
+	Strategy string `json:"strategy,omitempty"`
+	// MinReadySeconds is how long a new replica must keep running before a
+	// rolling update moves on to the next one
+	MinReadySeconds int `json:"min_ready_seconds,omitempty"`
+	// PortOffset is the host port step between consecutive replicas (default 1)
+	PortOffset int `json:"port_offset,omitempty"`
+	// DependsOn names deployments of the same namespace whose replicas must
+	// all be ready before the replicas of this one start
+	DependsOn []string `json:"depends_on,omitempty"`
+	// PublishMode selects how replica ports are published: "direct" (default)
+	// binds base+index host ports, "proxy" binds ephemeral ports behind an
+	// ORCA proxy listening on the configured host port
+	PublishMode string `json:"publish_mode,omitempty"`
+	// SharedVolume is a named volume mounted into every replica
+	SharedVolume *SharedVolume `json:"shared_volume,omitempty"`
+	// PinDigest resolves the image to its digest on create and runs every
+	// replica from exactly that image
+	PinDigest bool `json:"pin_digest,omitempty"`
 }
 
+// SharedVolume is a named Docker volume shared by the replicas of a deployment
+type SharedVolume struct {
+	Name        string `json:"name"`
+	Destination string `json:"destination"`
+	// SingleWriter mounts the volume read-write into replica 0 only and
+	// read-only into all other replicas
+	SingleWriter bool `json:"single_writer,omitempty"`
+}
+
+// ManagedLabel marks the containers, volumes and networks ORCA manages,
+// including containers adopted from plain Docker
+const ManagedLabel = "orca.managed"
+
+// DeploymentLabel is the container label naming the deployment a replica
+// belongs to
+const DeploymentLabel = "orca.deployment"
+
+// Deployment update strategies
+const (
+	StrategyRollingUpdate = "RollingUpdate"
+	StrategyRecreate      = "Recreate"
+)
+
+// Deployment publish modes
+const (
+	PublishModeDirect = "direct"
+	PublishModeProxy  = "proxy"
+)
+
 // ServiceSpec defines the specification for a service
 type ServiceSpec struct {
-	Name     string            `json:"name"`
-	Type     string            `json:"type"`
-	Selector map[string]string `json:"selector"`
-	Ports    []ServicePort     `json:"ports"`
+	TypeMeta
+
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace,omitempty"`
+	Type      string            `json:"type"`
+	Selector  map[string]string `json:"selector"`
+	Ports     []ServicePort     `json:"ports"`
+	// DeploymentRef targets a deployment in the same namespace by name,
+	// bypassing selector matching
+	DeploymentRef string `json:"deployment_ref,omitempty"`
+	// HealthCheck sets how endpoints are probed; TCP connects by default
+	HealthCheck *ServiceHealthCheck `json:"health_check,omitempty"`
+}
+
+// Service health check types
+const (
+	HealthCheckTCP  = "tcp"
+	HealthCheckHTTP = "http"
+)
+
+// ServiceHealthCheck probes the endpoints of a service. An HTTP check
+// requests Path and counts 2xx and 3xx responses as healthy.
+type ServiceHealthCheck struct {
+	Type string `json:"type"`
+	Path string `json:"path,omitempty"`
 }
 
 // ServicePort defines a service port mapping
 type ServicePort struct {
-	Port       int `json:"port"`
-	TargetPort int `json:"target_port"`
-}
\ No newline at end of file
+	Port       int    `json:"port"`
+	TargetPort int    `json:"target_port"`
+	Protocol   string `json:"protocol,omitempty"`
+	NodePort   int    `json:"node_port,omitempty"`
+}
@@ -0,0 +1,39 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/docker/docker/errdefs"
+)
+
+// ErrNotRunning is returned when listing the processes of, or attaching to,
+// a container that is not running
+var ErrNotRunning = errors.New("container çalışmıyor")
+
+// ContainerProcesses is the process table of a container as reported by ps
+type ContainerProcesses struct {
+	Titles    []string   `json:"titles"`
+	Processes [][]string `json:"processes"`
+}
+
+// Top lists the processes running inside a container
+func (m *Manager) Top(ctx context.Context, containerID string) (*ContainerProcesses, error) {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	top, err := m.client.ContainerTop(ctx, containerID, nil)
+	if errdefs.IsConflict(err) {
+		return nil, fmt.Errorf("%w: %s", ErrNotRunning, containerID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("container işlemleri alınamadı: %w", err)
+	}
+
+	processes := &ContainerProcesses{Titles: top.Titles, Processes: top.Processes}
+	if processes.Processes == nil {
+		processes.Processes = [][]string{}
+	}
+	return processes, nil
+}
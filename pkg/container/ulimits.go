@@ -0,0 +1,134 @@
+package container
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+)
+
+// ulimitNames are the resource limits Docker can set on a container
+var ulimitNames = map[string]bool{
+	"core":       true,
+	"cpu":        true,
+	"data":       true,
+	"fsize":      true,
+	"locks":      true,
+	"memlock":    true,
+	"msgqueue":   true,
+	"nice":       true,
+	"nofile":     true,
+	"nproc":      true,
+	"rss":        true,
+	"rtprio":     true,
+	"rttime":     true,
+	"sigpending": true,
+	"stack":      true,
+}
+
+// Ulimit is a resource limit of the processes in a container, e.g. nofile
+// for open files. -1 means unlimited.
+type Ulimit struct {
+	Name string `json:"name"`
+	Soft int64  `json:"soft"`
+	Hard int64  `json:"hard"`
+}
+
+// ParseUlimit parses a ulimit of the form name=soft[:hard], e.g.
+// nofile=65536:65536. The hard limit defaults to the soft one.
+func ParseUlimit(value string) (Ulimit, error) {
+	name, limits, ok := strings.Cut(value, "=")
+	if !ok {
+		return Ulimit{}, fmt.Errorf("geçersiz ulimit: %s (ad=soft[:hard] olmalı)", value)
+	}
+
+	softStr, hardStr, hasHard := strings.Cut(limits, ":")
+	soft, err := strconv.ParseInt(softStr, 10, 64)
+	if err != nil {
+		return Ulimit{}, fmt.Errorf("geçersiz ulimit değeri: %s", value)
+	}
+	hard := soft
+	if hasHard {
+		if hard, err = strconv.ParseInt(hardStr, 10, 64); err != nil {
+			return Ulimit{}, fmt.Errorf("geçersiz ulimit değeri: %s", value)
+		}
+	}
+
+	ulimit := Ulimit{Name: name, Soft: soft, Hard: hard}
+	if err := validateUlimit(ulimit); err != nil {
+		return Ulimit{}, err
+	}
+	return ulimit, nil
+}
+
+// String formats the ulimit in the form accepted by ParseUlimit
+func (u Ulimit) String() string {
+	return fmt.Sprintf("%s=%d:%d", u.Name, u.Soft, u.Hard)
+}
+
+// ValidateUlimits checks that every ulimit has a known name, appears once
+// and has a soft limit no higher than its hard limit
+func ValidateUlimits(ulimits []Ulimit) error {
+	seen := make(map[string]bool, len(ulimits))
+	for _, ulimit := range ulimits {
+		if err := validateUlimit(ulimit); err != nil {
+			return err
+		}
+		if seen[ulimit.Name] {
+			return fmt.Errorf("ulimit birden fazla tanımlanmış: %s", ulimit.Name)
+		}
+		seen[ulimit.Name] = true
+	}
+	return nil
+}
+
+// validateUlimit checks a single ulimit
+func validateUlimit(ulimit Ulimit) error {
+	if !ulimitNames[ulimit.Name] {
+		names := make([]string, 0, len(ulimitNames))
+		for name := range ulimitNames {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("bilinmeyen ulimit: %q (desteklenenler: %s)", ulimit.Name, strings.Join(names, ", "))
+	}
+	if ulimit.Soft < -1 || ulimit.Hard < -1 {
+		return fmt.Errorf("%s ulimit değerleri negatif olamaz (sınırsız için -1)", ulimit.Name)
+	}
+	// -1 is unlimited, which is above every finite limit
+	if ulimit.Hard != -1 && (ulimit.Soft == -1 || ulimit.Soft > ulimit.Hard) {
+		return fmt.Errorf("%s ulimit'inin soft değeri hard değerinden büyük olamaz (%d > %d)", ulimit.Name, ulimit.Soft, ulimit.Hard)
+	}
+	return nil
+}
+
+// toDockerUlimits converts ulimits for the host config
+func toDockerUlimits(ulimits []Ulimit) []*units.Ulimit {
+	if len(ulimits) == 0 {
+		return nil
+	}
+
+	result := make([]*units.Ulimit, 0, len(ulimits))
+	for _, ulimit := range ulimits {
+		result = append(result, &units.Ulimit{Name: ulimit.Name, Soft: ulimit.Soft, Hard: ulimit.Hard})
+	}
+	return result
+}
+
+// fromDockerUlimits converts the ulimits of a host config
+func fromDockerUlimits(ulimits []*units.Ulimit) []Ulimit {
+	if len(ulimits) == 0 {
+		return nil
+	}
+
+	result := make([]Ulimit, 0, len(ulimits))
+	for _, ulimit := range ulimits {
+		if ulimit == nil {
+			continue
+		}
+		result = append(result, Ulimit{Name: ulimit.Name, Soft: ulimit.Soft, Hard: ulimit.Hard})
+	}
+	return result
+}
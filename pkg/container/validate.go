@@ -0,0 +1,279 @@
+package container
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldError is a validation problem with a single spec field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors collects every validation problem found in a spec
+type ValidationErrors []FieldError
+
+// Error joins the problems into a single message
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fe := range e {
+		messages[i] = fmt.Sprintf("%s: %s", fe.Field, fe.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// add records a problem with field
+func (e *ValidationErrors) add(field, format string, args ...interface{}) {
+	*e = append(*e, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// addErr records err as a problem with field if it is not nil
+func (e *ValidationErrors) addErr(field string, err error) {
+	if err != nil {
+		e.add(field, "%s", err.Error())
+	}
+}
+
+// ValidateContainerSpec checks a container spec and normalizes its port
+// keys. All problems are returned together; nil means the spec is valid.
+func ValidateContainerSpec(spec *ContainerSpec) ValidationErrors {
+	var errs ValidationErrors
+	errs.addErr("namespace", ValidateNamespace(spec.Namespace))
+	validateContainer(&errs, "", spec, true)
+	return errs
+}
+
+// ValidateDeploymentSpec checks a deployment spec, including its container
+// template, and normalizes its port keys. maxReplicas is the configured
+// replica cap. Defaults should be applied first.
+func ValidateDeploymentSpec(spec *DeploymentSpec, maxReplicas int) ValidationErrors {
+	var errs ValidationErrors
+
+	if spec.Name == "" {
+		errs.add("name", "Deployment adı boş olamaz")
+	}
+	errs.addErr("namespace", ValidateNamespace(spec.Namespace))
+	if spec.Replicas < 1 {
+		errs.add("replicas", "Replica sayısı en az 1 olmalıdır")
+	} else if spec.Replicas > maxReplicas {
+		errs.add("replicas", "Replica sayısı en fazla %d olabilir (scheduler.max_replicas)", maxReplicas)
+	}
+
+	switch spec.Strategy {
+	case "", StrategyRollingUpdate, StrategyRecreate:
+	default:
+		errs.add("strategy", "Geçersiz strategy: %s (RollingUpdate veya Recreate olmalı)", spec.Strategy)
+	}
+	seen := make(map[string]bool, len(spec.DependsOn))
+	for i, name := range spec.DependsOn {
+		field := fmt.Sprintf("depends_on[%d]", i)
+		switch {
+		case name == "":
+			errs.add(field, "Bağımlılık adı boş olamaz")
+		case name == spec.Name:
+			errs.add(field, "Deployment kendisine bağımlı olamaz")
+		case seen[name]:
+			errs.add(field, "Bağımlılık birden fazla tanımlanmış: %s", name)
+		}
+		seen[name] = true
+	}
+
+	if spec.MinReadySeconds < 0 {
+		errs.add("min_ready_seconds", "min_ready_seconds negatif olamaz: %d", spec.MinReadySeconds)
+	}
+
+	// Host ports of a deployment are base ports offset per replica, so an
+	// empty one is allowed
+	validateContainer(&errs, "container.", &spec.Container, false)
+
+	if spec.SharedVolume != nil {
+		if spec.SharedVolume.Name == "" || !path.IsAbs(spec.SharedVolume.Destination) {
+			errs.add("shared_volume", "shared_volume için ad ve mutlak bir hedef yol belirtilmelidir")
+		}
+	}
+
+	// The proxy only forwards TCP
+	switch spec.PublishMode {
+	case "", PublishModeDirect:
+	case PublishModeProxy:
+		for _, key := range sortedPortKeys(spec.Container.Ports) {
+			if _, protocol, err := ParsePortKey(key); err == nil && protocol != "tcp" {
+				errs.add("publish_mode", "Proxy modunda yalnızca tcp portları desteklenir: %s", key)
+			}
+		}
+	default:
+		errs.add("publish_mode", "Geçersiz publish_mode: %s (direct veya proxy olmalı)", spec.PublishMode)
+	}
+
+	return errs
+}
+
+// ValidateServiceSpec checks a service spec and fills in default port
+// protocols
+func ValidateServiceSpec(spec *ServiceSpec) ValidationErrors {
+	var errs ValidationErrors
+
+	if spec.Name == "" {
+		errs.add("name", "Service adı boş olamaz")
+	}
+	errs.addErr("namespace", ValidateNamespace(spec.Namespace))
+
+	switch spec.Type {
+	case "ClusterIP", "NodePort", "LoadBalancer":
+	case "":
+		errs.add("type", "Service tipi belirtilmelidir")
+	default:
+		errs.add("type", "Geçersiz service tipi. Desteklenen tipler: ClusterIP, NodePort, LoadBalancer")
+	}
+
+	// A service without a selector or deployment reference never gets endpoints
+	if len(spec.Selector) == 0 && spec.DeploymentRef == "" {
+		errs.add("selector", "%s tipindeki service için selector veya deployment_ref belirtilmelidir", spec.Type)
+	}
+	if _, ok := spec.Selector[""]; ok {
+		errs.add("selector", "Selector anahtarları boş olamaz")
+	}
+
+	if check := spec.HealthCheck; check != nil {
+		switch check.Type {
+		case "", HealthCheckTCP:
+			if check.Path != "" {
+				errs.add("health_check.path", "path yalnızca http health check'lerinde kullanılabilir")
+			}
+		case HealthCheckHTTP:
+			if check.Path != "" && !strings.HasPrefix(check.Path, "/") {
+				errs.add("health_check.path", "Health check yolu / ile başlamalıdır: %s", check.Path)
+			}
+		default:
+			errs.add("health_check.type", "Geçersiz health check tipi: %s (tcp veya http olmalı)", check.Type)
+		}
+	}
+
+	if len(spec.Ports) == 0 {
+		errs.add("ports", "En az bir port tanımlanmalıdır")
+	}
+	for i, port := range spec.Ports {
+		field := fmt.Sprintf("ports[%d]", i)
+		if port.Port < 1 || port.Port > 65535 {
+			errs.add(field+".port", "Port numarası 1-65535 arasında olmalıdır")
+		}
+		if port.TargetPort < 1 || port.TargetPort > 65535 {
+			errs.add(field+".target_port", "Hedef port numarası 1-65535 arasında olmalıdır")
+		}
+		if port.NodePort != 0 && spec.Type != "NodePort" {
+			errs.add(field+".node_port", "node_port yalnızca NodePort tipindeki service'lerde kullanılabilir")
+		}
+		if protocol := ServicePortProtocol(port); protocol != "tcp" && protocol != "udp" {
+			errs.add(field+".protocol", "Geçersiz port protokolü. Desteklenen protokoller: tcp, udp")
+		}
+	}
+
+	if len(errs) == 0 {
+		for i := range spec.Ports {
+			spec.Ports[i].Protocol = ServicePortProtocol(spec.Ports[i])
+		}
+	}
+	return errs
+}
+
+// validateContainer records the problems of a container spec under prefix.
+// Ports are normalized only when all of them are valid.
+func validateContainer(errs *ValidationErrors, prefix string, spec *ContainerSpec, requireHostPorts bool) {
+	if spec.Name == "" {
+		errs.add(prefix+"name", "Container adı boş olamaz")
+	}
+	if spec.Image == "" {
+		errs.add(prefix+"image", "Container image boş olamaz")
+	} else {
+		errs.addErr(prefix+"image", ValidateImageReference(spec.Image))
+	}
+
+	// Working directory must be an absolute path inside the container
+	if spec.WorkingDir != "" && !path.IsAbs(spec.WorkingDir) {
+		errs.add(prefix+"working_dir", "Çalışma dizini mutlak bir yol olmalıdır: %s", spec.WorkingDir)
+	}
+
+	errs.addErr(prefix+"pull_policy", ValidatePullPolicy(spec.PullPolicy))
+	errs.addErr(prefix+"platform", ValidatePlatform(spec.Platform))
+	if _, err := ParseRestartPolicy(spec.RestartPolicy); err != nil {
+		errs.addErr(prefix+"restart_policy", err)
+	} else {
+		errs.addErr(prefix+"auto_remove", ValidateAutoRemove(spec.AutoRemove, spec.RestartPolicy))
+	}
+	errs.addErr(prefix+"log_driver", ValidateLogDriver(spec.LogDriver, spec.LogOpts))
+	errs.addErr(prefix+"network_mode", ValidateNetworkMode(spec.NetworkMode, spec.Network))
+
+	if spec.Resources != nil {
+		errs.addErr(prefix+"resources", ValidateResources(*spec.Resources))
+	}
+	errs.addErr(prefix+"gpus", ValidateGPUs(spec.GPUs))
+	for i, device := range spec.Devices {
+		if _, err := ParseDevice(device); err != nil {
+			errs.addErr(fmt.Sprintf("%sdevices[%d]", prefix, i), err)
+		}
+	}
+	if signal, err := NormalizeStopSignal(spec.StopSignal); err != nil {
+		errs.addErr(prefix+"stop_signal", err)
+	} else {
+		spec.StopSignal = signal
+	}
+	errs.addErr(prefix+"ulimits", ValidateUlimits(spec.Ulimits))
+	if spec.HealthCheck != nil {
+		errs.addErr(prefix+"health_check", ValidateHealthCheck(*spec.HealthCheck))
+	}
+	for i, mount := range spec.Secrets {
+		errs.addErr(fmt.Sprintf("%ssecrets[%d]", prefix, i), validateSecretMount(mount))
+	}
+
+	if spec.Ports == nil {
+		return
+	}
+
+	before := len(*errs)
+	ports := make(map[string]string, len(spec.Ports))
+	for _, key := range sortedPortKeys(spec.Ports) {
+		field := fmt.Sprintf("%sports.%s", prefix, key)
+		hostPortStr := spec.Ports[key]
+
+		port, protocol, err := ParsePortKey(key)
+		if err != nil {
+			errs.addErr(field, err)
+			continue
+		}
+		if port < 1 || port > 65535 {
+			errs.add(field, "Container port numarası 1-65535 arasında olmalıdır")
+		}
+
+		if hostPortStr != "" || requireHostPorts {
+			if hostPort, err := strconv.Atoi(hostPortStr); hostPortStr != "" && err != nil {
+				errs.add(field, "Geçersiz host port formatı: %s", hostPortStr)
+			} else if hostPort < 1 || hostPort > 65535 {
+				errs.add(field, "Host port numarası 1-65535 arasında olmalıdır")
+			}
+		}
+
+		normalized := fmt.Sprintf("%d/%s", port, protocol)
+		if _, exists := ports[normalized]; exists {
+			errs.add(field, "port birden fazla tanımlanmış: %s", normalized)
+		}
+		ports[normalized] = hostPortStr
+	}
+
+	if len(*errs) == before {
+		spec.Ports = ports
+	}
+}
+
+// sortedPortKeys returns the keys of a port map in a stable order
+func sortedPortKeys(ports map[string]string) []string {
+	keys := make([]string, 0, len(ports))
+	for key := range ports {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,72 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/client"
+)
+
+// toDockerMounts converts volume mounts to Docker mounts. Absolute sources
+// are bind mounted from the host, other sources are named volumes.
+func toDockerMounts(volumes []VolumeMount) ([]mount.Mount, error) {
+	mounts := make([]mount.Mount, 0, len(volumes))
+	for _, v := range volumes {
+		if v.Source == "" || !path.IsAbs(v.Destination) {
+			return nil, fmt.Errorf("geçersiz volume tanımı: %s → %s", v.Source, v.Destination)
+		}
+
+		mountType := mount.TypeVolume
+		if path.IsAbs(v.Source) {
+			mountType = mount.TypeBind
+		}
+
+		mounts = append(mounts, mount.Mount{
+			Type:     mountType,
+			Source:   v.Source,
+			Target:   v.Destination,
+			ReadOnly: v.ReadOnly,
+		})
+	}
+	return mounts, nil
+}
+
+// EnsureVolume creates the named Docker volume if it does not exist yet
+func (m *Manager) EnsureVolume(ctx context.Context, name string) error {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	_, err := m.client.VolumeInspect(ctx, name)
+	if err == nil {
+		return nil
+	}
+	if !client.IsErrNotFound(err) {
+		return fmt.Errorf("volume bilgisi alınamadı (%s): %w", name, err)
+	}
+
+	if _, err := m.client.VolumeCreate(ctx, volume.CreateOptions{
+		Name:   name,
+		Labels: map[string]string{ManagedLabel: "true"},
+	}); err != nil {
+		return fmt.Errorf("volume oluşturulamadı (%s): %w", name, err)
+	}
+
+	m.logger.WithField("volume", name).Info("Volume oluşturuldu")
+	return nil
+}
+
+// RemoveVolume removes the named Docker volume
+func (m *Manager) RemoveVolume(ctx context.Context, name string) error {
+	ctx, cancel := m.withTimeout(ctx)
+	defer cancel()
+
+	if err := m.client.VolumeRemove(ctx, name, false); err != nil {
+		return fmt.Errorf("volume silinemedi (%s): %w", name, err)
+	}
+
+	m.logger.WithField("volume", name).Info("Volume silindi")
+	return nil
+}
@@ -0,0 +1,189 @@
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// dialTimeout bounds connecting to a single backend
+const dialTimeout = 5 * time.Second
+
+// Proxy forwards TCP connections arriving on frontend ports to a set of
+// backends in round-robin order
+type Proxy struct {
+	logger    *logrus.Logger
+	mutex     sync.Mutex
+	frontends map[int]*frontend
+}
+
+// frontend is a listening port and the backends it forwards to
+type frontend struct {
+	owner    string
+	port     int
+	listener net.Listener
+	backends atomic.Value // []string
+	next     uint64
+}
+
+// New creates a new proxy with no frontends
+func New(logger *logrus.Logger) *Proxy {
+	return &Proxy{
+		logger:    logger,
+		frontends: make(map[int]*frontend),
+	}
+}
+
+// Sync makes the frontends owned by owner match routes, a map of frontend
+// port to backend addresses. Frontends of owner missing from routes are closed.
+func (p *Proxy) Sync(owner string, routes map[int][]string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for port, f := range p.frontends {
+		if _, ok := routes[port]; !ok && f.owner == owner {
+			p.closeFrontend(f)
+		}
+	}
+
+	ports := make([]int, 0, len(routes))
+	for port := range routes {
+		ports = append(ports, port)
+	}
+	sort.Ints(ports)
+
+	var firstErr error
+	for _, port := range ports {
+		f, ok := p.frontends[port]
+		if ok && f.owner != owner {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("proxy portu %d zaten '%s' tarafından kullanılıyor", port, f.owner)
+			}
+			continue
+		}
+
+		if !ok {
+			listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("proxy portu dinlenemedi (%d): %w", port, err)
+				}
+				continue
+			}
+
+			f = &frontend{owner: owner, port: port, listener: listener}
+			f.backends.Store([]string{})
+			p.frontends[port] = f
+			go p.serve(f)
+
+			p.logger.WithFields(logrus.Fields{
+				"owner": owner,
+				"port":  port,
+			}).Info("Proxy dinlemeye başladı")
+		}
+
+		f.backends.Store(append([]string(nil), routes[port]...))
+	}
+
+	return firstErr
+}
+
+// Remove closes every frontend owned by owner
+func (p *Proxy) Remove(owner string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, f := range p.frontends {
+		if f.owner == owner {
+			p.closeFrontend(f)
+		}
+	}
+}
+
+// Close closes all frontends
+func (p *Proxy) Close() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, f := range p.frontends {
+		p.closeFrontend(f)
+	}
+}
+
+// closeFrontend stops listening on a frontend. Caller must hold the mutex.
+func (p *Proxy) closeFrontend(f *frontend) {
+	f.listener.Close()
+	delete(p.frontends, f.port)
+
+	p.logger.WithFields(logrus.Fields{
+		"owner": f.owner,
+		"port":  f.port,
+	}).Info("Proxy durduruldu")
+}
+
+// serve accepts connections on a frontend until its listener is closed
+func (p *Proxy) serve(f *frontend) {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go p.handle(f, conn)
+	}
+}
+
+// handle forwards a client connection to the next reachable backend
+func (p *Proxy) handle(f *frontend, client net.Conn) {
+	defer client.Close()
+
+	backends := f.backends.Load().([]string)
+	if len(backends) == 0 {
+		p.logger.WithField("port", f.port).Warn("Proxy için kullanılabilir backend yok")
+		return
+	}
+
+	// Try each backend once, starting at the next one in round-robin order
+	start := atomic.AddUint64(&f.next, 1)
+	var backend net.Conn
+	for i := 0; i < len(backends); i++ {
+		addr := backends[(start+uint64(i))%uint64(len(backends))]
+		conn, err := net.DialTimeout("tcp", addr, dialTimeout)
+		if err == nil {
+			backend = conn
+			break
+		}
+		p.logger.WithError(err).WithField("backend", addr).Debug("Proxy backend'e bağlanılamadı")
+	}
+	if backend == nil {
+		p.logger.WithField("port", f.port).Warn("Proxy hiçbir backend'e bağlanamadı")
+		return
+	}
+	defer backend.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(backend, client)
+		closeWrite(backend)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(client, backend)
+		closeWrite(client)
+		done <- struct{}{}
+	}()
+	<-done
+	<-done
+}
+
+// closeWrite half-closes a TCP connection so the peer sees EOF
+func closeWrite(conn net.Conn) {
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		tcp.CloseWrite()
+	}
+}
@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"orca/pkg/container"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrAlreadyAdopted is returned when adopting a container that already
+// belongs to a deployment
+var ErrAlreadyAdopted = errors.New("container zaten bir deployment'a ait")
+
+// AdoptContainer brings a container created outside ORCA, e.g. with docker
+// run, under management as a single replica deployment named name in the
+// container's namespace. Docker labels cannot be changed in place, so the
+// container is replaced by replica 0 of the deployment, keeping its image,
+// settings, volumes and host ports; the old container is restored if the
+// replacement does not start. From then on the reconcile loop keeps it
+// running and it can be scaled like any deployment.
+func (s *Scheduler) AdoptContainer(ctx context.Context, containerID, name string) (*Deployment, error) {
+	containerSpec, err := s.containerManager.SpecFromContainer(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if owner := containerSpec.Labels[container.DeploymentLabel]; owner != "" {
+		return nil, fmt.Errorf("%w: %s (%s)", ErrAlreadyAdopted, containerSpec.Name, owner)
+	}
+	if name == "" {
+		name = containerSpec.Name
+	}
+
+	// The image may have been built locally, so it is only pulled if missing
+	containerSpec.PullPolicy = container.PullMissing
+	spec := container.DeploymentSpec{
+		Name:      name,
+		Namespace: containerSpec.Namespace,
+		Replicas:  1,
+		Container: *containerSpec,
+	}
+	s.ApplyDefaults(&spec)
+	if errs := container.ValidateDeploymentSpec(&spec, s.config.MaxReplicas); errs != nil {
+		return nil, errs
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.findDeployment(spec.Namespace, spec.Name) != nil {
+		return nil, fmt.Errorf("%w: %s", ErrDeploymentExists, spec.Name)
+	}
+
+	deployment := &Deployment{
+		ID:       generateID(),
+		Name:     spec.Name,
+		Spec:     spec,
+		Status:   StatusCreating,
+		Replicas: make([]*container.Container, 0, 1),
+		Created:  time.Now(),
+	}
+	if err := s.persistDeployment(deployment); err != nil {
+		return nil, fmt.Errorf("deployment kaydedilemedi: %w", err)
+	}
+
+	// The adopted container still holds the host ports, so they are not
+	// checked up front; the replacement gets them once it is stopped
+	replicaSpec, err := s.buildReplicaSpec(spec, 0)
+	if err == nil {
+		var c *container.Container
+		c, err = s.containerManager.Replace(ctx, containerID, replicaSpec)
+		if err == nil {
+			deployment.Replicas = append(deployment.Replicas, c)
+		}
+	}
+	if err != nil {
+		deployment.setStatus(StatusDeleting)
+		deployment.setStatus(StatusDeleted)
+		if s.store != nil {
+			if deleteErr := s.store.DeleteDeployment(deployment.ID); deleteErr != nil {
+				s.logger.WithError(deleteErr).WithField("deployment_id", deployment.ID).Warn("Deployment kaydı silinemedi")
+			}
+		}
+		return nil, fmt.Errorf("container sahiplenilemedi: %w", err)
+	}
+
+	deployment.setStatus(StatusRunning)
+	s.deployments[deployment.ID] = deployment
+	s.refreshServiceEndpoints()
+	s.syncRoutes(deployment)
+
+	if err := s.persistDeployment(deployment); err != nil {
+		s.logger.WithError(err).WithField("deployment_id", deployment.ID).Warn("Deployment kaydedilemedi")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"deployment_id": deployment.ID,
+		"name":          deployment.Name,
+		"old_id":        containerID,
+		"new_id":        deployment.Replicas[0].ID,
+	}).Info("Container sahiplenildi")
+
+	s.emit(EventDeploymentAdopted, deployment.Spec.Namespace, deployment.Name, deployment)
+
+	return deployment, nil
+}
@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"orca/pkg/container"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrDependencyCycle is returned when the dependencies of a deployment would
+// make it wait for itself
+var ErrDependencyCycle = errors.New("deployment bağımlılıkları döngü oluşturuyor")
+
+// ErrWaitingForDependencies is returned when changing a deployment whose
+// replicas have not started because its dependencies are not ready yet
+var ErrWaitingForDependencies = errors.New("deployment bağımlılıklarının hazır olmasını bekliyor")
+
+// dependencyCycle returns the cycle the dependencies of spec would form with
+// the deployments of its namespace, starting and ending at spec, or nil.
+// Dependencies that do not exist yet end a path. Caller must hold the
+// scheduler mutex.
+func (s *Scheduler) dependencyCycle(spec container.DeploymentSpec) []string {
+	visited := make(map[string]bool)
+
+	var visit func(path []string, dependsOn []string) []string
+	visit = func(path []string, dependsOn []string) []string {
+		for _, name := range dependsOn {
+			if name == spec.Name {
+				return append(path, name)
+			}
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+
+			dependency := s.findDeployment(spec.Namespace, name)
+			if dependency == nil {
+				continue
+			}
+			if cycle := visit(append(path, name), dependency.Spec.DependsOn); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+
+	return visit([]string{spec.Name}, spec.DependsOn)
+}
+
+// checkDependencyCycle returns ErrDependencyCycle if the dependencies of
+// spec form a cycle. Caller must hold the scheduler mutex.
+func (s *Scheduler) checkDependencyCycle(spec container.DeploymentSpec) error {
+	if cycle := s.dependencyCycle(spec); cycle != nil {
+		return fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " → "))
+	}
+	return nil
+}
+
+// dependencySnapshot is the state of a dependency copied under the
+// scheduler lock, so its replicas can be probed without holding it
+type dependencySnapshot struct {
+	name     string
+	found    bool
+	status   string
+	desired  int
+	replicas []*container.Container
+}
+
+// snapshotDependencies copies the state of the deployments spec depends on.
+// Caller must hold the scheduler mutex.
+func (s *Scheduler) snapshotDependencies(spec container.DeploymentSpec) []dependencySnapshot {
+	snapshots := make([]dependencySnapshot, 0, len(spec.DependsOn))
+	for _, name := range spec.DependsOn {
+		snapshot := dependencySnapshot{name: name}
+		if dependency := s.findDeployment(spec.Namespace, name); dependency != nil {
+			snapshot.found = true
+			snapshot.status = dependency.Status
+			snapshot.desired = dependency.Spec.Replicas
+			snapshot.replicas = make([]*container.Container, len(dependency.Replicas))
+			copy(snapshot.replicas, dependency.Replicas)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+// pendingDependencies returns the names of the dependencies that do not
+// exist yet or do not have all of their replicas ready
+func (s *Scheduler) pendingDependencies(ctx context.Context, snapshots []dependencySnapshot) []string {
+	var pending []string
+	for _, snapshot := range snapshots {
+		if !snapshot.found || (snapshot.status != StatusRunning && snapshot.status != StatusDegraded) ||
+			len(snapshot.replicas) < snapshot.desired {
+			pending = append(pending, snapshot.name)
+			continue
+		}
+
+		ready := 0
+		states, errs := s.probeReplicas(ctx, snapshot.replicas)
+		for i := range states {
+			if errs[i] == nil && states[i].Ready() {
+				ready++
+			}
+		}
+		if ready < snapshot.desired {
+			pending = append(pending, snapshot.name)
+		}
+	}
+	return pending
+}
+
+// startWaiting moves a waiting deployment to creating once all of its
+// dependencies are ready, so the rest of the reconcile pass creates its
+// replicas. It reports whether the deployment may be reconciled.
+func (s *Scheduler) startWaiting(ctx context.Context, deployment *Deployment) bool {
+	s.mutex.RLock()
+	snapshots := s.snapshotDependencies(deployment.Spec)
+	s.mutex.RUnlock()
+
+	pending := s.pendingDependencies(ctx, snapshots)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.deployments[deployment.ID] != deployment || deployment.Status != StatusWaiting {
+		return false
+	}
+	if len(pending) > 0 {
+		deployment.WaitingFor = pending
+		return false
+	}
+
+	if err := deployment.setStatus(StatusCreating); err != nil {
+		s.logger.WithError(err).Warn("Deployment durumu güncellenemedi")
+		return false
+	}
+	deployment.WaitingFor = nil
+	if err := s.persistDeployment(deployment); err != nil {
+		s.logger.WithError(err).WithField("deployment_id", deployment.ID).Warn("Deployment kaydedilemedi")
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"deployment": deployment.Name,
+		"depends_on": deployment.Spec.DependsOn,
+	}).Info("Bağımlılıklar hazır, deployment başlatılıyor")
+	s.emit(EventDeploymentStarted, deployment.Spec.Namespace, deployment.Name, deployment)
+	return true
+}
@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"strings"
+	"time"
+)
+
+// maxRecentEvents is the number of events kept for RecentEvents
+const maxRecentEvents = 500
+
+// Event types emitted by the scheduler
+const (
+	EventDeploymentCreated    = "deployment.created"
+	EventDeploymentStarted    = "deployment.started"
+	EventDeploymentAdopted    = "deployment.adopted"
+	EventDeploymentScaled     = "deployment.scaled"
+	EventDeploymentDeleted    = "deployment.deleted"
+	EventDeploymentRestarted  = "deployment.restarted"
+	EventDeploymentUpdated    = "deployment.updated"
+	EventDeploymentCanary     = "deployment.canary"
+	EventDeploymentPromoted   = "deployment.promoted"
+	EventDeploymentAborted    = "deployment.rollout_aborted"
+	EventReplicaRecycled      = "deployment.replica_recycled"
+	EventReplicaFailed        = "deployment.replica_failed"
+	EventReplicaRecreated     = "deployment.replica_recreated"
+	EventServiceCreated       = "service.created"
+	EventServiceDeleted       = "service.deleted"
+	EventServiceHealthChanged = "service.health_changed"
+)
+
+// Event describes a change to a deployment or service
+type Event struct {
+	Type      string      `json:"type"`
+	Namespace string      `json:"namespace"`
+	Name      string      `json:"name"`
+	Object    interface{} `json:"object,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// EventHandler receives scheduler events. Handlers are called synchronously,
+// possibly with the scheduler lock held, and must not block or call back
+// into the scheduler.
+type EventHandler func(event Event)
+
+// OnEvent registers a handler for all scheduler events
+func (s *Scheduler) OnEvent(handler EventHandler) {
+	s.handlersMutex.Lock()
+	defer s.handlersMutex.Unlock()
+
+	s.handlers = append(s.handlers, handler)
+}
+
+// RecentEvents returns the most recent events of the deployments or
+// services (kind "deployment" or "service") named name in a namespace,
+// oldest first. Only the last few hundred events of all resources are kept.
+func (s *Scheduler) RecentEvents(namespace, kind, name string) []Event {
+	s.handlersMutex.RLock()
+	defer s.handlersMutex.RUnlock()
+
+	events := []Event{}
+	for _, event := range s.recentEvents {
+		if event.Namespace == namespace && event.Name == name && strings.HasPrefix(event.Type, kind+".") {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// emit records an event and delivers it to all registered handlers
+func (s *Scheduler) emit(eventType, namespace, name string, object interface{}) {
+	event := Event{
+		Type:      eventType,
+		Namespace: namespace,
+		Name:      name,
+		Object:    object,
+		Timestamp: time.Now(),
+	}
+
+	// Deployments and services change after the event, so only replica
+	// events keep their object in the history
+	recorded := event
+	if _, ok := object.(ReplicaEvent); !ok {
+		recorded.Object = nil
+	}
+
+	s.handlersMutex.Lock()
+	handlers := s.handlers
+	s.recentEvents = append(s.recentEvents, recorded)
+	if len(s.recentEvents) > maxRecentEvents {
+		s.recentEvents = s.recentEvents[len(s.recentEvents)-maxRecentEvents:]
+	}
+	s.handlersMutex.Unlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
@@ -0,0 +1,217 @@
+package scheduler
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"orca/pkg/container"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Service states. A service is active until its endpoints are first
+// checked, and down whenever it has no endpoints at all.
+const (
+	ServiceActive   = "active"
+	ServiceHealthy  = "healthy"
+	ServiceDegraded = "degraded"
+	ServiceDown     = "down"
+)
+
+// ServiceHealth is the result of the last health check of a service
+type ServiceHealth struct {
+	Healthy   int       `json:"healthy"`
+	Total     int       `json:"total"`
+	Unhealthy []string  `json:"unhealthy,omitempty"`
+	Checked   time.Time `json:"checked"`
+}
+
+// serviceProbe is a service and the endpoints its health check probes
+type serviceProbe struct {
+	service *Service
+	check   container.ServiceHealthCheck
+	targets []string
+	healthy []bool
+}
+
+// RunServiceHealth probes the endpoints of every service each service health
+// interval until ctx is cancelled and marks services healthy, degraded or
+// down by how many endpoints respond. A zero interval disables the checks.
+func (s *Scheduler) RunServiceHealth(ctx context.Context) {
+	if s.config.ServiceHealthInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(s.config.ServiceHealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.checkServices(ctx)
+	}
+}
+
+// checkServices probes every endpoint of every service once and records the
+// results
+func (s *Scheduler) checkServices(ctx context.Context) {
+	s.mutex.RLock()
+	probes := make([]*serviceProbe, 0, len(s.services))
+	for _, svc := range s.services {
+		probe := &serviceProbe{service: svc, check: container.ServiceHealthCheck{Type: container.HealthCheckTCP}}
+		if svc.Spec.HealthCheck != nil && svc.Spec.HealthCheck.Type != "" {
+			probe.check = *svc.Spec.HealthCheck
+		}
+		probe.targets = s.resolveEndpoints(tcpPorts(svc.Spec))
+		probe.healthy = make([]bool, len(probe.targets))
+		probes = append(probes, probe)
+	}
+	s.mutex.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, probe := range probes {
+		for i, target := range probe.targets {
+			wg.Add(1)
+			go func(probe *serviceProbe, i int, target string) {
+				defer wg.Done()
+				probe.healthy[i] = s.probeEndpoint(ctx, probe.check, target)
+			}(probe, i, target)
+		}
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	changed := false
+	now := time.Now()
+	for _, probe := range probes {
+		svc := probe.service
+		if s.services[svc.ID] != svc {
+			continue
+		}
+
+		previous := svc.Status
+		switch {
+		case len(svc.Endpoints) == 0:
+			svc.Status = ServiceDown
+			svc.Health = nil
+		case len(probe.targets) == 0:
+			// UDP endpoints cannot be probed, so they count as long as they exist
+			svc.Status = ServiceActive
+			svc.Health = nil
+		default:
+			health := &ServiceHealth{Total: len(probe.targets), Checked: now}
+			for i, ok := range probe.healthy {
+				if ok {
+					health.Healthy++
+				} else {
+					health.Unhealthy = append(health.Unhealthy, probe.targets[i])
+				}
+			}
+			svc.Health = health
+			svc.Status = healthStatus(health)
+		}
+
+		if svc.Status == previous {
+			continue
+		}
+		changed = true
+
+		fields := logrus.Fields{
+			"service": svc.Name,
+			"from":    previous,
+			"to":      svc.Status,
+		}
+		if svc.Health != nil {
+			fields["healthy"] = svc.Health.Healthy
+			fields["total"] = svc.Health.Total
+		}
+		if svc.Status == ServiceDegraded || svc.Status == ServiceDown {
+			s.logger.WithFields(fields).Warn("Service sağlığı bozuldu")
+		} else {
+			s.logger.WithFields(fields).Info("Service sağlık durumu değişti")
+		}
+		s.emit(EventServiceHealthChanged, svc.Spec.Namespace, svc.Name, svc)
+	}
+
+	if changed {
+		if err := s.persistServices(); err != nil {
+			s.logger.WithError(err).Warn("Service'ler kaydedilemedi")
+		}
+	}
+}
+
+// probeEndpoint reports whether an endpoint accepts a TCP connection or, for
+// HTTP checks, answers with a 2xx or 3xx status
+func (s *Scheduler) probeEndpoint(ctx context.Context, check container.ServiceHealthCheck, target string) bool {
+	ctx, cancel := context.WithTimeout(ctx, s.config.ServiceHealthTimeout)
+	defer cancel()
+
+	if check.Type != container.HealthCheckHTTP {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", target)
+		if err != nil {
+			return false
+		}
+		conn.Close()
+		return true
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+target+check.Path, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode < 400
+}
+
+// healthStatus maps a health check result to a service state
+func healthStatus(health *ServiceHealth) string {
+	switch health.Healthy {
+	case health.Total:
+		return ServiceHealthy
+	case 0:
+		return ServiceDown
+	default:
+		return ServiceDegraded
+	}
+}
+
+// endpointStatus returns the state of a service after its endpoints changed.
+// Without endpoints a service is down; a service that was down because it
+// had none is active again until its next check.
+func endpointStatus(svc *Service) string {
+	if len(svc.Endpoints) == 0 {
+		return ServiceDown
+	}
+	if (svc.Status == ServiceDown && svc.Health == nil) || svc.Status == "" {
+		return ServiceActive
+	}
+	return svc.Status
+}
+
+// tcpPorts returns a copy of a service spec with only its TCP ports
+func tcpPorts(spec container.ServiceSpec) container.ServiceSpec {
+	ports := make([]container.ServicePort, 0, len(spec.Ports))
+	for _, port := range spec.Ports {
+		if container.ServicePortProtocol(port) == "tcp" {
+			ports = append(ports, port)
+		}
+	}
+	spec.Ports = ports
+	return spec
+}
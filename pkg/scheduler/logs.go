@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"orca/pkg/container"
+)
+
+// logLine is a single log line of a replica
+type logLine struct {
+	text string
+	time time.Time
+}
+
+// DeploymentLogs collects the logs of every replica of a deployment, prefixing
+// each line with the replica name. With opts.Timestamps the lines of all
+// replicas are interleaved by time, otherwise replicas follow each other.
+func (s *Scheduler) DeploymentLogs(ctx context.Context, namespace, name string, opts container.LogOptions) (*container.LogResult, error) {
+	s.mutex.RLock()
+	deployment := s.findDeployment(namespace, name)
+	if deployment == nil {
+		s.mutex.RUnlock()
+		return nil, fmt.Errorf("deployment bulunamadı: %s", name)
+	}
+	replicas := make([]*container.Container, len(deployment.Replicas))
+	copy(replicas, deployment.Replicas)
+	s.mutex.RUnlock()
+
+	result := &container.LogResult{}
+	var lines []logLine
+
+	for _, replica := range replicas {
+		prefix := fmt.Sprintf("[%s] ", replica.Name)
+
+		logs, err := s.containerManager.LogsWithOptions(ctx, replica.ID, opts)
+		if err != nil {
+			s.logger.WithError(err).WithField("container_id", replica.ID).Warn("Replica logları alınamadı")
+			lines = append(lines, logLine{text: prefix + fmt.Sprintf("loglar alınamadı: %v", err)})
+			continue
+		}
+		if logs.Truncated {
+			result.Truncated = true
+		}
+
+		// Lines without a timestamp keep the time of the line before them
+		var last time.Time
+		for _, text := range strings.Split(strings.TrimSuffix(logs.Logs, "\n"), "\n") {
+			if text == "" {
+				continue
+			}
+			if opts.Timestamps {
+				if ts, _, ok := strings.Cut(text, " "); ok {
+					if parsed, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+						last = parsed
+					}
+				}
+			}
+			lines = append(lines, logLine{text: prefix + text, time: last})
+		}
+	}
+
+	if opts.Timestamps {
+		sort.SliceStable(lines, func(i, j int) bool {
+			return lines[i].time.Before(lines[j].time)
+		})
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(line.text)
+		b.WriteByte('\n')
+	}
+	result.Logs = b.String()
+
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+
+	"orca/pkg/container"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ListOrphans lists the containers labeled with a deployment that no longer
+// exists, e.g. replicas left behind by an interrupted cleanup
+func (s *Scheduler) ListOrphans(ctx context.Context) ([]*container.Container, error) {
+	containers, err := s.containerManager.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// A deployment being created holds the lock until it is registered, so
+	// its containers are never reported here
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	orphans := make([]*container.Container, 0)
+	for _, c := range containers {
+		name := c.Labels[container.DeploymentLabel]
+		if name != "" && s.findDeployment(container.NamespaceOf(c.Labels), name) == nil {
+			orphans = append(orphans, c)
+		}
+	}
+
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].Name < orphans[j].Name
+	})
+	return orphans, nil
+}
+
+// PruneOrphans removes the orphaned containers and reports a result per
+// container
+func (s *Scheduler) PruneOrphans(ctx context.Context) ([]BatchDeleteResult, error) {
+	orphans, err := s.ListOrphans(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchDeleteResult, 0, len(orphans))
+	for _, c := range orphans {
+		result := BatchDeleteResult{Name: c.Name, Deleted: true}
+		if err := s.containerManager.Remove(ctx, c.ID); err != nil {
+			result.Deleted = false
+			result.Error = err.Error()
+		} else {
+			s.logger.WithFields(logrus.Fields{
+				"container_id": c.ID,
+				"name":         c.Name,
+				"deployment":   c.Labels[container.DeploymentLabel],
+			}).Info("Sahipsiz replica silindi")
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
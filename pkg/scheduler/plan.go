@@ -0,0 +1,213 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"orca/pkg/container"
+)
+
+// Plan step actions
+const (
+	PlanCreate  = "create"
+	PlanRemove  = "remove"
+	PlanReplace = "replace"
+)
+
+// Plan lists the replica changes an operation would make, in the order it
+// would make them, without making any. Canary is the number of replicas a
+// canary update stops after.
+type Plan struct {
+	Operation  string     `json:"operation"`
+	Deployment string     `json:"deployment"`
+	Namespace  string     `json:"namespace"`
+	Strategy   string     `json:"strategy,omitempty"`
+	Canary     int        `json:"canary,omitempty"`
+	Steps      []PlanStep `json:"steps"`
+}
+
+// PlanStep is a single replica change of a plan. Replace stops and removes
+// the old container before its replacement is created.
+type PlanStep struct {
+	Action      string            `json:"action"`
+	Index       int               `json:"index"`
+	Replica     string            `json:"replica"`
+	ContainerID string            `json:"container_id,omitempty"`
+	Image       string            `json:"image,omitempty"`
+	Ports       map[string]string `json:"ports,omitempty"`
+}
+
+// PlanScale returns the plan of scaling a deployment to replicas: new
+// replicas are created first and surplus ones removed once the new state is
+// committed
+func (s *Scheduler) PlanScale(namespace, name string, replicas int) (*Plan, error) {
+	if replicas < 0 {
+		return nil, fmt.Errorf("replica sayısı negatif olamaz: %d", replicas)
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	deployment := s.findDeployment(namespace, name)
+	if deployment == nil {
+		return nil, fmt.Errorf("deployment bulunamadı: %s", name)
+	}
+	if deployment.Rollout != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRolloutInProgress, name)
+	}
+	spec := deployment.replicaSpec()
+	plan := newPlan("scale", deployment)
+
+	for i := len(deployment.Replicas); i < replicas; i++ {
+		step, err := s.planCreate(spec, i)
+		if err != nil {
+			return nil, err
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+	for i := replicas; i < len(deployment.Replicas); i++ {
+		plan.Steps = append(plan.Steps, planRemove(deployment.Replicas[i], i))
+	}
+
+	return plan, nil
+}
+
+// PlanRestart returns the plan of a rollout restart: replicas are replaced
+// one at a time, or with the Recreate strategy all are removed first and then
+// created again
+func (s *Scheduler) PlanRestart(namespace, name string) (*Plan, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	deployment := s.findDeployment(namespace, name)
+	if deployment == nil {
+		return nil, fmt.Errorf("deployment bulunamadı: %s", name)
+	}
+	if deployment.Rollout != nil {
+		return nil, fmt.Errorf("%w: %s", ErrRolloutInProgress, name)
+	}
+	plan := newPlan("restart", deployment)
+	if err := s.planReplace(plan, deployment, deployment.replicaSpec(), 0, len(deployment.Replicas)); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// PlanUpdate returns the plan of rolling out spec to an existing deployment:
+// replicas are replaced like in a restart, stopping after the canary ones.
+// A pinned image digest is not resolved, so steps show the image as given.
+func (s *Scheduler) PlanUpdate(spec container.DeploymentSpec, opts UpdateOptions) (*Plan, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	spec.Namespace = container.NormalizeNamespace(spec.Namespace)
+	deployment, err := s.updatableDeployment(spec.Namespace, spec.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := newPlan("update", deployment)
+	spec.Replicas = deployment.Spec.Replicas
+	if !opts.ForceRecreate && specUnchanged(spec, deployment) {
+		return plan, nil
+	}
+	if err := s.checkDependencyCycle(spec); err != nil {
+		return nil, err
+	}
+	canary, err := opts.canaryCount(len(deployment.Replicas))
+	if err != nil {
+		return nil, err
+	}
+
+	last := len(deployment.Replicas)
+	if canary > 0 {
+		last = canary
+	}
+	plan.Canary = canary
+	if err := s.planReplace(plan, deployment, spec, 0, last); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// PlanRollback returns the plan of aborting a paused rollout: the updated
+// replicas are replaced with the previous spec, newest first
+func (s *Scheduler) PlanRollback(namespace, name string) (*Plan, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	deployment, err := s.pausedRollout(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	plan := newPlan("rollback", deployment)
+	previous := deployment.Rollout.previousReplicaSpec()
+	for i := deployment.Rollout.Updated - 1; i >= 0; i-- {
+		if err := s.planReplace(plan, deployment, previous, i, i+1); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+// planReplace adds the steps of replacing the replicas from first up to last
+// with spec, in the order rollingReplace does: one at a time, or with the
+// Recreate strategy all of them removed first and then created again
+func (s *Scheduler) planReplace(plan *Plan, deployment *Deployment, spec container.DeploymentSpec, first, last int) error {
+	plan.Strategy = spec.Strategy
+
+	if spec.Strategy == container.StrategyRecreate {
+		for i := first; i < last; i++ {
+			if replica := deployment.Replicas[i]; !isMissing(replica) {
+				plan.Steps = append(plan.Steps, planRemove(replica, i))
+			}
+		}
+	}
+	for i := first; i < last; i++ {
+		step, err := s.planCreate(spec, i)
+		if err != nil {
+			return err
+		}
+		if replica := deployment.Replicas[i]; spec.Strategy != container.StrategyRecreate && !isMissing(replica) {
+			step.Action = PlanReplace
+			step.ContainerID = replica.ID
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+	return nil
+}
+
+// newPlan returns an empty plan of an operation on deployment
+func newPlan(operation string, deployment *Deployment) *Plan {
+	return &Plan{
+		Operation:  operation,
+		Deployment: deployment.Name,
+		Namespace:  deployment.Spec.Namespace,
+		Steps:      []PlanStep{},
+	}
+}
+
+// planCreate returns the step creating replica index
+func (s *Scheduler) planCreate(spec container.DeploymentSpec, index int) (PlanStep, error) {
+	containerSpec, err := s.buildReplicaSpec(spec, index)
+	if err != nil {
+		return PlanStep{}, err
+	}
+	return PlanStep{
+		Action:  PlanCreate,
+		Index:   index,
+		Replica: containerSpec.Name,
+		Image:   containerSpec.Image,
+		Ports:   containerSpec.Ports,
+	}, nil
+}
+
+// planRemove returns the step removing a replica
+func planRemove(replica *container.Container, index int) PlanStep {
+	return PlanStep{
+		Action:      PlanRemove,
+		Index:       index,
+		Replica:     replica.Name,
+		ContainerID: replica.ID,
+		Image:       replica.Image,
+	}
+}
@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"orca/pkg/container"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrNotReplica is returned when a container is not a replica of a deployment
+var ErrNotReplica = errors.New("container bir deployment replica'sı değil")
+
+// ErrNoPreviousReplica is returned when no exited container of a replica is
+// kept, e.g. because the replica was never recreated
+var ErrNoPreviousReplica = errors.New("replica'nın önceki bir container'ı yok")
+
+// PreviousReplica returns the ID of the last exited container of the replica
+// slot the given container belongs to. The reconcile loop keeps it stopped
+// when it replaces the replica, so its logs can still be read.
+func (s *Scheduler) PreviousReplica(namespace, containerID string) (string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	namespace = container.NormalizeNamespace(namespace)
+	for _, d := range s.deployments {
+		if d.Spec.Namespace != namespace {
+			continue
+		}
+		for i, replica := range d.Replicas {
+			if replica.ID != containerID {
+				continue
+			}
+			previous, ok := d.PreviousReplicas[i]
+			if !ok {
+				return "", fmt.Errorf("%w: %s", ErrNoPreviousReplica, replica.Name)
+			}
+			return previous, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrNotReplica, containerID)
+}
+
+// previousName returns the Docker name the exited container of replica
+// index is kept under once it is replaced
+func (s *Scheduler) previousName(namespace, name string, index int) (string, error) {
+	replicaName, err := s.config.ReplicaName(name, index)
+	if err != nil {
+		return "", err
+	}
+	return s.containerManager.DockerName(namespace, replicaName+"-previous"), nil
+}
+
+// retireReplica keeps the exited container of replica index under its
+// previous name, replacing the one kept before, and records it so its logs
+// remain readable. If it cannot be renamed it is removed.
+func (s *Scheduler) retireReplica(ctx context.Context, deployment *Deployment, index int, replica *container.Container) {
+	s.mutex.RLock()
+	namespace, name := deployment.Spec.Namespace, deployment.Name
+	previous, ok := deployment.PreviousReplicas[index]
+	s.mutex.RUnlock()
+
+	if ok {
+		s.removeReplicas(ctx, []*container.Container{{ID: previous}})
+	}
+	dockerName, err := s.previousName(namespace, name, index)
+	if err == nil {
+		// A container left under the name by a lost record is replaced too
+		if c, getErr := s.containerManager.Get(ctx, dockerName); getErr == nil && c.ID != previous {
+			s.removeReplicas(ctx, []*container.Container{c})
+		}
+		err = s.containerManager.Rename(ctx, replica.ID, dockerName)
+	}
+	if err != nil {
+		s.logger.WithError(err).WithFields(logrus.Fields{
+			"deployment":   name,
+			"index":        index,
+			"container_id": replica.ID,
+		}).Warn("Önceki replica saklanamadı")
+		s.removeReplicas(ctx, []*container.Container{replica})
+
+		s.mutex.Lock()
+		delete(deployment.PreviousReplicas, index)
+		s.mutex.Unlock()
+		return
+	}
+
+	s.mutex.Lock()
+	if deployment.PreviousReplicas == nil {
+		deployment.PreviousReplicas = make(map[int]string)
+	}
+	deployment.PreviousReplicas[index] = replica.ID
+	s.mutex.Unlock()
+}
+
+// dropPreviousReplicas forgets the exited containers kept for replica
+// indices from index on and returns them by index. Caller must hold the
+// scheduler mutex.
+func (d *Deployment) dropPreviousReplicas(index int) map[int]string {
+	dropped := make(map[int]string)
+	for i, id := range d.PreviousReplicas {
+		if i >= index {
+			dropped[i] = id
+			delete(d.PreviousReplicas, i)
+		}
+	}
+	return dropped
+}
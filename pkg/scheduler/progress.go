@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+)
+
+// Progress stages reported while a deployment is created
+const (
+	// ProgressPulling the image is being pulled according to the pull policy
+	ProgressPulling = "pulling"
+	// ProgressCreating a replica container is being created and started
+	ProgressCreating = "creating"
+	// ProgressStarted a replica container is running
+	ProgressStarted = "started"
+	// ProgressWaiting the deployment waits for its dependencies to be ready
+	ProgressWaiting = "waiting"
+)
+
+// Progress is a step of a deployment operation, reported while it runs
+type Progress struct {
+	Stage   string `json:"stage"`
+	Message string `json:"message"`
+	// Replica is the 1-based number of the replica the step is about, out
+	// of Replicas
+	Replica  int       `json:"replica,omitempty"`
+	Replicas int       `json:"replicas,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// ProgressFunc receives the progress of an operation. It may be called with
+// the scheduler mutex held and must not block.
+type ProgressFunc func(Progress)
+
+// progressKey is the context key of the ProgressFunc of an operation
+type progressKey struct{}
+
+// WithProgress returns a context that makes the operations it is passed to
+// report their progress to fn
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// reportProgress passes a step to the ProgressFunc of ctx, if any
+func reportProgress(ctx context.Context, progress Progress) {
+	fn, ok := ctx.Value(progressKey{}).(ProgressFunc)
+	if !ok {
+		return
+	}
+	progress.Time = time.Now()
+	fn(progress)
+}
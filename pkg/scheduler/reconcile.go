@@ -0,0 +1,353 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"orca/pkg/container"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ReconcileStatus reports the state of the reconcile loop
+type ReconcileStatus struct {
+	// Active is set when the loop is enabled and not paused
+	Active   bool       `json:"active"`
+	Paused   bool       `json:"paused"`
+	Interval string     `json:"interval"`
+	LastRun  *time.Time `json:"last_run,omitempty"`
+}
+
+// reconcileState is the pause flag and bookkeeping of the reconcile loop
+type reconcileState struct {
+	mutex   sync.Mutex
+	paused  bool
+	lastRun time.Time
+}
+
+// RunReconcile repairs deployments every reconcile interval, varied by the
+// configured jitter, until ctx is cancelled: replicas whose container is gone
+// or has stopped are recreated and missing replicas are added. Ticks are
+// skipped while paused. A zero interval disables the loop.
+func (s *Scheduler) RunReconcile(ctx context.Context) {
+	if s.config.ReconcileInterval <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(s.nextReconcileDelay())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		timer.Reset(s.nextReconcileDelay())
+
+		s.reconcile.mutex.Lock()
+		paused := s.reconcile.paused
+		s.reconcile.mutex.Unlock()
+		if paused {
+			continue
+		}
+
+		s.reconcileOnce(ctx)
+
+		s.reconcile.mutex.Lock()
+		s.reconcile.lastRun = time.Now()
+		s.reconcile.mutex.Unlock()
+	}
+}
+
+// nextReconcileDelay returns the reconcile interval shifted by a random
+// amount of up to ReconcileJitter times the interval in either direction
+func (s *Scheduler) nextReconcileDelay() time.Duration {
+	interval := s.config.ReconcileInterval
+	if s.config.ReconcileJitter <= 0 {
+		return interval
+	}
+
+	spread := float64(interval) * s.config.ReconcileJitter
+	return interval + time.Duration((rand.Float64()*2-1)*spread)
+}
+
+// probeReplicas fetches the current state of replicas concurrently, with at
+// most ReconcileConcurrency requests to Docker in flight across all
+// deployments. The results are in the order of replicas.
+func (s *Scheduler) probeReplicas(ctx context.Context, replicas []*container.Container) ([]*container.Container, []error) {
+	current := make([]*container.Container, len(replicas))
+	errs := make([]error, len(replicas))
+
+	var wg sync.WaitGroup
+	for i, replica := range replicas {
+		// Placeholders of missing replicas have no container to probe
+		if isMissing(replica) {
+			errs[i] = fmt.Errorf("replica eksik: %s", replica.Name)
+			continue
+		}
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			select {
+			case s.probeSlots <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-s.probeSlots }()
+
+			current[i], errs[i] = s.containerManager.Get(ctx, id)
+		}(i, replica.ID)
+	}
+	wg.Wait()
+
+	return current, errs
+}
+
+// PauseReconcile stops the reconcile loop from acting until ResumeReconcile
+func (s *Scheduler) PauseReconcile() {
+	s.reconcile.mutex.Lock()
+	defer s.reconcile.mutex.Unlock()
+
+	if !s.reconcile.paused {
+		s.reconcile.paused = true
+		s.logger.Info("Reconcile döngüsü duraklatıldı")
+	}
+}
+
+// ResumeReconcile lets a paused reconcile loop act again from the next tick
+func (s *Scheduler) ResumeReconcile() {
+	s.reconcile.mutex.Lock()
+	defer s.reconcile.mutex.Unlock()
+
+	if s.reconcile.paused {
+		s.reconcile.paused = false
+		s.logger.Info("Reconcile döngüsü devam ettirildi")
+	}
+}
+
+// GetReconcileStatus returns the state of the reconcile loop
+func (s *Scheduler) GetReconcileStatus() ReconcileStatus {
+	s.reconcile.mutex.Lock()
+	defer s.reconcile.mutex.Unlock()
+
+	status := ReconcileStatus{
+		Active:   s.config.ReconcileInterval > 0 && !s.reconcile.paused,
+		Paused:   s.reconcile.paused,
+		Interval: s.config.ReconcileInterval.String(),
+	}
+	if !s.reconcile.lastRun.IsZero() {
+		lastRun := s.reconcile.lastRun
+		status.LastRun = &lastRun
+	}
+	return status
+}
+
+// reconcileOnce repairs every deployment once
+func (s *Scheduler) reconcileOnce(ctx context.Context) {
+	for _, deployment := range s.ListDeployments("") {
+		if ctx.Err() != nil {
+			return
+		}
+		s.reconcileDeployment(ctx, deployment)
+		s.autoPromote(ctx, deployment)
+	}
+}
+
+// reconcileDeployment recreates the failed replicas of a deployment and
+// creates missing ones. Replicas of auto-remove deployments that exited are
+// marked completed instead of being recreated. Replicas changed by a
+// concurrent scale or rollout are left for the next tick. Deployments whose
+// replicas a rollout or restart is replacing are skipped, since recreating a
+// replica it removed would race it for the replica name. During a paused
+// canary rollout each replica is repaired with the spec it is meant to run.
+func (s *Scheduler) reconcileDeployment(ctx context.Context, deployment *Deployment) {
+	s.mutex.RLock()
+	replacing := deployment.restarting || (deployment.Rollout != nil && deployment.Rollout.Phase == RolloutProgressing)
+	replicas := make([]*container.Container, len(deployment.Replicas))
+	copy(replicas, deployment.Replicas)
+	spec := deployment.replicaSpec()
+	status := deployment.Status
+	count := max(len(replicas), spec.Replicas)
+	specs := make([]container.DeploymentSpec, count)
+	digests := make([]string, count)
+	for i := range specs {
+		specs[i], digests[i] = deployment.replicaSpecAt(i)
+	}
+	s.mutex.RUnlock()
+
+	if replacing {
+		return
+	}
+
+	// Replicas of a waiting deployment start once its dependencies are ready
+	if status == StatusWaiting {
+		if !s.startWaiting(ctx, deployment) {
+			return
+		}
+		status = StatusCreating
+	}
+
+	// Finish a delete that was interrupted
+	if status == StatusDeleting {
+		if err := s.DeleteDeployment(ctx, spec.Namespace, deployment.Name, DeleteOptions{}); err != nil {
+			s.logger.WithError(err).WithField("deployment", deployment.Name).Warn("Yarım kalan deployment silme işlemi tamamlanamadı")
+		}
+		return
+	}
+
+	states, errs := s.probeReplicas(ctx, replicas)
+	for i, replica := range replicas {
+		if ctx.Err() != nil {
+			return
+		}
+		current, err := states[i], errs[i]
+		spec, digest := specs[i], digests[i]
+		missing := isMissing(replica)
+
+		// Replicas of a pinned deployment must run exactly the pinned image
+		drifted := !missing && err == nil && digest != "" && current.ImageID != digest
+		if !missing && err == nil && current.Status != "exited" && current.Status != "dead" && !drifted {
+			continue
+		}
+		if drifted {
+			s.logger.WithFields(logrus.Fields{
+				"deployment":   deployment.Name,
+				"index":        i,
+				"container_id": replica.ID,
+				"image_id":     current.ImageID,
+				"image_digest": digest,
+			}).Warn("Replica sabitlenen image'dan farklı, yeniden oluşturuluyor")
+		}
+
+		// Auto-removed replicas that exited or are gone have completed
+		if spec.Container.AutoRemove && !drifted && !missing {
+			if replica.Status != "completed" {
+				s.mutex.Lock()
+				replica.Status = "completed"
+				s.mutex.Unlock()
+				s.logger.WithFields(logrus.Fields{
+					"deployment":   deployment.Name,
+					"index":        i,
+					"container_id": replica.ID,
+				}).Info("Replica tamamlandı")
+			}
+			continue
+		}
+
+		// Keep a crashed container so logs --previous can read it
+		switch {
+		case missing:
+		case err == nil && !drifted:
+			s.retireReplica(ctx, deployment, i, replica)
+		default:
+			s.removeReplicas(ctx, []*container.Container{replica})
+		}
+		c, err := s.createReplica(ctx, spec, i)
+		if err != nil {
+			s.logger.WithError(err).WithFields(logrus.Fields{
+				"deployment": deployment.Name,
+				"index":      i,
+			}).Warn("Replica yeniden oluşturulamadı")
+			continue
+		}
+
+		s.mutex.Lock()
+		if s.deployments[deployment.ID] != deployment || i >= len(deployment.Replicas) || deployment.Replicas[i] != replica {
+			s.mutex.Unlock()
+			s.removeReplicas(ctx, []*container.Container{c})
+			continue
+		}
+		deployment.Replicas[i] = c
+		s.commitReconciled(deployment)
+		s.emit(EventReplicaRecreated, deployment.Spec.Namespace, deployment.Name, ReplicaEvent{
+			Deployment: deployment.Name,
+			Index:      i,
+			OldID:      replica.ID,
+			NewID:      c.ID,
+		})
+		s.mutex.Unlock()
+
+		s.logger.WithFields(logrus.Fields{
+			"deployment": deployment.Name,
+			"index":      i,
+			"old_id":     replica.ID,
+			"new_id":     c.ID,
+		}).Info("Replica yeniden oluşturuldu")
+	}
+
+	for i := len(replicas); i < spec.Replicas; i++ {
+		// An interrupted create may have left the container without a record
+		if status == StatusCreating {
+			s.removeStaleReplica(ctx, spec.Namespace, spec.Name, i)
+		}
+
+		c, err := s.createReplica(ctx, specs[i], i)
+		if err != nil {
+			s.logger.WithError(err).WithFields(logrus.Fields{
+				"deployment": deployment.Name,
+				"index":      i,
+			}).Warn("Eksik replica oluşturulamadı")
+			return
+		}
+
+		s.mutex.Lock()
+		if s.deployments[deployment.ID] != deployment || len(deployment.Replicas) != i {
+			s.mutex.Unlock()
+			s.removeReplicas(ctx, []*container.Container{c})
+			return
+		}
+		deployment.Replicas = append(deployment.Replicas, c)
+		s.commitReconciled(deployment)
+		s.emit(EventReplicaRecreated, deployment.Spec.Namespace, deployment.Name, ReplicaEvent{
+			Deployment: deployment.Name,
+			Index:      i,
+			NewID:      c.ID,
+		})
+		s.mutex.Unlock()
+
+		s.logger.WithFields(logrus.Fields{
+			"deployment": deployment.Name,
+			"index":      i,
+			"new_id":     c.ID,
+		}).Info("Eksik replica oluşturuldu")
+	}
+
+	// A create interrupted after its last replica only needs its status
+	s.mutex.Lock()
+	if s.deployments[deployment.ID] == deployment && deployment.Status == StatusCreating && len(deployment.Replicas) >= deployment.Spec.Replicas {
+		s.commitReconciled(deployment)
+	}
+	s.mutex.Unlock()
+}
+
+// removeStaleReplica removes the container of replica index of a deployment
+// if it exists but is not recorded
+func (s *Scheduler) removeStaleReplica(ctx context.Context, namespace, name string, index int) {
+	replicaName, err := s.config.ReplicaName(name, index)
+	if err != nil {
+		return
+	}
+	c, err := s.containerManager.Get(ctx, s.containerManager.DockerName(namespace, replicaName))
+	if err != nil || c.Labels[container.DeploymentLabel] != name || c.Namespace != namespace {
+		return
+	}
+	s.removeReplicas(ctx, []*container.Container{c})
+}
+
+// commitReconciled marks a deployment running again once all replicas exist
+// and commits it. Caller must hold the scheduler mutex.
+func (s *Scheduler) commitReconciled(deployment *Deployment) {
+	if len(deployment.Replicas) >= deployment.Spec.Replicas && len(deployment.missingReplicas()) == 0 {
+		if err := deployment.setStatus(StatusRunning); err != nil {
+			s.logger.WithError(err).Warn("Deployment durumu güncellenemedi")
+		}
+	}
+	if err := s.commitDeployment(deployment); err != nil {
+		s.logger.WithError(err).WithField("deployment_id", deployment.ID).Warn("Deployment kaydedilemedi")
+	}
+}
@@ -0,0 +1,232 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"orca/pkg/container"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ReplicaEvent is the object of replica level deployment events
+type ReplicaEvent struct {
+	Deployment string `json:"deployment"`
+	Index      int    `json:"index"`
+	OldID      string `json:"old_id"`
+	NewID      string `json:"new_id,omitempty"`
+}
+
+// RestartDeployment recycles every replica of a deployment one at a time,
+// replacing each with a fresh container of the same spec
+func (s *Scheduler) RestartDeployment(ctx context.Context, namespace, name string) (*Deployment, error) {
+	s.mutex.Lock()
+	deployment := s.findDeployment(namespace, name)
+	if deployment == nil {
+		s.mutex.Unlock()
+		return nil, fmt.Errorf("deployment bulunamadı: %s", name)
+	}
+	if deployment.busy() {
+		s.mutex.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrRolloutInProgress, name)
+	}
+	// Scales and updates are refused until the restart is done
+	deployment.restarting = true
+	spec := deployment.replicaSpec()
+	count := len(deployment.Replicas)
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		deployment.restarting = false
+		s.mutex.Unlock()
+	}()
+
+	if err := s.rollingReplace(ctx, deployment, spec, 0, count, nil); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"deployment_id": deployment.ID,
+		"name":          name,
+	}).Info("Deployment yeniden başlatıldı")
+
+	s.mutex.RLock()
+	s.emit(EventDeploymentRestarted, deployment.Spec.Namespace, name, deployment)
+	s.mutex.RUnlock()
+
+	return deployment, nil
+}
+
+// rollingReplace replaces the replicas from index first up to last of a
+// deployment one at a time with containers built from spec. Each replica is
+// stopped and removed before its replacement is created, since both share the
+// same name and host ports; with the Recreate strategy all replicas in the
+// range are removed up front instead. A replacement must keep running for
+// MinReadySeconds before the next one is replaced. replaced, if set, is
+// called with the scheduler mutex held after each successful replacement. If
+// a replacement fails, the rollout stops, the slots of the failed replica and
+// of the replicas already removed are kept as missing placeholders for the
+// reconcile loop to fill, and the deployment is marked degraded. Keeping the
+// slots keeps every replica at the index its name and ports derive from.
+func (s *Scheduler) rollingReplace(ctx context.Context, deployment *Deployment, spec container.DeploymentSpec, first, last int, replaced func(index int)) error {
+	s.mutex.RLock()
+	last = min(last, len(deployment.Replicas))
+	originals := make([]*container.Container, len(deployment.Replicas))
+	copy(originals, deployment.Replicas)
+	s.mutex.RUnlock()
+
+	recreate := spec.Strategy == container.StrategyRecreate
+	if recreate && first < last {
+		s.removeReplicas(ctx, originals[first:last])
+	}
+	minReady := time.Duration(spec.MinReadySeconds) * time.Second
+
+	for i := first; i < last; i++ {
+		s.mutex.RLock()
+		if i >= len(deployment.Replicas) {
+			s.mutex.RUnlock()
+			break
+		}
+		old := deployment.Replicas[i]
+		s.mutex.RUnlock()
+
+		if !recreate {
+			s.removeReplicas(ctx, []*container.Container{old})
+		}
+
+		c, err := s.createReplica(ctx, spec, i)
+		if err == nil {
+			err = s.waitReady(ctx, c, minReady)
+			if err != nil {
+				s.removeReplicas(ctx, []*container.Container{c})
+			}
+		}
+
+		s.mutex.Lock()
+		if err != nil {
+			replaceReplica(deployment.Replicas, old, missingReplica(old))
+			if recreate {
+				for _, pending := range originals[i+1 : last] {
+					replaceReplica(deployment.Replicas, pending, missingReplica(pending))
+				}
+			}
+			if statusErr := deployment.setStatus(StatusDegraded); statusErr != nil {
+				s.logger.WithError(statusErr).Warn("Deployment durumu güncellenemedi")
+			}
+		} else {
+			replaceReplica(deployment.Replicas, old, c)
+			if replaced != nil {
+				replaced(i)
+			}
+		}
+		if commitErr := s.commitDeployment(deployment); commitErr != nil {
+			s.logger.WithError(commitErr).WithField("deployment_id", deployment.ID).Warn("Deployment kaydedilemedi")
+		}
+
+		event := ReplicaEvent{Deployment: deployment.Name, Index: i, OldID: old.ID}
+		if err != nil {
+			s.emit(EventReplicaFailed, deployment.Spec.Namespace, deployment.Name, event)
+		} else {
+			event.NewID = c.ID
+			s.emit(EventReplicaRecycled, deployment.Spec.Namespace, deployment.Name, event)
+		}
+		s.mutex.Unlock()
+
+		if err != nil {
+			return fmt.Errorf("replica %d yenilenemedi: %w", i, err)
+		}
+
+		s.logger.WithFields(logrus.Fields{
+			"deployment": deployment.Name,
+			"index":      i,
+			"old_id":     old.ID,
+			"new_id":     c.ID,
+		}).Info("Replica yenilendi")
+	}
+
+	return nil
+}
+
+// waitReady verifies that a freshly started replica is running and, for a
+// positive minReady, keeps running without a restart for that long. This
+// catches replicas that start and crash right after.
+func (s *Scheduler) waitReady(ctx context.Context, c *container.Container, minReady time.Duration) error {
+	first, err := s.checkRunning(ctx, c.ID, nil)
+	if err != nil || minReady <= 0 {
+		return err
+	}
+
+	deadline := time.NewTimer(minReady)
+	defer deadline.Stop()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		done := false
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			done = true
+		case <-ticker.C:
+		}
+
+		if _, err := s.checkRunning(ctx, c.ID, first.Started); err != nil {
+			return fmt.Errorf("replica %s içinde hazır kalmadı: %w", minReady, err)
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// checkRunning returns the container if it is running and not reported
+// unhealthy by its health check. A non-nil started time must match the
+// container's start time, so restarts are detected.
+func (s *Scheduler) checkRunning(ctx context.Context, containerID string, started *time.Time) (*container.Container, error) {
+	current, err := s.containerManager.Get(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status != "running" {
+		return nil, fmt.Errorf("replica çalışmıyor (durum: %s)", current.Status)
+	}
+	if current.Health == container.HealthUnhealthy {
+		return nil, fmt.Errorf("replica sağlıksız (health check başarısız)")
+	}
+	if started != nil && (current.Started == nil || !current.Started.Equal(*started)) {
+		return nil, fmt.Errorf("replica yeniden başlatıldı")
+	}
+	return current, nil
+}
+
+// replaceReplica swaps old for replacement in replicas
+func replaceReplica(replicas []*container.Container, old, replacement *container.Container) {
+	for i, r := range replicas {
+		if r == old {
+			replicas[i] = replacement
+			return
+		}
+	}
+}
+
+// ReplicaMissing is the status of the placeholder of a replica whose
+// container is gone and not replaced yet
+const ReplicaMissing = "missing"
+
+// missingReplica returns the placeholder keeping the slot of a removed
+// replica until the reconcile loop recreates it
+func missingReplica(old *container.Container) *container.Container {
+	return &container.Container{
+		Name:      old.Name,
+		Namespace: old.Namespace,
+		Status:    ReplicaMissing,
+	}
+}
+
+// isMissing reports whether a replica is the placeholder of a missing one
+func isMissing(replica *container.Container) bool {
+	return replica.ID == ""
+}
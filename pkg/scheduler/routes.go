@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"orca/pkg/container"
+)
+
+// Router fronts the replicas of proxy mode deployments on their published
+// host ports
+type Router interface {
+	// Sync sets the frontend port to backend addresses routes of a deployment
+	Sync(owner string, routes map[int][]string) error
+	// Remove drops every route of a deployment
+	Remove(owner string)
+}
+
+// SetRouter sets the router used for deployments published in proxy mode
+func (s *Scheduler) SetRouter(router Router) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.router = router
+}
+
+// syncRoutes points the router at the current replicas of a proxy mode
+// deployment. Caller must hold the scheduler mutex.
+func (s *Scheduler) syncRoutes(deployment *Deployment) {
+	if s.router == nil || deployment.Spec.PublishMode != container.PublishModeProxy {
+		return
+	}
+
+	if err := s.router.Sync(routeOwner(deployment), proxyRoutes(deployment)); err != nil {
+		s.logger.WithError(err).WithField("deployment", deployment.Name).Warn("Proxy yönlendirmeleri güncellenemedi")
+	}
+}
+
+// routeOwner identifies the routes of a deployment across namespaces
+func routeOwner(deployment *Deployment) string {
+	return container.QualifiedName(deployment.Spec.Namespace, deployment.Name)
+}
+
+// proxyRoutes maps every published host port of a deployment to the
+// ephemeral host ports of its replicas
+func proxyRoutes(deployment *Deployment) map[int][]string {
+	routes := make(map[int][]string)
+	for containerPort, hostPort := range deployment.Spec.Container.Ports {
+		frontend, err := strconv.Atoi(hostPort)
+		if err != nil {
+			continue
+		}
+
+		backends := []string{}
+		for _, replica := range deployment.Replicas {
+			if backendPort := replica.Ports[containerPort]; backendPort != "" {
+				backends = append(backends, "127.0.0.1:"+backendPort)
+			}
+		}
+		routes[frontend] = backends
+	}
+	return routes
+}
+
+// resolveReplicaPorts replaces the requested ports of a started replica with
+// the host ports Docker actually bound
+func (s *Scheduler) resolveReplicaPorts(ctx context.Context, c *container.Container) error {
+	live, err := s.containerManager.Get(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+
+	if unbound := applyLivePorts(c, live); len(unbound) > 0 {
+		return fmt.Errorf("port %s için host portu atanmadı", unbound[0])
+	}
+	return nil
+}
+
+// applyLivePorts replaces the host ports of a replica with those bound to its
+// running container and returns the container ports Docker left unbound,
+// which keep their previous host port
+func applyLivePorts(c, live *container.Container) []string {
+	var unbound []string
+	ports := make(map[string]string, len(c.Ports))
+	for containerPort, hostPort := range c.Ports {
+		ports[containerPort] = hostPort
+		portNum, protocol, err := container.ParsePortKey(containerPort)
+		if err != nil {
+			continue
+		}
+		if liveHostPort, ok := live.Ports[fmt.Sprintf("%d/%s", portNum, protocol)]; ok {
+			ports[containerPort] = liveHostPort
+		} else {
+			unbound = append(unbound, containerPort)
+		}
+	}
+	c.Ports = ports
+	sort.Strings(unbound)
+	return unbound
+}
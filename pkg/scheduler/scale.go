@@ -0,0 +1,209 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"orca/pkg/container"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ScaleStatus reports the desired replica count of a deployment, the
+// replicas it has and how many of them are ready
+type ScaleStatus struct {
+	Name    string `json:"name"`
+	Desired int    `json:"desired"`
+	Current int    `json:"current"`
+	Ready   int    `json:"ready"`
+}
+
+// GetScale reports the replica counts of a deployment without the rest of
+// the deployment, for clients that poll to decide whether to scale
+func (s *Scheduler) GetScale(ctx context.Context, namespace, name string) (*ScaleStatus, error) {
+	s.mutex.RLock()
+	deployment := s.findDeployment(namespace, name)
+	if deployment == nil {
+		s.mutex.RUnlock()
+		return nil, fmt.Errorf("deployment bulunamadı: %s", name)
+	}
+	desired := deployment.Spec.Replicas
+	replicas := make([]*container.Container, len(deployment.Replicas))
+	copy(replicas, deployment.Replicas)
+	s.mutex.RUnlock()
+
+	return s.scaleStatus(ctx, name, desired, replicas), nil
+}
+
+// scaleStatus probes replicas and counts the ready ones
+func (s *Scheduler) scaleStatus(ctx context.Context, name string, desired int, replicas []*container.Container) *ScaleStatus {
+	status := &ScaleStatus{
+		Name:    name,
+		Desired: desired,
+		Current: len(replicas),
+	}
+
+	states, errs := s.probeReplicas(ctx, replicas)
+	for i := range states {
+		if errs[i] == nil && states[i].Ready() {
+			status.Ready++
+		}
+	}
+	return status
+}
+
+// ScaleDeployment changes the replica count of a deployment. Docker work is
+// done outside the scheduler lock; the in-memory replicas, the persisted
+// record and the service endpoints are then committed in a single critical
+// section. If the commit fails, all three are rolled back.
+func (s *Scheduler) ScaleDeployment(ctx context.Context, namespace, name string, replicas int) (*ScaleStatus, error) {
+	if replicas < 0 {
+		return nil, fmt.Errorf("replica sayısı negatif olamaz: %d", replicas)
+	}
+
+	s.mutex.RLock()
+	deployment := s.findDeployment(namespace, name)
+	if deployment == nil {
+		s.mutex.RUnlock()
+		return nil, fmt.Errorf("deployment bulunamadı: %s", name)
+	}
+	if deployment.busy() {
+		s.mutex.RUnlock()
+		return nil, fmt.Errorf("%w: %s", ErrRolloutInProgress, name)
+	}
+	if deployment.Status == StatusWaiting {
+		s.mutex.RUnlock()
+		return nil, fmt.Errorf("%w: %s", ErrWaitingForDependencies, name)
+	}
+	current := len(deployment.Replicas)
+	spec := deployment.replicaSpec()
+	s.mutex.RUnlock()
+
+	// Start new replicas before touching any state
+	var created []*container.Container
+	for i := current; i < replicas; i++ {
+		c, err := s.createReplica(ctx, spec, i)
+		if err != nil {
+			s.removeReplicas(ctx, created)
+			return nil, err
+		}
+		created = append(created, c)
+	}
+
+	s.mutex.Lock()
+	if s.deployments[deployment.ID] != deployment || len(deployment.Replicas) != current {
+		s.mutex.Unlock()
+		s.removeReplicas(ctx, created)
+		return nil, fmt.Errorf("deployment ölçeklendirme sırasında değişti: %s", name)
+	}
+
+	prevReplicas := deployment.Replicas
+	prevDesired := deployment.Spec.Replicas
+
+	var removed []*container.Container
+	newReplicas := make([]*container.Container, 0, replicas)
+	if replicas >= current {
+		newReplicas = append(newReplicas, prevReplicas...)
+		newReplicas = append(newReplicas, created...)
+	} else {
+		newReplicas = append(newReplicas, prevReplicas[:replicas]...)
+		removed = prevReplicas[replicas:]
+	}
+
+	deployment.Replicas = newReplicas
+	deployment.Spec.Replicas = replicas
+	dropped := deployment.dropPreviousReplicas(replicas)
+
+	if err := s.commitDeployment(deployment); err != nil {
+		// Roll back in-memory state, endpoints and the persisted record
+		deployment.Replicas = prevReplicas
+		deployment.Spec.Replicas = prevDesired
+		for i, id := range dropped {
+			deployment.PreviousReplicas[i] = id
+		}
+		s.refreshServiceEndpoints()
+		if rbErr := s.commitDeployment(deployment); rbErr != nil {
+			s.logger.WithError(rbErr).WithField("deployment_id", deployment.ID).Error("Deployment kaydı geri alınamadı")
+		}
+		s.mutex.Unlock()
+
+		s.removeReplicas(ctx, created)
+		return nil, fmt.Errorf("deployment kaydedilemedi: %w", err)
+	}
+	s.mutex.Unlock()
+
+	// Old replicas are only removed once the new state is committed
+	for _, id := range dropped {
+		removed = append(removed, &container.Container{ID: id})
+	}
+	s.removeReplicas(ctx, removed)
+
+	s.logger.WithFields(logrus.Fields{
+		"deployment_id": deployment.ID,
+		"name":          name,
+		"from":          current,
+		"to":            replicas,
+	}).Info("Deployment ölçeklendirildi")
+
+	s.mutex.RLock()
+	s.emit(EventDeploymentScaled, deployment.Spec.Namespace, name, deployment)
+	s.mutex.RUnlock()
+
+	return s.scaleStatus(ctx, name, replicas, newReplicas), nil
+}
+
+// commitDeployment refreshes service endpoints and proxy routes and persists
+// the deployment together with the services. Caller must hold the scheduler mutex.
+func (s *Scheduler) commitDeployment(deployment *Deployment) error {
+	deployment.MissingReplicas = deployment.missingReplicas()
+	s.refreshServiceEndpoints()
+	s.syncRoutes(deployment)
+
+	if err := s.persistDeployment(deployment); err != nil {
+		return err
+	}
+	return s.persistServices()
+}
+
+// missingReplicas returns the replica indices a deployment should have but
+// has not created, including the slots of replicas a failed rollout left
+// missing. Waiting deployments have not started any replica yet and report
+// none.
+func (d *Deployment) missingReplicas() []int {
+	if d.Status == StatusWaiting {
+		return nil
+	}
+	var missing []int
+	for i, replica := range d.Replicas {
+		if i < d.Spec.Replicas && isMissing(replica) {
+			missing = append(missing, i)
+		}
+	}
+	for i := len(d.Replicas); i < d.Spec.Replicas; i++ {
+		missing = append(missing, i)
+	}
+	return missing
+}
+
+// busy reports whether a rollout or restart is replacing the replicas of a
+// deployment. Caller must hold the scheduler mutex.
+func (d *Deployment) busy() bool {
+	return d.Rollout != nil || d.restarting
+}
+
+// findDeployment finds a deployment by namespace and name. Caller must hold
+// the scheduler mutex.
+func (s *Scheduler) findDeployment(namespace, name string) *Deployment {
+	namespace = container.NormalizeNamespace(namespace)
+	for _, d := range s.deployments {
+		if d.Name == name && d.Spec.Namespace == namespace {
+			return d
+		}
+	}
+	return nil
+}
+
+// removeReplicas stops and removes the given replica containers
+func (s *Scheduler) removeReplicas(ctx context.Context, replicas []*container.Container) {
+	s.cleanupDeployment(ctx, &Deployment{Replicas: replicas})
+}
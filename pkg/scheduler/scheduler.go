@@ -2,11 +2,16 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
+	"text/template"
 	"time"
 
+	"orca/pkg/config"
 	"orca/pkg/container"
 
 	"github.com/sirupsen/logrus"
@@ -20,6 +25,21 @@ type Deployment struct {
 	Status    string                    `json:"status"`
 	Replicas  []*container.Container    `json:"replicas"`
 	Created   time.Time                 `json:"created"`
+	// ImageDigest is the image every replica runs when the spec pins digests
+	ImageDigest string `json:"image_digest,omitempty"`
+	// Rollout is the spec update in progress, if any
+	Rollout *Rollout `json:"rollout,omitempty"`
+	// WaitingFor lists the dependencies a waiting deployment still waits for
+	WaitingFor []string `json:"waiting_for,omitempty"`
+	// MissingReplicas lists the replica indices a degraded deployment lacks
+	// until the reconcile loop creates them
+	MissingReplicas []int `json:"missing_replicas,omitempty"`
+	// PreviousReplicas maps replica indices to the last exited container
+	// of the replica, kept when the reconcile loop replaced it
+	PreviousReplicas map[int]string `json:"previous_replicas,omitempty"`
+
+	// restarting is set while RestartDeployment replaces the replicas
+	restarting bool
 }
 
 // Service represents a service
@@ -30,139 +50,550 @@ type Service struct {
 	Endpoints []string               `json:"endpoints"`
 	Status    string                 `json:"status"`
 	Created   time.Time              `json:"created"`
+	// Health is the result of the last endpoint health check
+	Health *ServiceHealth `json:"health,omitempty"`
+}
+
+// DeploymentStatus is a concise, live summary of a deployment's replicas
+type DeploymentStatus struct {
+	Name        string `json:"name"`
+	Desired     int    `json:"desired"`
+	Ready       int    `json:"ready"`
+	Available   int    `json:"available"`
+	Unavailable int    `json:"unavailable"`
+	// ImageDigest is the pinned image; Drifted counts replicas running
+	// another image
+	ImageDigest string `json:"image_digest,omitempty"`
+	Drifted     int    `json:"drifted,omitempty"`
+	// Rollout is the progress of a spec update still in progress
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+	// WaitingFor lists the dependencies the deployment waits for before
+	// its replicas start
+	WaitingFor []string `json:"waiting_for,omitempty"`
+	// MissingReplicas lists the replica indices not created yet
+	MissingReplicas []int `json:"missing_replicas,omitempty"`
+}
+
+// BatchDeleteResult reports the outcome of deleting a single resource
+type BatchDeleteResult struct {
+	Name    string `json:"name"`
+	Deleted bool   `json:"deleted"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ErrDeploymentExists is returned when a deployment name is already taken in
+// its namespace
+var ErrDeploymentExists = errors.New("deployment zaten mevcut")
+
+// ErrDeploymentRefNotFound is returned when a service targets a deployment
+// that does not exist in its namespace
+var ErrDeploymentRefNotFound = errors.New("referans verilen deployment bulunamadı")
+
+// ErrNodePortOutOfRange is returned when a service requests a node port
+// outside the configured range
+var ErrNodePortOutOfRange = errors.New("node port izin verilen aralıkta değil")
+
+// ErrNodePortInUse is returned when a requested node port is already bound
+var ErrNodePortInUse = errors.New("node port zaten kullanımda")
+
+// ErrNodePortsExhausted is returned when no free node port is left
+var ErrNodePortsExhausted = errors.New("NodePort aralığında boş port kalmadı")
+
+// Store persists deployments and services
+type Store interface {
+	SaveDeployment(deployment *Deployment) error
+	DeleteDeployment(id string) error
+	SaveService(service *Service) error
+	DeleteService(id string) error
 }
 
 // Scheduler manages deployments and services
 type Scheduler struct {
+	config           config.SchedulerConfig
 	containerManager *container.Manager
+	store            Store
 	deployments      map[string]*Deployment
 	services         map[string]*Service
 	mutex            sync.RWMutex
+	handlers         []EventHandler
+	recentEvents     []Event
+	handlersMutex    sync.RWMutex
+	router           Router
+	reconcile        reconcileState
+	probeSlots       chan struct{}
 	logger           *logrus.Logger
 }
 
-// NewScheduler creates a new scheduler
-func NewScheduler(containerManager *container.Manager, logger *logrus.Logger) *Scheduler {
+// NewScheduler creates a new scheduler. store may be nil, in which case
+// state is kept in memory only.
+func NewScheduler(cfg config.SchedulerConfig, containerManager *container.Manager, store Store, logger *logrus.Logger) *Scheduler {
 	return &Scheduler{
+		config:           cfg,
 		containerManager: containerManager,
+		store:            store,
 		deployments:      make(map[string]*Deployment),
 		services:         make(map[string]*Service),
+		probeSlots:       make(chan struct{}, max(cfg.ReconcileConcurrency, 1)),
 		logger:           logger,
 	}
 }
 
-// CreateDeployment creates a new deployment
-func (s *Scheduler) CreateDeployment(ctx context.Context, spec container.DeploymentSpec) (*Deployment, error) {
+// ApplyDefaults fills in deployment spec fields left unset with the
+// configured defaults
+func (s *Scheduler) ApplyDefaults(spec *container.DeploymentSpec) {
+	if spec.Replicas == 0 {
+		spec.Replicas = s.config.DefaultReplicas
+	}
+	if spec.Container.PullPolicy == "" {
+		spec.Container.PullPolicy = s.config.DefaultPullPolicy
+	}
+	if spec.Strategy == "" {
+		spec.Strategy = s.config.DefaultStrategy
+	}
+	spec.Namespace = container.NormalizeNamespace(spec.Namespace)
+}
+
+// MaxReplicas returns the largest replica count a deployment may have
+func (s *Scheduler) MaxReplicas() int {
+	return s.config.MaxReplicas
+}
+
+// CreateOptions controls how a deployment is created
+type CreateOptions struct {
+	// Partial keeps the replicas created before one fails instead of
+	// removing them all. The deployment is then degraded and the reconcile
+	// loop creates the missing replicas; at least one replica must start.
+	Partial bool
+}
+
+// CreateDeployment creates a new deployment. Names are unique within a
+// namespace.
+func (s *Scheduler) CreateDeployment(ctx context.Context, spec container.DeploymentSpec, opts CreateOptions) (*Deployment, error) {
+	spec.Namespace = container.NormalizeNamespace(spec.Namespace)
+
+	// Checked first so nothing is probed or pulled for a create that
+	// cannot happen
+	s.mutex.RLock()
+	err := s.checkCreatable(spec)
+	var dependencies []dependencySnapshot
+	if err == nil {
+		dependencies = s.snapshotDependencies(spec)
+	}
+	s.mutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Replicas start once every dependency is ready; until then the
+	// reconcile loop keeps checking. Probing and resolving the digest talk
+	// to Docker, so they run without the mutex held.
+	pending := s.pendingDependencies(ctx, dependencies)
+	if len(pending) > 0 && s.config.ReconcileInterval <= 0 {
+		return nil, fmt.Errorf("%w: %s (reconcile döngüsü kapalıyken bağımlılıklar hazır olmalıdır)", ErrWaitingForDependencies, strings.Join(pending, ", "))
+	}
+
+	// The image is pulled by the digest lookup or else by the first replica
+	if spec.PinDigest || len(pending) == 0 {
+		s.reportPull(ctx, spec)
+	}
+	digest := ""
+	if spec.PinDigest {
+		digest, err = s.containerManager.ResolveImage(ctx, spec.Container.Image, spec.Container.PullPolicy, spec.Container.Platform)
+		if err != nil {
+			return nil, fmt.Errorf("image digest'i çözümlenemedi: %w", err)
+		}
+	}
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	// Check if deployment already exists
-	for _, d := range s.deployments {
-		if d.Name == spec.Name {
-			return nil, fmt.Errorf("deployment zaten mevcut: %s", spec.Name)
-		}
+	// Another request may have created it in the meantime
+	if err := s.checkCreatable(spec); err != nil {
+		return nil, err
 	}
 
 	deployment := &Deployment{
-		ID:       generateID(),
-		Name:     spec.Name,
-		Spec:     spec,
-		Status:   "creating",
-		Replicas: make([]*container.Container, 0, spec.Replicas),
-		Created:  time.Now(),
+		ID:          generateID(),
+		Name:        spec.Name,
+		Spec:        spec,
+		Status:      StatusCreating,
+		Replicas:    make([]*container.Container, 0, spec.Replicas),
+		Created:     time.Now(),
+		ImageDigest: digest,
+	}
+	replicaSpec := deployment.replicaSpec()
+
+	if len(pending) > 0 {
+		reportProgress(ctx, Progress{
+			Stage:   ProgressWaiting,
+			Message: fmt.Sprintf("bağımlılıkların hazır olması bekleniyor: %s", strings.Join(pending, ", ")),
+		})
+		return s.createWaiting(deployment, pending)
 	}
 
-	// Create containers for replicas
+	// Persist the creating record before any container exists, so a crash
+	// never leaves containers without a record
+	if err := s.persistDeployment(deployment); err != nil {
+		return nil, fmt.Errorf("deployment kaydedilemedi: %w", err)
+	}
+
+	// Create containers for replicas, recording each one as it is created.
+	// Replica names and ports follow their index, so a partial create stops
+	// at the first failure and leaves the remaining indices to reconcile.
+	var replicaErr error
 	for i := 0; i < spec.Replicas; i++ {
-		containerSpec := spec.Container
-		containerSpec.Name = fmt.Sprintf("%s-%d", spec.Name, i)
-
-		// Assign unique ports for each replica
-		if containerSpec.Ports != nil {
-			ports := make(map[string]string)
-			for containerPort, baseHostPort := range containerSpec.Ports {
-				hostPort := fmt.Sprintf("%d", mustParseInt(baseHostPort)+i)
-				ports[containerPort] = hostPort
+		reportProgress(ctx, Progress{
+			Stage:    ProgressCreating,
+			Message:  fmt.Sprintf("replica %d/%d oluşturuluyor", i+1, spec.Replicas),
+			Replica:  i + 1,
+			Replicas: spec.Replicas,
+		})
+		c, err := s.createReplica(ctx, replicaSpec, i)
+		if err != nil {
+			if !opts.Partial || i == 0 {
+				s.abortCreate(ctx, deployment)
+				return nil, err
 			}
-			containerSpec.Ports = ports
+			replicaErr = err
+			break
 		}
+		reportProgress(ctx, Progress{
+			Stage:    ProgressStarted,
+			Message:  fmt.Sprintf("replica %d/%d başlatıldı", i+1, spec.Replicas),
+			Replica:  i + 1,
+			Replicas: spec.Replicas,
+		})
 
-		c, err := s.containerManager.Create(ctx, containerSpec)
-		if err != nil {
-			// Cleanup created containers on error
-			s.cleanupDeployment(ctx, deployment)
-			return nil, fmt.Errorf("container oluşturulamadı (replica %d): %w", i, err)
+		deployment.Replicas = append(deployment.Replicas, c)
+		if err := s.persistDeployment(deployment); err != nil {
+			s.logger.WithError(err).WithField("deployment_id", deployment.ID).Warn("Deployment kaydedilemedi")
 		}
+	}
 
-		err = s.containerManager.Start(ctx, c.ID)
-		if err != nil {
-			// Cleanup created containers on error
-			s.cleanupDeployment(ctx, deployment)
-			return nil, fmt.Errorf("container başlatılamadı (replica %d): %w", i, err)
-		}
+	if replicaErr != nil {
+		deployment.setStatus(StatusDegraded)
+		deployment.MissingReplicas = deployment.missingReplicas()
+	} else {
+		deployment.setStatus(StatusRunning)
+	}
+	s.deployments[deployment.ID] = deployment
+	s.refreshServiceEndpoints()
+	s.syncRoutes(deployment)
 
-		c.Status = "running"
-		deployment.Replicas = append(deployment.Replicas, c)
+	// If this fails the record stays creating and the reconcile loop
+	// completes it
+	if err := s.persistDeployment(deployment); err != nil {
+		s.logger.WithError(err).WithField("deployment_id", deployment.ID).Warn("Deployment kaydedilemedi")
+	}
+
+	if replicaErr != nil {
+		s.logger.WithError(replicaErr).WithFields(logrus.Fields{
+			"deployment_id": deployment.ID,
+			"name":          deployment.Name,
+			"replicas":      len(deployment.Replicas),
+			"missing":       deployment.MissingReplicas,
+		}).Warn("Deployment eksik replica'larla oluşturuldu")
+	} else {
+		s.logger.WithFields(logrus.Fields{
+			"deployment_id": deployment.ID,
+			"name":          deployment.Name,
+			"replicas":      spec.Replicas,
+		}).Info("Deployment oluşturuldu")
 	}
 
-	deployment.Status = "running"
+	s.emit(EventDeploymentCreated, deployment.Spec.Namespace, deployment.Name, deployment)
+
+	return deployment, nil
+}
+
+// checkCreatable checks that the name of spec is free in its namespace and
+// that its dependencies form no cycle. Caller must hold the scheduler mutex.
+func (s *Scheduler) checkCreatable(spec container.DeploymentSpec) error {
+	if s.findDeployment(spec.Namespace, spec.Name) != nil {
+		return fmt.Errorf("%w: %s", ErrDeploymentExists, spec.Name)
+	}
+	return s.checkDependencyCycle(spec)
+}
+
+// reportPull reports that the image of spec is pulled, or checked and
+// pulled if missing, before its first replica is created
+func (s *Scheduler) reportPull(ctx context.Context, spec container.DeploymentSpec) {
+	image := spec.Container.Image
+	switch spec.Container.PullPolicy {
+	case container.PullAlways:
+		reportProgress(ctx, Progress{Stage: ProgressPulling, Message: fmt.Sprintf("image çekiliyor: %s", image)})
+	case container.PullMissing:
+		reportProgress(ctx, Progress{Stage: ProgressPulling, Message: fmt.Sprintf("image yerelde yoksa çekiliyor: %s", image)})
+	}
+}
+
+// createWaiting records a deployment whose dependencies are not ready yet
+// without creating any replica. Caller must hold the scheduler mutex.
+func (s *Scheduler) createWaiting(deployment *Deployment, pending []string) (*Deployment, error) {
+	deployment.Status = StatusWaiting
+	deployment.WaitingFor = pending
+	if err := s.persistDeployment(deployment); err != nil {
+		return nil, fmt.Errorf("deployment kaydedilemedi: %w", err)
+	}
 	s.deployments[deployment.ID] = deployment
 
 	s.logger.WithFields(logrus.Fields{
 		"deployment_id": deployment.ID,
 		"name":          deployment.Name,
-		"replicas":      spec.Replicas,
-	}).Info("Deployment oluşturuldu")
+		"waiting_for":   pending,
+	}).Info("Deployment bağımlılıklarını bekliyor")
+
+	s.emit(EventDeploymentCreated, deployment.Spec.Namespace, deployment.Name, deployment)
 
 	return deployment, nil
 }
 
-// GetDeployment gets a deployment by name
-func (s *Scheduler) GetDeployment(name string) (*Deployment, error) {
+// abortCreate removes the containers and the record of a deployment whose
+// creation failed
+func (s *Scheduler) abortCreate(ctx context.Context, deployment *Deployment) {
+	deployment.setStatus(StatusDeleting)
+	if err := s.persistDeployment(deployment); err != nil {
+		s.logger.WithError(err).WithField("deployment_id", deployment.ID).Warn("Deployment kaydedilemedi")
+	}
+
+	s.cleanupDeployment(ctx, deployment)
+	deployment.setStatus(StatusDeleted)
+
+	if s.store != nil {
+		if err := s.store.DeleteDeployment(deployment.ID); err != nil {
+			s.logger.WithError(err).WithField("deployment_id", deployment.ID).Warn("Deployment kaydı silinemedi")
+		}
+	}
+}
+
+// RestoreDeployments registers deployments loaded from storage. Deployments
+// left creating or deleting by a crash are finished by the reconcile loop.
+func (s *Scheduler) RestoreDeployments(deployments []*Deployment) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, deployment := range deployments {
+		// Records written before namespaces existed belong to the default one
+		deployment.Spec.Namespace = container.NormalizeNamespace(deployment.Spec.Namespace)
+		if s.findDeployment(deployment.Spec.Namespace, deployment.Name) != nil {
+			continue
+		}
+		// A rollout interrupted by the restart waits to be promoted or aborted
+		if deployment.Rollout != nil {
+			deployment.Rollout.Phase = RolloutPaused
+		}
+		s.deployments[deployment.ID] = deployment
+		s.syncRoutes(deployment)
+	}
+	s.refreshServiceEndpoints()
+}
+
+// RestoreServices registers services loaded from storage. Their persisted
+// endpoints are kept until RefreshEndpoints recomputes them.
+func (s *Scheduler) RestoreServices(services []*Service) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, svc := range services {
+		svc.Spec.Namespace = container.NormalizeNamespace(svc.Spec.Namespace)
+		if s.findService(svc.Spec.Namespace, svc.Name) != nil {
+			continue
+		}
+		s.services[svc.ID] = svc
+	}
+}
+
+// RefreshEndpoints reads the live state of every replica and recomputes the
+// service endpoints from the running ones. Docker assigns new ephemeral host
+// ports when it restarts a container, so endpoints persisted before a
+// restart may point at ports that moved.
+func (s *Scheduler) RefreshEndpoints(ctx context.Context) {
 	s.mutex.RLock()
-	defer s.mutex.RUnlock()
+	deployments := make([]*Deployment, 0, len(s.deployments))
+	for _, deployment := range s.deployments {
+		deployments = append(deployments, deployment)
+	}
+	s.mutex.RUnlock()
 
-	for _, d := range s.deployments {
-		if d.Name == name {
-			return d, nil
+	for _, deployment := range deployments {
+		s.mutex.RLock()
+		replicas := make([]*container.Container, len(deployment.Replicas))
+		copy(replicas, deployment.Replicas)
+		s.mutex.RUnlock()
+
+		states, errs := s.probeReplicas(ctx, replicas)
+
+		s.mutex.Lock()
+		for i, replica := range replicas {
+			switch {
+			case errs[i] != nil:
+				// Left for the reconcile loop to recreate
+				if replica.Status != "completed" {
+					replica.Status = "missing"
+				}
+			case states[i].Status == "running":
+				replica.Status = states[i].Status
+				applyLivePorts(replica, states[i])
+			default:
+				replica.Status = states[i].Status
+			}
+		}
+		s.syncRoutes(deployment)
+		if err := s.persistDeployment(deployment); err != nil {
+			s.logger.WithError(err).WithField("deployment_id", deployment.ID).Warn("Deployment kaydedilemedi")
 		}
+		s.mutex.Unlock()
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.refreshServiceEndpoints()
+	if err := s.persistServices(); err != nil {
+		s.logger.WithError(err).Warn("Service'ler kaydedilemedi")
+	}
+	s.logger.WithField("services", len(s.services)).Info("Service endpoint'leri yeniden hesaplandı")
+}
+
+// GetDeployment gets a deployment by namespace and name
+func (s *Scheduler) GetDeployment(namespace, name string) (*Deployment, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if d := s.findDeployment(namespace, name); d != nil {
+		return d, nil
 	}
 
 	return nil, fmt.Errorf("deployment bulunamadı: %s", name)
 }
 
-// ListDeployments lists all deployments
-func (s *Scheduler) ListDeployments() []*Deployment {
+// GetDeploymentStatus computes a live status summary for a deployment by
+// inspecting the current state of each replica container
+func (s *Scheduler) GetDeploymentStatus(ctx context.Context, namespace, name string) (*DeploymentStatus, error) {
+	deployment, err := s.GetDeployment(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.RLock()
+	replicas := make([]*container.Container, len(deployment.Replicas))
+	copy(replicas, deployment.Replicas)
+	desired := deployment.Spec.Replicas
+	digest := deployment.ImageDigest
+	digests := make([]string, len(replicas))
+	for i := range digests {
+		_, digests[i] = deployment.replicaSpecAt(i)
+	}
+	var rollout *RolloutStatus
+	if deployment.Rollout != nil {
+		rollout = deployment.Rollout.status(deployment)
+	}
+	waitingFor := deployment.WaitingFor
+	missing := deployment.missingReplicas()
+	s.mutex.RUnlock()
+
+	status := &DeploymentStatus{
+		Name:            name,
+		Desired:         desired,
+		ImageDigest:     digest,
+		Rollout:         rollout,
+		WaitingFor:      waitingFor,
+		MissingReplicas: missing,
+	}
+
+	for i, replica := range replicas {
+		c, err := s.containerManager.Get(ctx, replica.ID)
+		if err != nil {
+			s.logger.WithError(err).WithField("container_id", replica.ID).Debug("Replica durumu alınamadı")
+			continue
+		}
+		if c.Ready() {
+			status.Ready++
+		}
+		if digests[i] != "" && c.ImageID != digests[i] {
+			status.Drifted++
+		}
+	}
+
+	// A replica is considered available as soon as it is ready
+	status.Available = status.Ready
+	status.Unavailable = status.Desired - status.Available
+	if status.Unavailable < 0 {
+		status.Unavailable = 0
+	}
+
+	return status, nil
+}
+
+// ListDeployments lists the deployments of a namespace, or of all
+// namespaces if namespace is empty
+func (s *Scheduler) ListDeployments(namespace string) []*Deployment {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
 	deployments := make([]*Deployment, 0, len(s.deployments))
 	for _, d := range s.deployments {
-		deployments = append(deployments, d)
+		if namespace == "" || d.Spec.Namespace == namespace {
+			deployments = append(deployments, d)
+		}
+	}
+
+	sortDeployments(deployments)
+	return deployments
+}
+
+// ListDeploymentsBySelector lists deployments of a namespace (all namespaces
+// if empty) whose container labels match selector, sorted by name
+func (s *Scheduler) ListDeploymentsBySelector(namespace string, selector map[string]string) []*Deployment {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	deployments := make([]*Deployment, 0)
+	for _, d := range s.deployments {
+		if (namespace == "" || d.Spec.Namespace == namespace) && matchesSelector(selector, d.Spec.Container.Labels) {
+			deployments = append(deployments, d)
+		}
 	}
 
+	sortDeployments(deployments)
 	return deployments
 }
 
+// sortDeployments sorts deployments by namespace and name
+func sortDeployments(deployments []*Deployment) {
+	sort.Slice(deployments, func(i, j int) bool {
+		if deployments[i].Spec.Namespace != deployments[j].Spec.Namespace {
+			return deployments[i].Spec.Namespace < deployments[j].Spec.Namespace
+		}
+		return deployments[i].Name < deployments[j].Name
+	})
+}
+
+// DeleteOptions controls what is removed together with a deployment
+type DeleteOptions struct {
+	// RemoveVolume also removes the shared volume of the deployment
+	RemoveVolume bool
+}
+
 // DeleteDeployment deletes a deployment
-func (s *Scheduler) DeleteDeployment(ctx context.Context, name string) error {
+func (s *Scheduler) DeleteDeployment(ctx context.Context, namespace, name string, opts DeleteOptions) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	var deployment *Deployment
-	var deploymentID string
-	
-	for id, d := range s.deployments {
-		if d.Name == name {
-			deployment = d
-			deploymentID = id
-			break
-		}
-	}
-
+	deployment := s.findDeployment(namespace, name)
 	if deployment == nil {
 		return fmt.Errorf("deployment bulunamadı: %s", name)
 	}
+	deploymentID := deployment.ID
+
+	// Mark the record deleting first so an interrupted delete is finished
+	// by the reconcile loop
+	if err := deployment.setStatus(StatusDeleting); err != nil {
+		return err
+	}
+	if err := s.persistDeployment(deployment); err != nil {
+		s.logger.WithError(err).WithField("deployment_id", deploymentID).Warn("Deployment kaydedilemedi")
+	}
 
 	// Stop and remove all containers
 	err := s.cleanupDeployment(ctx, deployment)
@@ -171,25 +602,72 @@ func (s *Scheduler) DeleteDeployment(ctx context.Context, name string) error {
 	}
 
 	delete(s.deployments, deploymentID)
+	deployment.setStatus(StatusDeleted)
+	s.refreshServiceEndpoints()
+	if s.router != nil {
+		s.router.Remove(routeOwner(deployment))
+	}
+
+	// The shared volume outlives the deployment unless removal is requested
+	if opts.RemoveVolume && deployment.Spec.SharedVolume != nil {
+		if err := s.containerManager.RemoveVolume(ctx, deployment.Spec.SharedVolume.Name); err != nil {
+			s.logger.WithError(err).WithField("deployment_id", deploymentID).Warn("Deployment volume'u silinemedi")
+		}
+	}
+
+	if s.store != nil {
+		if err := s.store.DeleteDeployment(deploymentID); err != nil {
+			s.logger.WithError(err).WithField("deployment_id", deploymentID).Warn("Deployment kaydı silinemedi")
+		}
+	}
 
 	s.logger.WithFields(logrus.Fields{
 		"deployment_id": deploymentID,
 		"name":          name,
 	}).Info("Deployment silindi")
 
+	s.emit(EventDeploymentDeleted, deployment.Spec.Namespace, name, deployment)
+
 	return nil
 }
 
-// CreateService creates a new service
+// DeleteDeploymentsBySelector deletes every deployment of a namespace whose
+// container labels match the selector and reports a result per deployment
+func (s *Scheduler) DeleteDeploymentsBySelector(ctx context.Context, namespace string, selector map[string]string) []BatchDeleteResult {
+	namespace = container.NormalizeNamespace(namespace)
+
+	s.mutex.RLock()
+	var names []string
+	for _, d := range s.deployments {
+		if d.Spec.Namespace == namespace && matchesSelector(selector, d.Spec.Container.Labels) {
+			names = append(names, d.Name)
+		}
+	}
+	s.mutex.RUnlock()
+
+	sort.Strings(names)
+	results := make([]BatchDeleteResult, 0, len(names))
+	for _, name := range names {
+		result := BatchDeleteResult{Name: name, Deleted: true}
+		if err := s.DeleteDeployment(ctx, namespace, name, DeleteOptions{}); err != nil {
+			result.Deleted = false
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// CreateService creates a new service. Names are unique within a namespace.
 func (s *Scheduler) CreateService(ctx context.Context, spec container.ServiceSpec) (*Service, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	// Check if service already exists
-	for _, svc := range s.services {
-		if svc.Name == spec.Name {
-			return nil, fmt.Errorf("service zaten mevcut: %s", spec.Name)
-		}
+	spec.Namespace = container.NormalizeNamespace(spec.Namespace)
+	if s.findService(spec.Namespace, spec.Name) != nil {
+		return nil, fmt.Errorf("service zaten mevcut: %s", spec.Name)
 	}
 
 	// Validate port conflicts
@@ -197,93 +675,418 @@ func (s *Scheduler) CreateService(ctx context.Context, spec container.ServiceSpe
 		return nil, fmt.Errorf("port çakışması: %w", err)
 	}
 
+	// Check that the referenced deployment exists in the same namespace
+	if spec.DeploymentRef != "" && s.findDeployment(spec.Namespace, spec.DeploymentRef) == nil {
+		return nil, fmt.Errorf("%w: %s", ErrDeploymentRefNotFound, spec.DeploymentRef)
+	}
+
+	// Allocate node ports for NodePort services
+	if spec.Type == "NodePort" {
+		ports, err := s.allocateNodePorts(spec.Ports)
+		if err != nil {
+			return nil, fmt.Errorf("node port atanamadı: %w", err)
+		}
+		spec.Ports = ports
+	}
+
 	service := &Service{
 		ID:        generateID(),
 		Name:      spec.Name,
 		Spec:      spec,
-		Endpoints: []string{},
-		Status:    "active",
+		Endpoints: s.resolveEndpoints(spec),
 		Created:   time.Now(),
 	}
+	service.Status = endpointStatus(service)
 
 	s.services[service.ID] = service
 
+	if s.store != nil {
+		if err := s.store.SaveService(service); err != nil {
+			s.logger.WithError(err).WithField("service_id", service.ID).Warn("Service kaydedilemedi")
+		}
+	}
+
 	s.logger.WithFields(logrus.Fields{
 		"service_id": service.ID,
 		"name":       service.Name,
 		"type":       spec.Type,
 	}).Info("Service oluşturuldu")
 
+	s.emit(EventServiceCreated, service.Spec.Namespace, service.Name, service)
+
 	return service, nil
 }
 
-// GetService gets a service by name
-func (s *Scheduler) GetService(name string) (*Service, error) {
+// GetService gets a service by namespace and name
+func (s *Scheduler) GetService(namespace, name string) (*Service, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	for _, svc := range s.services {
-		if svc.Name == name {
-			return svc, nil
-		}
+	if svc := s.findService(namespace, name); svc != nil {
+		return svc, nil
 	}
 
 	return nil, fmt.Errorf("service bulunamadı: %s", name)
 }
 
-// ListServices lists all services
-func (s *Scheduler) ListServices() []*Service {
+// ListServices lists the services of a namespace, or of all namespaces if
+// namespace is empty
+func (s *Scheduler) ListServices(namespace string) []*Service {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
 	services := make([]*Service, 0, len(s.services))
 	for _, svc := range s.services {
-		services = append(services, svc)
+		if namespace == "" || svc.Spec.Namespace == namespace {
+			services = append(services, svc)
+		}
 	}
 
 	return services
 }
 
+// findService finds a service by namespace and name. Caller must hold the
+// scheduler mutex.
+func (s *Scheduler) findService(namespace, name string) *Service {
+	namespace = container.NormalizeNamespace(namespace)
+	for _, svc := range s.services {
+		if svc.Name == name && container.NormalizeNamespace(svc.Spec.Namespace) == namespace {
+			return svc
+		}
+	}
+	return nil
+}
+
 // DeleteService deletes a service
-func (s *Scheduler) DeleteService(name string) error {
+func (s *Scheduler) DeleteService(namespace, name string) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	var serviceID string
-	for id, svc := range s.services {
-		if svc.Name == name {
-			serviceID = id
-			break
-		}
-	}
-
-	if serviceID == "" {
+	service := s.findService(namespace, name)
+	if service == nil {
 		return fmt.Errorf("service bulunamadı: %s", name)
 	}
+	serviceID := service.ID
 
 	delete(s.services, serviceID)
 
+	if s.store != nil {
+		if err := s.store.DeleteService(serviceID); err != nil {
+			s.logger.WithError(err).WithField("service_id", serviceID).Warn("Service kaydı silinemedi")
+		}
+	}
+
 	s.logger.WithFields(logrus.Fields{
 		"service_id": serviceID,
 		"name":       name,
 	}).Info("Service silindi")
 
+	s.emit(EventServiceDeleted, service.Spec.Namespace, name, service)
+
+	return nil
+}
+
+// DeleteServicesBySelector deletes every service of a namespace whose
+// selector contains all key/value pairs of the given selector and reports a
+// result per service
+func (s *Scheduler) DeleteServicesBySelector(namespace string, selector map[string]string) []BatchDeleteResult {
+	namespace = container.NormalizeNamespace(namespace)
+
+	s.mutex.RLock()
+	var names []string
+	for _, svc := range s.services {
+		if container.NormalizeNamespace(svc.Spec.Namespace) == namespace && matchesSelector(selector, svc.Spec.Selector) {
+			names = append(names, svc.Name)
+		}
+	}
+	s.mutex.RUnlock()
+
+	sort.Strings(names)
+	results := make([]BatchDeleteResult, 0, len(names))
+	for _, name := range names {
+		result := BatchDeleteResult{Name: name, Deleted: true}
+		if err := s.DeleteService(namespace, name); err != nil {
+			result.Deleted = false
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// createReplica creates and starts the container for replica index of a deployment
+func (s *Scheduler) createReplica(ctx context.Context, spec container.DeploymentSpec, index int) (*container.Container, error) {
+	containerSpec, err := s.buildReplicaSpec(spec, index)
+	if err != nil {
+		return nil, err
+	}
+
+	if !container.IsolatedNetworkMode(containerSpec.NetworkMode) {
+		if err := container.CheckHostPorts(containerSpec.Ports); err != nil {
+			return nil, fmt.Errorf("replica %d oluşturulamadı: %w", index, err)
+		}
+	}
+
+	if spec.SharedVolume != nil {
+		if err := s.containerManager.EnsureVolume(ctx, spec.SharedVolume.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := s.containerManager.Create(ctx, containerSpec)
+	if err != nil {
+		return nil, fmt.Errorf("container oluşturulamadı (replica %d): %w", index, err)
+	}
+
+	if err := s.containerManager.Start(ctx, c); err != nil {
+		s.removeReplicas(ctx, []*container.Container{c})
+		return nil, fmt.Errorf("container başlatılamadı (replica %d): %w", index, err)
+	}
+
+	// Ephemeral host ports are only known once the container is running
+	if spec.PublishMode == container.PublishModeProxy {
+		if err := s.resolveReplicaPorts(ctx, c); err != nil {
+			s.removeReplicas(ctx, []*container.Container{c})
+			return nil, fmt.Errorf("replica portları alınamadı (replica %d): %w", index, err)
+		}
+	}
+
+	c.Status = "running"
+	return c, nil
+}
+
+// buildReplicaSpec derives the container spec of replica index from a deployment spec
+func (s *Scheduler) buildReplicaSpec(spec container.DeploymentSpec, index int) (container.ContainerSpec, error) {
+	name, err := s.config.ReplicaName(spec.Name, index)
+	if err != nil {
+		return container.ContainerSpec{}, err
+	}
+
+	containerSpec := spec.Container
+	containerSpec.Name = name
+	containerSpec.Namespace = spec.Namespace
+
+	// Label replicas with their deployment
+	labels := make(map[string]string, len(containerSpec.Labels)+1)
+	for key, value := range containerSpec.Labels {
+		labels[key] = value
+	}
+	labels[container.DeploymentLabel] = spec.Name
+	containerSpec.Labels = labels
+
+	// Mount the shared volume; with a single writer only replica 0 may write
+	if spec.SharedVolume != nil {
+		volumes := make([]container.VolumeMount, 0, len(containerSpec.Volumes)+1)
+		volumes = append(volumes, containerSpec.Volumes...)
+		volumes = append(volumes, container.VolumeMount{
+			Source:      spec.SharedVolume.Name,
+			Destination: spec.SharedVolume.Destination,
+			ReadOnly:    spec.SharedVolume.SingleWriter && index > 0,
+		})
+		containerSpec.Volumes = volumes
+	}
+
+	portOffset := spec.PortOffset
+	if portOffset <= 0 {
+		portOffset = 1
+	}
+
+	// Assign unique ports for each replica; behind the proxy Docker picks
+	// ephemeral host ports instead
+	if containerSpec.Ports != nil {
+		ports := make(map[string]string)
+		for containerPort, baseHostPort := range containerSpec.Ports {
+			hostPort := fmt.Sprintf("%d", mustParseInt(baseHostPort)+index*portOffset)
+			if spec.PublishMode == container.PublishModeProxy {
+				hostPort = ""
+			}
+			ports[containerPort] = hostPort
+		}
+		containerSpec.Ports = ports
+	}
+
+	// Render per-replica environment values
+	env, err := renderReplicaEnv(containerSpec.Environment, replicaTemplateData{
+		Index: index,
+		Name:  containerSpec.Name,
+	})
+	if err != nil {
+		return containerSpec, fmt.Errorf("ortam değişkenleri işlenemedi (replica %d): %w", index, err)
+	}
+	containerSpec.Environment = env
+
+	return containerSpec, nil
+}
+
+// persistDeployment saves a deployment if a store is configured
+func (s *Scheduler) persistDeployment(deployment *Deployment) error {
+	if s.store == nil {
+		return nil
+	}
+	return s.store.SaveDeployment(deployment)
+}
+
+// persistServices saves every service if a store is configured. Caller must
+// hold the scheduler mutex.
+func (s *Scheduler) persistServices() error {
+	if s.store == nil {
+		return nil
+	}
+	for _, svc := range s.services {
+		if err := s.store.SaveService(svc); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // cleanupDeployment removes all containers in a deployment
 func (s *Scheduler) cleanupDeployment(ctx context.Context, deployment *Deployment) error {
 	for _, c := range deployment.Replicas {
-		if err := s.containerManager.Stop(ctx, c.ID); err != nil {
+		if isMissing(c) {
+			continue
+		}
+		if err := s.containerManager.Stop(ctx, c); err != nil {
 			s.logger.WithError(err).WithField("container_id", c.ID).Warn("Container durdurulamadı")
 		}
 		if err := s.containerManager.Remove(ctx, c.ID); err != nil {
 			s.logger.WithError(err).WithField("container_id", c.ID).Warn("Container silinemedi")
 		}
 	}
+	for _, id := range deployment.PreviousReplicas {
+		if err := s.containerManager.Remove(ctx, id); err != nil {
+			s.logger.WithError(err).WithField("container_id", id).Warn("Önceki replica silinemedi")
+		}
+	}
 	return nil
 }
 
+// refreshServiceEndpoints recomputes the endpoints of every service.
+// Caller must hold the scheduler mutex.
+func (s *Scheduler) refreshServiceEndpoints() {
+	for _, svc := range s.services {
+		svc.Endpoints = s.resolveEndpoints(svc.Spec)
+		svc.Status = endpointStatus(svc)
+		if len(svc.Endpoints) == 0 {
+			svc.Health = nil
+		}
+	}
+}
+
+// resolveEndpoints computes the endpoints of a service from the replicas of
+// the deployments it targets. Caller must hold the scheduler mutex.
+func (s *Scheduler) resolveEndpoints(spec container.ServiceSpec) []string {
+	endpoints := []string{}
+
+	for _, d := range s.deployments {
+		if !ServiceTargets(spec, d) {
+			continue
+		}
+
+		for _, replica := range d.Replicas {
+			// Stopped or missing replicas serve no traffic
+			if replica.Status != "running" {
+				continue
+			}
+			for _, port := range spec.Ports {
+				for containerPort, hostPort := range replica.Ports {
+					portNum, protocol, err := container.ParsePortKey(containerPort)
+					if err != nil || hostPort == "" {
+						continue
+					}
+					if portNum == port.TargetPort && protocol == container.ServicePortProtocol(port) {
+						endpoints = append(endpoints, fmt.Sprintf("localhost:%s", hostPort))
+					}
+				}
+			}
+		}
+	}
+
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+// ServiceTargets reports whether a service sends traffic to the replicas of
+// a deployment. A DeploymentRef takes precedence over the selector.
+func ServiceTargets(spec container.ServiceSpec, d *Deployment) bool {
+	if container.NormalizeNamespace(spec.Namespace) != d.Spec.Namespace {
+		return false
+	}
+	if spec.DeploymentRef != "" {
+		return d.Name == spec.DeploymentRef
+	}
+	return matchesSelector(spec.Selector, d.Spec.Container.Labels)
+}
+
+// ParseSelector parses a selector of the form "key=value,key2=value2"
+func ParseSelector(s string) (map[string]string, error) {
+	selector := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("geçersiz selector: %s", pair)
+		}
+		selector[parts[0]] = parts[1]
+	}
+	if len(selector) == 0 {
+		return nil, fmt.Errorf("selector boş olamaz")
+	}
+	return selector, nil
+}
+
+// matchesSelector reports whether labels contain every key/value in selector.
+// An empty selector matches nothing.
+func matchesSelector(selector, labels map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// replicaTemplateData is the data available to replica environment templates
+type replicaTemplateData struct {
+	Index int
+	Name  string
+}
+
+// renderReplicaEnv renders {{.Index}} and {{.Name}} templates in environment values
+func renderReplicaEnv(env map[string]string, data replicaTemplateData) (map[string]string, error) {
+	if env == nil {
+		return nil, nil
+	}
+
+	result := make(map[string]string, len(env))
+	for key, value := range env {
+		if !strings.Contains(value, "{{") {
+			result[key] = value
+			continue
+		}
+
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("geçersiz şablon (%s): %w", key, err)
+		}
+
+		var buf strings.Builder
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("şablon işlenemedi (%s): %w", key, err)
+		}
+		result[key] = buf.String()
+	}
+
+	return result, nil
+}
+
 // generateID generates a unique ID
 func generateID() string {
 	return fmt.Sprintf("%d", time.Now().UnixNano())
@@ -296,9 +1099,81 @@ func mustParseInt(s string) int {
 	return result
 }
 
-// validatePortConflicts checks for port conflicts in service ports
+// allocateNodePorts assigns a node port from the configured range to every
+// service port that does not request one, and validates requested ones.
+// Caller must hold the scheduler mutex.
+func (s *Scheduler) allocateNodePorts(ports []container.ServicePort) ([]container.ServicePort, error) {
+	used := s.usedHostPorts()
+	result := make([]container.ServicePort, len(ports))
+	copy(result, ports)
+
+	// Reserve explicitly requested node ports first
+	for _, port := range result {
+		if port.NodePort == 0 {
+			continue
+		}
+		if port.NodePort < s.config.NodePortMin || port.NodePort > s.config.NodePortMax {
+			return nil, fmt.Errorf("%w: %d (%d-%d)", ErrNodePortOutOfRange, port.NodePort, s.config.NodePortMin, s.config.NodePortMax)
+		}
+		if used[port.NodePort] {
+			return nil, fmt.Errorf("%w: %d", ErrNodePortInUse, port.NodePort)
+		}
+		used[port.NodePort] = true
+	}
+
+	next := s.config.NodePortMin
+	for i := range result {
+		if result[i].NodePort != 0 {
+			continue
+		}
+		for next <= s.config.NodePortMax && used[next] {
+			next++
+		}
+		if next > s.config.NodePortMax {
+			return nil, fmt.Errorf("%w (%d-%d)", ErrNodePortsExhausted, s.config.NodePortMin, s.config.NodePortMax)
+		}
+		result[i].NodePort = next
+		used[next] = true
+	}
+
+	return result, nil
+}
+
+// usedHostPorts returns every host port bound by services and deployment
+// replicas. Caller must hold the scheduler mutex.
+func (s *Scheduler) usedHostPorts() map[int]bool {
+	used := make(map[int]bool)
+	for _, svc := range s.services {
+		for _, port := range svc.Spec.Ports {
+			used[port.Port] = true
+			if port.NodePort != 0 {
+				used[port.NodePort] = true
+			}
+		}
+	}
+	for _, d := range s.deployments {
+		for _, replica := range d.Replicas {
+			for _, hostPort := range replica.Ports {
+				if p, err := strconv.Atoi(hostPort); err == nil {
+					used[p] = true
+				}
+			}
+		}
+		if d.Spec.PublishMode == container.PublishModeProxy {
+			for _, hostPort := range d.Spec.Container.Ports {
+				if p, err := strconv.Atoi(hostPort); err == nil {
+					used[p] = true
+				}
+			}
+		}
+	}
+	return used
+}
+
+// validatePortConflicts checks for port conflicts in service ports.
+// Ports only conflict when both the number and the protocol match.
 func (s *Scheduler) validatePortConflicts(ports []container.ServicePort) error {
-	usedPorts := make(map[int]bool)
+	usedPorts := make(map[string]bool)
 	
 	// Check for conflicts within the service itself
 	for _, port := range ports {
@@ -310,18 +1185,19 @@ func (s *Scheduler) validatePortConflicts(ports []container.ServicePort) error {
 			return fmt.Errorf("geçersiz hedef port numarası: %d (1-65535 arası olmalı)", port.TargetPort)
 		}
 		
-		if usedPorts[port.Port] {
-			return fmt.Errorf("port %d zaten kullanımda (aynı service içinde)", port.Port)
+		key := fmt.Sprintf("%d/%s", port.Port, container.ServicePortProtocol(port))
+		if usedPorts[key] {
+			return fmt.Errorf("port %s zaten kullanımda (aynı service içinde)", key)
 		}
-		usedPorts[port.Port] = true
+		usedPorts[key] = true
 	}
 	
 	// Check for conflicts with existing services
 	for _, existingService := range s.services {
 		for _, existingPort := range existingService.Spec.Ports {
 			for _, newPort := range ports {
-				if existingPort.Port == newPort.Port {
-					return fmt.Errorf("port %d zaten service '%s' tarafından kullanılıyor", newPort.Port, existingService.Name)
+				if existingPort.Port == newPort.Port && container.ServicePortProtocol(existingPort) == container.ServicePortProtocol(newPort) {
+					return fmt.Errorf("port %d/%s zaten service '%s' tarafından kullanılıyor", newPort.Port, container.ServicePortProtocol(newPort), existingService.Name)
 				}
 			}
 		}
@@ -337,8 +1213,13 @@ func (s *Scheduler) validatePortConflicts(ports []container.ServicePort) error {
 						continue // Skip invalid port strings
 					}
 					
+					_, protocol, err := container.ParsePortKey(containerPort)
+					if err != nil {
+						continue
+					}
+					
 					for _, newPort := range ports {
-						if hostPort == newPort.Port {
+						if hostPort == newPort.Port && protocol == container.ServicePortProtocol(newPort) {
 							return fmt.Errorf("port %d zaten deployment '%s' (container port %s) tarafından kullanılıyor", newPort.Port, deployment.Name, containerPort)
 						}
 					}
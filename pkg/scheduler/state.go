@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"fmt"
+
+	"orca/pkg/container"
+)
+
+// Deployment lifecycle states. A deployment is persisted as creating before
+// any container exists, becomes running once all replicas are up, and is
+// marked deleting before its containers are removed, so a crash at any step
+// leaves a record the reconcile loop can finish. A deployment whose
+// dependencies are not ready yet is waiting until the reconcile loop starts
+// creating it.
+const (
+	StatusWaiting  = "waiting"
+	StatusCreating = "creating"
+	StatusRunning  = "running"
+	StatusDegraded = "degraded"
+	StatusDeleting = "deleting"
+	StatusDeleted  = "deleted"
+)
+
+// deploymentTransitions lists the states each state may move to
+var deploymentTransitions = map[string][]string{
+	StatusWaiting:  {StatusCreating, StatusDeleting},
+	StatusCreating: {StatusRunning, StatusDegraded, StatusDeleting},
+	StatusRunning:  {StatusDegraded, StatusDeleting},
+	StatusDegraded: {StatusRunning, StatusDeleting},
+	StatusDeleting: {StatusDeleted},
+}
+
+// replicaSpec returns the spec replicas are created from. With a pinned
+// digest the image is referenced by its ID, which is already present locally.
+// Caller must hold the scheduler mutex for deployments known to the scheduler.
+func (d *Deployment) replicaSpec() container.DeploymentSpec {
+	spec := d.Spec
+	if d.ImageDigest != "" {
+		spec.Container.Image = d.ImageDigest
+		spec.Container.PullPolicy = container.PullNever
+	}
+	return spec
+}
+
+// replicaSpecAt returns the spec and pinned digest of the replica at index.
+// During a rollout the replicas not yet updated keep the previous spec.
+// Caller must hold the scheduler mutex.
+func (d *Deployment) replicaSpecAt(index int) (container.DeploymentSpec, string) {
+	if d.Rollout != nil && index >= d.Rollout.Updated {
+		return d.Rollout.previousReplicaSpec(), d.Rollout.PreviousImageDigest
+	}
+	return d.replicaSpec(), d.ImageDigest
+}
+
+// setStatus moves a deployment to status, rejecting transitions the state
+// machine does not allow. Setting the current status is a no-op. Caller must
+// hold the scheduler mutex for deployments known to the scheduler.
+func (d *Deployment) setStatus(status string) error {
+	if d.Status == status {
+		return nil
+	}
+	for _, next := range deploymentTransitions[d.Status] {
+		if next == status {
+			d.Status = status
+			return nil
+		}
+	}
+	return fmt.Errorf("geçersiz deployment durum geçişi: %s → %s (%s)", d.Status, status, d.Name)
+}
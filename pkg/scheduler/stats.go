@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"strings"
+)
+
+// DeploymentStats summarizes the health of all deployments from the live
+// state of their replicas
+type DeploymentStats struct {
+	Total int `json:"total"`
+	// Available deployments have all desired replicas running
+	Available int `json:"available"`
+	// Degraded deployments have some but not all desired replicas running
+	Degraded int `json:"degraded"`
+	// Failed deployments have no running replica
+	Failed int `json:"failed"`
+	// Progressing deployments are still being created or deleted
+	Progressing     int `json:"progressing"`
+	DesiredReplicas int `json:"desired_replicas"`
+	ReadyReplicas   int `json:"ready_replicas"`
+}
+
+// GetDeploymentStats counts deployments by health. Replica states come from a
+// single container listing rather than inspecting every replica.
+func (s *Scheduler) GetDeploymentStats(ctx context.Context) (*DeploymentStats, error) {
+	containers, err := s.containerManager.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	running := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		// Listed containers report Docker's status text, e.g. "Up 5 minutes"
+		// or "Up 5 minutes (unhealthy)" for containers with a health check
+		if strings.HasPrefix(c.Status, "Up") && !strings.Contains(c.Status, "Paused") &&
+			!strings.Contains(c.Status, "(unhealthy)") && !strings.Contains(c.Status, "(health: starting)") {
+			running[c.ID] = true
+		}
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	stats := &DeploymentStats{Total: len(s.deployments)}
+	for _, deployment := range s.deployments {
+		desired := deployment.Spec.Replicas
+		ready := 0
+		for _, replica := range deployment.Replicas {
+			if running[replica.ID] {
+				ready++
+			}
+		}
+		stats.DesiredReplicas += desired
+		stats.ReadyReplicas += ready
+
+		switch {
+		case deployment.Status == StatusWaiting || deployment.Status == StatusCreating || deployment.Status == StatusDeleting:
+			stats.Progressing++
+		case ready >= desired:
+			stats.Available++
+		case ready == 0:
+			stats.Failed++
+		default:
+			stats.Degraded++
+		}
+	}
+
+	return stats, nil
+}
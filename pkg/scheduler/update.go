@@ -0,0 +1,405 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"orca/pkg/container"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Rollout phases
+const (
+	// RolloutProgressing replicas are being replaced
+	RolloutProgressing = "progressing"
+	// RolloutPaused waits for the rollout to be promoted or aborted
+	RolloutPaused = "paused"
+)
+
+// ErrRolloutInProgress is returned when a deployment already has an
+// unfinished rollout
+var ErrRolloutInProgress = errors.New("deployment'ta tamamlanmamış bir rollout var")
+
+// ErrNoPausedRollout is returned when promoting or aborting a deployment
+// without a paused rollout
+var ErrNoPausedRollout = errors.New("deployment'ta bekleyen bir rollout yok")
+
+// Rollout is a spec update of a deployment. Replicas below index Updated run
+// the deployment's spec, the others still run PreviousSpec. A canary rollout
+// pauses once Canary replicas are updated.
+type Rollout struct {
+	Phase   string    `json:"phase"`
+	Canary  int       `json:"canary,omitempty"`
+	Updated int       `json:"updated"`
+	Started time.Time `json:"started"`
+	// PromoteAt is when a paused canary that stayed healthy is promoted
+	PromoteAt           *time.Time               `json:"promote_at,omitempty"`
+	PreviousSpec        container.DeploymentSpec `json:"previous_spec"`
+	PreviousImageDigest string                   `json:"previous_image_digest,omitempty"`
+}
+
+// RolloutStatus summarizes a rollout for the status endpoint
+type RolloutStatus struct {
+	Phase         string     `json:"phase"`
+	Canary        int        `json:"canary,omitempty"`
+	Updated       int        `json:"updated"`
+	Replicas      int        `json:"replicas"`
+	Image         string     `json:"image"`
+	PreviousImage string     `json:"previous_image"`
+	Started       time.Time  `json:"started"`
+	PromoteAt     *time.Time `json:"promote_at,omitempty"`
+}
+
+// UpdateOptions controls how UpdateDeployment rolls out a new spec
+type UpdateOptions struct {
+	// Canary updates only this many replicas, then pauses the rollout until
+	// it is promoted or aborted. Zero updates every replica.
+	Canary int
+	// CanaryPercent sets Canary as a percentage of the replicas, rounded up
+	CanaryPercent int
+	// AutoPromote promotes a paused canary once it has stayed healthy this long
+	AutoPromote time.Duration
+	// ForceRecreate replaces the replicas even if the spec is unchanged, e.g.
+	// to pick up a new image pushed under the same tag
+	ForceRecreate bool
+}
+
+// canaryCount returns the number of canary replicas of a deployment with
+// replicas replicas, or zero for a full rollout
+func (o UpdateOptions) canaryCount(replicas int) (int, error) {
+	canary := o.Canary
+	if o.CanaryPercent > 0 {
+		if o.CanaryPercent >= 100 {
+			return 0, fmt.Errorf("canary yüzdesi 1-99 arasında olmalıdır: %d", o.CanaryPercent)
+		}
+		canary = int(math.Ceil(float64(replicas) * float64(o.CanaryPercent) / 100))
+	}
+	if canary < 0 {
+		return 0, fmt.Errorf("canary sayısı negatif olamaz: %d", canary)
+	}
+	if canary > 0 && canary >= replicas {
+		return 0, fmt.Errorf("canary sayısı replica sayısından (%d) az olmalıdır: %d", replicas, canary)
+	}
+	if o.AutoPromote > 0 && canary == 0 {
+		return 0, fmt.Errorf("otomatik promote yalnızca canary ile kullanılabilir")
+	}
+	return canary, nil
+}
+
+// UpdateDeployment rolls out a new spec to an existing deployment, replacing
+// its replicas like a rolling restart. The replica count is kept; use
+// ScaleDeployment to change it. An unchanged spec is a no-op unless
+// opts.ForceRecreate is set. With a canary
+// only the first replicas are updated and the rollout pauses until
+// PromoteDeployment or AbortRollout.
+func (s *Scheduler) UpdateDeployment(ctx context.Context, spec container.DeploymentSpec, opts UpdateOptions) (*Deployment, error) {
+	spec.Namespace = container.NormalizeNamespace(spec.Namespace)
+
+	// Checked first so nothing is pulled for an update that cannot happen
+	s.mutex.RLock()
+	deployment, err := s.updatableDeployment(spec.Namespace, spec.Name)
+	unchanged := err == nil && !opts.ForceRecreate && specUnchanged(spec, deployment)
+	s.mutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	if unchanged {
+		return deployment, nil
+	}
+
+	// Resolving may pull the image, so it runs without the mutex held
+	digest := ""
+	if spec.PinDigest {
+		digest, err = s.containerManager.ResolveImage(ctx, spec.Container.Image, spec.Container.PullPolicy, spec.Container.Platform)
+		if err != nil {
+			return nil, fmt.Errorf("image digest'i çözümlenemedi: %w", err)
+		}
+	}
+
+	// The deployment may have changed while the image was resolved
+	s.mutex.Lock()
+	deployment, err = s.updatableDeployment(spec.Namespace, spec.Name)
+	if err != nil {
+		s.mutex.Unlock()
+		return nil, err
+	}
+
+	spec.Replicas = deployment.Spec.Replicas
+	if !opts.ForceRecreate && specUnchanged(spec, deployment) {
+		s.mutex.Unlock()
+		return deployment, nil
+	}
+	if err := s.checkDependencyCycle(spec); err != nil {
+		s.mutex.Unlock()
+		return nil, err
+	}
+
+	canary, err := opts.canaryCount(len(deployment.Replicas))
+	if err != nil {
+		s.mutex.Unlock()
+		return nil, err
+	}
+
+	deployment.Rollout = &Rollout{
+		Phase:               RolloutProgressing,
+		Canary:              canary,
+		Started:             time.Now(),
+		PreviousSpec:        deployment.Spec,
+		PreviousImageDigest: deployment.ImageDigest,
+	}
+	deployment.Spec = spec
+	deployment.ImageDigest = digest
+	if err := s.persistDeployment(deployment); err != nil {
+		deployment.Spec = deployment.Rollout.PreviousSpec
+		deployment.ImageDigest = deployment.Rollout.PreviousImageDigest
+		deployment.Rollout = nil
+		s.mutex.Unlock()
+		return nil, fmt.Errorf("deployment kaydedilemedi: %w", err)
+	}
+	replicaSpec := deployment.replicaSpec()
+	last := len(deployment.Replicas)
+	if canary > 0 {
+		last = canary
+	}
+	s.mutex.Unlock()
+
+	s.logger.WithFields(logrus.Fields{
+		"deployment": spec.Name,
+		"image":      spec.Container.Image,
+		"canary":     canary,
+		"force":      opts.ForceRecreate,
+	}).Info("Deployment güncelleniyor")
+
+	if err := s.advanceRollout(ctx, deployment, replicaSpec, last); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if canary > 0 {
+		if opts.AutoPromote > 0 {
+			promoteAt := time.Now().Add(opts.AutoPromote)
+			deployment.Rollout.PromoteAt = &promoteAt
+		}
+		deployment.Rollout.Phase = RolloutPaused
+		if err := s.persistDeployment(deployment); err != nil {
+			s.logger.WithError(err).WithField("deployment_id", deployment.ID).Warn("Deployment kaydedilemedi")
+		}
+		s.emit(EventDeploymentCanary, spec.Namespace, spec.Name, deployment)
+		s.logger.WithFields(logrus.Fields{
+			"deployment": spec.Name,
+			"canary":     canary,
+		}).Info("Canary replica'lar güncellendi, rollout promote bekliyor")
+		return deployment, nil
+	}
+
+	s.finishRollout(deployment)
+	s.emit(EventDeploymentUpdated, spec.Namespace, spec.Name, deployment)
+	s.logger.WithField("deployment", spec.Name).Info("Deployment güncellendi")
+	return deployment, nil
+}
+
+// updatableDeployment finds a deployment a spec update can be rolled out to:
+// running or degraded and without a rollout or restart in progress. Caller
+// must hold the scheduler mutex.
+func (s *Scheduler) updatableDeployment(namespace, name string) (*Deployment, error) {
+	deployment := s.findDeployment(namespace, name)
+	if deployment == nil {
+		return nil, fmt.Errorf("deployment bulunamadı: %s", name)
+	}
+	if deployment.busy() {
+		return nil, fmt.Errorf("%w: %s", ErrRolloutInProgress, name)
+	}
+	if deployment.Status != StatusRunning && deployment.Status != StatusDegraded {
+		return nil, fmt.Errorf("%s durumundaki deployment güncellenemez: %s", deployment.Status, name)
+	}
+	return deployment, nil
+}
+
+// specUnchanged reports whether spec, keeping the replica count of
+// deployment, equals its current spec. Caller must hold the scheduler mutex.
+func specUnchanged(spec container.DeploymentSpec, deployment *Deployment) bool {
+	spec.Replicas = deployment.Spec.Replicas
+	return reflect.DeepEqual(spec, deployment.Spec)
+}
+
+// PromoteDeployment rolls a paused canary out to the remaining replicas
+func (s *Scheduler) PromoteDeployment(ctx context.Context, namespace, name string) (*Deployment, error) {
+	s.mutex.Lock()
+	deployment, err := s.pausedRollout(namespace, name)
+	if err != nil {
+		s.mutex.Unlock()
+		return nil, err
+	}
+	deployment.Rollout.Phase = RolloutProgressing
+	deployment.Rollout.PromoteAt = nil
+	if err := s.persistDeployment(deployment); err != nil {
+		s.logger.WithError(err).WithField("deployment_id", deployment.ID).Warn("Deployment kaydedilemedi")
+	}
+	replicaSpec := deployment.replicaSpec()
+	last := len(deployment.Replicas)
+	s.mutex.Unlock()
+
+	if err := s.advanceRollout(ctx, deployment, replicaSpec, last); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.finishRollout(deployment)
+	s.emit(EventDeploymentPromoted, deployment.Spec.Namespace, name, deployment)
+	s.logger.WithField("deployment", name).Info("Canary rollout promote edildi")
+	return deployment, nil
+}
+
+// AbortRollout reverts the replicas updated by a paused rollout to the
+// previous spec, newest first, and restores that spec
+func (s *Scheduler) AbortRollout(ctx context.Context, namespace, name string) (*Deployment, error) {
+	s.mutex.Lock()
+	deployment, err := s.pausedRollout(namespace, name)
+	if err != nil {
+		s.mutex.Unlock()
+		return nil, err
+	}
+	deployment.Rollout.Phase = RolloutProgressing
+	deployment.Rollout.PromoteAt = nil
+	if err := s.persistDeployment(deployment); err != nil {
+		s.logger.WithError(err).WithField("deployment_id", deployment.ID).Warn("Deployment kaydedilemedi")
+	}
+	previous := deployment.Rollout.previousReplicaSpec()
+	updated := deployment.Rollout.Updated
+	s.mutex.Unlock()
+
+	// Going down from the newest keeps the updated replicas below Updated
+	for i := updated - 1; i >= 0; i-- {
+		err := s.rollingReplace(ctx, deployment, previous, i, i+1, func(index int) {
+			deployment.Rollout.Updated = index
+		})
+		if err != nil {
+			s.pauseRollout(deployment)
+			return nil, err
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	deployment.Spec = deployment.Rollout.PreviousSpec
+	deployment.ImageDigest = deployment.Rollout.PreviousImageDigest
+	s.finishRollout(deployment)
+	s.emit(EventDeploymentAborted, deployment.Spec.Namespace, name, deployment)
+	s.logger.WithField("deployment", name).Info("Rollout geri alındı")
+	return deployment, nil
+}
+
+// advanceRollout updates the replicas from Rollout.Updated up to last with
+// spec. A failure pauses the rollout so it can be promoted again or aborted.
+func (s *Scheduler) advanceRollout(ctx context.Context, deployment *Deployment, spec container.DeploymentSpec, last int) error {
+	s.mutex.RLock()
+	first := deployment.Rollout.Updated
+	s.mutex.RUnlock()
+
+	err := s.rollingReplace(ctx, deployment, spec, first, last, func(index int) {
+		deployment.Rollout.Updated = index + 1
+	})
+	if err != nil {
+		s.pauseRollout(deployment)
+		return err
+	}
+	return nil
+}
+
+// pauseRollout pauses a rollout that stopped on a failed replica
+func (s *Scheduler) pauseRollout(deployment *Deployment) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	deployment.Rollout.Phase = RolloutPaused
+	if err := s.persistDeployment(deployment); err != nil {
+		s.logger.WithError(err).WithField("deployment_id", deployment.ID).Warn("Deployment kaydedilemedi")
+	}
+}
+
+// finishRollout clears a completed rollout. Caller must hold the scheduler
+// mutex.
+func (s *Scheduler) finishRollout(deployment *Deployment) {
+	deployment.Rollout = nil
+	if len(deployment.Replicas) >= deployment.Spec.Replicas {
+		if err := deployment.setStatus(StatusRunning); err != nil {
+			s.logger.WithError(err).Warn("Deployment durumu güncellenemedi")
+		}
+	}
+	if err := s.commitDeployment(deployment); err != nil {
+		s.logger.WithError(err).WithField("deployment_id", deployment.ID).Warn("Deployment kaydedilemedi")
+	}
+}
+
+// pausedRollout finds a deployment whose rollout is paused. Caller must hold
+// the scheduler mutex.
+func (s *Scheduler) pausedRollout(namespace, name string) (*Deployment, error) {
+	deployment := s.findDeployment(namespace, name)
+	if deployment == nil {
+		return nil, fmt.Errorf("deployment bulunamadı: %s", name)
+	}
+	if deployment.Rollout == nil || deployment.Rollout.Phase != RolloutPaused {
+		return nil, fmt.Errorf("%w: %s", ErrNoPausedRollout, name)
+	}
+	return deployment, nil
+}
+
+// autoPromote promotes a paused canary whose promote time has passed if
+// every canary replica is running and has not restarted
+func (s *Scheduler) autoPromote(ctx context.Context, deployment *Deployment) {
+	s.mutex.RLock()
+	rollout := deployment.Rollout
+	due := rollout != nil && rollout.Phase == RolloutPaused && rollout.PromoteAt != nil && time.Now().After(*rollout.PromoteAt)
+	var canaries []*container.Container
+	if due {
+		canaries = make([]*container.Container, min(rollout.Updated, len(deployment.Replicas)))
+		copy(canaries, deployment.Replicas)
+	}
+	namespace, name := deployment.Spec.Namespace, deployment.Name
+	s.mutex.RUnlock()
+	if !due {
+		return
+	}
+
+	states, errs := s.probeReplicas(ctx, canaries)
+	for i := range canaries {
+		if errs[i] != nil || !states[i].Ready() || states[i].RestartCount > 0 {
+			s.logger.WithField("deployment", name).Debug("Canary sağlıklı değil, otomatik promote bekletiliyor")
+			return
+		}
+	}
+
+	if _, err := s.PromoteDeployment(ctx, namespace, name); err != nil {
+		s.logger.WithError(err).WithField("deployment", name).Warn("Canary otomatik promote edilemedi")
+	}
+}
+
+// previousReplicaSpec returns the spec the replicas not yet updated run
+func (r *Rollout) previousReplicaSpec() container.DeploymentSpec {
+	previous := Deployment{Spec: r.PreviousSpec, ImageDigest: r.PreviousImageDigest}
+	return previous.replicaSpec()
+}
+
+// status summarizes the rollout of deployment
+func (r *Rollout) status(deployment *Deployment) *RolloutStatus {
+	return &RolloutStatus{
+		Phase:         r.Phase,
+		Canary:        r.Canary,
+		Updated:       r.Updated,
+		Replicas:      len(deployment.Replicas),
+		Image:         deployment.Spec.Container.Image,
+		PreviousImage: r.PreviousSpec.Container.Image,
+		Started:       r.Started,
+		PromoteAt:     r.PromoteAt,
+	}
+}
@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"orca/pkg/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WebhookDispatcher posts scheduler events to a webhook URL asynchronously
+type WebhookDispatcher struct {
+	url     string
+	retries int
+	client  *http.Client
+	logger  *logrus.Logger
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher
+func NewWebhookDispatcher(cfg config.NotificationsConfig, logger *logrus.Logger) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		url:     cfg.WebhookURL,
+		retries: cfg.Retries,
+		client:  &http.Client{Timeout: cfg.Timeout},
+		logger:  logger,
+	}
+}
+
+// Dispatch serializes the event and sends it in the background. It is
+// suitable for use as an EventHandler.
+func (d *WebhookDispatcher) Dispatch(event Event) {
+	// Serialize now so later changes to the object are not reflected
+	payload, err := json.Marshal(event)
+	if err != nil {
+		d.logger.WithError(err).WithField("event", event.Type).Warn("Webhook event serialize edilemedi")
+		return
+	}
+
+	go d.send(event.Type, payload)
+}
+
+// send posts the payload, retrying with exponential backoff
+func (d *WebhookDispatcher) send(eventType string, payload []byte) {
+	backoff := time.Second
+	var err error
+
+	for attempt := 0; attempt <= d.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		if err = d.post(payload); err == nil {
+			d.logger.WithField("event", eventType).Debug("Webhook gönderildi")
+			return
+		}
+	}
+
+	d.logger.WithError(err).WithFields(logrus.Fields{
+		"event":   eventType,
+		"url":     d.url,
+		"retries": d.retries,
+	}).Warn("Webhook gönderilemedi")
+}
+
+// post sends a single webhook request
+func (d *WebhookDispatcher) post(payload []byte) error {
+	resp, err := d.client.Post(d.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook HTTP %d döndürdü", resp.StatusCode)
+	}
+
+	return nil
+}
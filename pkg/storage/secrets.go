@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"orca/pkg/container"
+
+	"github.com/sirupsen/logrus"
+)
+
+// secretPath returns the file a secret is stored in. The namespace and name
+// are validated first, so the path never leaves the secrets directory.
+func (s *Storage) secretPath(namespace, name string) (string, error) {
+	if err := container.ValidateNamespace(namespace); err != nil {
+		return "", err
+	}
+	if err := container.ValidateSecretName(name); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dataDir, "secrets", fmt.Sprintf("%s.%s.json", container.NormalizeNamespace(namespace), name)), nil
+}
+
+// SaveSecret saves a secret to storage. Secret files are only readable by
+// the orchestrator.
+func (s *Storage) SaveSecret(secret *container.Secret) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	data, err := json.MarshalIndent(secret, "", "  ")
+	if err != nil {
+		return fmt.Errorf("secret serialize edilemedi: %w", err)
+	}
+
+	filePath, err := s.secretPath(secret.Namespace, secret.Name)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filePath, data, 0600); err != nil {
+		return fmt.Errorf("secret kaydedilemedi: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"name":      secret.Name,
+		"namespace": secret.Namespace,
+	}).Debug("Secret kaydedildi")
+
+	return nil
+}
+
+// LoadSecret loads a secret, including its data, from storage
+func (s *Storage) LoadSecret(namespace, name string) (*container.Secret, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	filePath, err := s.secretPath(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", container.ErrSecretNotFound, name)
+		}
+		return nil, fmt.Errorf("secret okunamadı: %w", err)
+	}
+
+	var secret container.Secret
+	if err := json.Unmarshal(data, &secret); err != nil {
+		return nil, fmt.Errorf("secret deserialize edilemedi: %w", err)
+	}
+
+	return &secret, nil
+}
+
+// ListSecrets returns the secrets of a namespace, or of every namespace if
+// namespace is empty, without their data
+func (s *Storage) ListSecrets(namespace string) ([]*container.Secret, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	secretsDir := filepath.Join(s.dataDir, "secrets")
+	files, err := ioutil.ReadDir(secretsDir)
+	if err != nil {
+		return nil, fmt.Errorf("secrets dizini okunamadı: %w", err)
+	}
+
+	secrets := []*container.Secret{}
+	for _, file := range files {
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(secretsDir, file.Name()))
+		if err != nil {
+			s.logger.WithError(err).WithField("file", file.Name()).Warn("Secret dosyası okunamadı")
+			continue
+		}
+
+		var secret container.Secret
+		if err := json.Unmarshal(data, &secret); err != nil {
+			s.logger.WithError(err).WithField("file", file.Name()).Warn("Secret deserialize edilemedi")
+			continue
+		}
+		if namespace != "" && container.NormalizeNamespace(secret.Namespace) != namespace {
+			continue
+		}
+		secrets = append(secrets, secret.Redacted())
+	}
+
+	sort.Slice(secrets, func(i, j int) bool {
+		if secrets[i].Namespace != secrets[j].Namespace {
+			return secrets[i].Namespace < secrets[j].Namespace
+		}
+		return secrets[i].Name < secrets[j].Name
+	})
+	return secrets, nil
+}
+
+// DeleteSecret deletes a secret from storage
+func (s *Storage) DeleteSecret(namespace, name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	filePath, err := s.secretPath(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filePath); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", container.ErrSecretNotFound, name)
+		}
+		return fmt.Errorf("secret silinemedi: %w", err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"name":      name,
+		"namespace": namespace,
+	}).Debug("Secret silindi")
+
+	return nil
+}
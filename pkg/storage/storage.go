@@ -36,6 +36,11 @@ func NewStorage(dataDir string, logger *logrus.Logger) (*Storage, error) {
 		}
 	}
 
+	// Secrets are readable by the orchestrator only
+	if err := os.MkdirAll(filepath.Join(dataDir, "secrets"), 0700); err != nil {
+		return nil, fmt.Errorf("alt dizin oluşturulamadı (secrets): %w", err)
+	}
+
 	return &Storage{
 		dataDir: dataDir,
 		logger:  logger,
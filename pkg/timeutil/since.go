@@ -0,0 +1,50 @@
+// Package timeutil parses the human time filters accepted by the API and CLI.
+package timeutil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// dayPattern matches day components such as 1d or 1.5d, which
+// time.ParseDuration does not know
+var dayPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)d`)
+
+// ParseDuration parses a duration like time.ParseDuration and additionally
+// accepts days (d) as 24 hours, e.g. 1d or 1d12h
+func ParseDuration(value string) (time.Duration, error) {
+	var convErr error
+	expanded := dayPattern.ReplaceAllStringFunc(value, func(match string) string {
+		days, err := strconv.ParseFloat(match[:len(match)-1], 64)
+		if err != nil {
+			convErr = err
+			return match
+		}
+		return strconv.FormatFloat(days*24, 'f', -1, 64) + "h"
+	})
+	if convErr != nil {
+		return 0, fmt.Errorf("geçersiz süre: %s", value)
+	}
+
+	d, err := time.ParseDuration(expanded)
+	if err != nil {
+		return 0, fmt.Errorf("geçersiz süre: %s", value)
+	}
+	return d, nil
+}
+
+// ParseSince parses a time filter, either an RFC3339 timestamp or a positive
+// duration before now such as 30m, 2h or 1d
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+
+	d, err := ParseDuration(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("geçersiz since değeri: %s (RFC3339 zaman damgası veya 10m, 2h, 1d gibi bir süre olmalı)", value)
+	}
+	return now.Add(-d), nil
+}